@@ -18,6 +18,25 @@ const (
 	Deprecated       StabilityLevel = "Deprecated"
 )
 
+const (
+	// FeatureOwnerAnnotation is the annotation holding the team or person owning a Feature.
+	FeatureOwnerAnnotation = "core.tanzu.vmware.com/feature-owner"
+	// FeatureDocsURLAnnotation is the annotation holding the URL of the documentation of a Feature.
+	FeatureDocsURLAnnotation = "core.tanzu.vmware.com/feature-docs-url"
+	// FeatureDependenciesAnnotation is the annotation holding the comma separated names of the Features a Feature
+	// depends on.
+	FeatureDependenciesAnnotation = "core.tanzu.vmware.com/feature-dependencies"
+)
+
+const (
+	// FeatureComponentLabel is the label holding the component a Feature belongs to.
+	FeatureComponentLabel = "core.tanzu.vmware.com/feature-component"
+	// FeatureOwnerTeamLabel is the label holding the team owning a Feature.
+	FeatureOwnerTeamLabel = "core.tanzu.vmware.com/feature-owner-team"
+	// FeatureCategoryLabel is the label holding the category a Feature is grouped in.
+	FeatureCategoryLabel = "core.tanzu.vmware.com/feature-category"
+)
+
 // FeatureSpec defines the desired state of Feature
 type FeatureSpec struct {
 	// Description of the feature.
@@ -0,0 +1,118 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FeatureActivationRequestSpec defines the desired state of FeatureActivationRequest
+type FeatureActivationRequestSpec struct {
+	// Feature is the name of the Feature whose activation is requested.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength:=1
+	Feature string `json:"feature"`
+	// Activate is the requested activation state of the Feature.
+	Activate bool `json:"activate,omitempty"`
+	// Justification explains why the change is requested.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength:=1
+	Justification string `json:"justification"`
+	// Requester is the user who created the request. It is set by the webhook.
+	// +optional
+	Requester string `json:"requester,omitempty"`
+	// Decision is the decision of an approver on the request. Once set, cannot be changed.
+	// +optional
+	Decision *FeatureActivationDecision `json:"decision,omitempty"`
+}
+
+// FeatureActivationDecisionType is the decision of an approver on a FeatureActivationRequest.
+type FeatureActivationDecisionType string
+
+const (
+	FeatureActivationApproved FeatureActivationDecisionType = "Approved"
+	FeatureActivationRejected FeatureActivationDecisionType = "Rejected"
+)
+
+// FeatureActivationDecision records the decision of an approver on a FeatureActivationRequest.
+type FeatureActivationDecision struct {
+	// Type is the decision.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Approved;Rejected
+	// - Approved: the FeatureGate gating the Feature is changed as requested.
+	// - Rejected: the request is closed without changing the FeatureGate.
+	Type FeatureActivationDecisionType `json:"type"`
+	// Reason explains the decision.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// PermanentlyVoidAllSupportGuarantees is the acknowledgement of the approver that approving the request
+	// permanently voids all support guarantees for this environment. Approving a request whose change voids the
+	// warranty, as the stability level of the Feature dictates, requires it to be true.
+	PermanentlyVoidAllSupportGuarantees bool `json:"permanentlyVoidAllSupportGuarantees,omitempty"`
+	// Approver is the user who decided on the request. It is set by the webhook.
+	// +optional
+	Approver string `json:"approver,omitempty"`
+}
+
+// FeatureActivationRequestPhase is the phase of a FeatureActivationRequest.
+type FeatureActivationRequestPhase string
+
+const (
+	FeatureActivationRequestPending  FeatureActivationRequestPhase = "Pending"
+	FeatureActivationRequestRejected FeatureActivationRequestPhase = "Rejected"
+	FeatureActivationRequestApplied  FeatureActivationRequestPhase = "Applied"
+	FeatureActivationRequestFailed   FeatureActivationRequestPhase = "Failed"
+)
+
+// FeatureActivationRequestStatus defines the observed state of FeatureActivationRequest
+type FeatureActivationRequestStatus struct {
+	// Phase is the phase of the request.
+	// +kubebuilder:validation:Enum=Pending;Rejected;Applied;Failed
+	// - Pending: the request waits for a decision.
+	// - Rejected: the request was rejected.
+	// - Applied: the request was approved and the FeatureGate gating the Feature was changed.
+	// - Failed: the request was approved, but the FeatureGate gating the Feature could not be changed.
+	// +optional
+	Phase FeatureActivationRequestPhase `json:"phase,omitempty"`
+	// FeatureGate is the name of the FeatureGate changed for the request.
+	// +optional
+	FeatureGate string `json:"featureGate,omitempty"`
+	// Message represents the reason for phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Feature",type=string,JSONPath=.spec.feature
+// +kubebuilder:printcolumn:name="Activate",type=boolean,JSONPath=.spec.activate
+// +kubebuilder:printcolumn:name="Requester",type=string,JSONPath=.spec.requester
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=.status.phase
+
+// FeatureActivationRequest is the Schema for the featureactivationrequests API. It requests a change to the
+// activation of a Feature, which is only made once an approver approves the request.
+type FeatureActivationRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the requested change and the decision on it.
+	Spec FeatureActivationRequestSpec `json:"spec,omitempty"`
+	// Status reports the outcome of the request.
+	Status FeatureActivationRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FeatureActivationRequestList contains a list of FeatureActivationRequest
+type FeatureActivationRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FeatureActivationRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FeatureActivationRequest{}, &FeatureActivationRequestList{})
+}
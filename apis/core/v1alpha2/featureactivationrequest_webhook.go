@@ -0,0 +1,279 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var featureactivationrequestlog = logf.Log.WithName("featureactivationrequest-resource").WithValues("apigroup", "core")
+
+// ApprovalPermission is the permission a user must hold to decide on a FeatureActivationRequest. The webhook checks
+// it with a SubjectAccessReview on the Feature named by the request, so approvers can be limited to some Features.
+type ApprovalPermission struct {
+	Verb     string
+	Group    string
+	Resource string
+}
+
+// DefaultApprovalPermission is the approve verb on Features.
+var DefaultApprovalPermission = ApprovalPermission{Verb: "approve", Group: GroupVersion.Group, Resource: "features"}
+
+// featureActivationRequestWebhook records who requested and who decided on a FeatureActivationRequest, and validates
+// the decision.
+type featureActivationRequestWebhook struct {
+	client     client.Client
+	permission ApprovalPermission
+}
+
+// SetupWebhookWithManager adds the webhook to the manager. Only users holding DefaultApprovalPermission can decide on
+// requests.
+func (r *FeatureActivationRequest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return r.SetupWebhookWithManagerAndPermission(mgr, DefaultApprovalPermission)
+}
+
+// SetupWebhookWithManagerAndPermission adds the webhook to the manager. Only users holding permission can decide on
+// requests.
+func (r *FeatureActivationRequest) SetupWebhookWithManagerAndPermission(mgr ctrl.Manager, permission ApprovalPermission) error {
+	s, err := getScheme()
+	if err != nil {
+		return err
+	}
+
+	c, err := client.New(mgr.GetConfig(), client.Options{Scheme: s})
+	if err != nil {
+		return err
+	}
+
+	w := &featureActivationRequestWebhook{client: c, permission: permission}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+//+kubebuilder:webhook:verbs=create;update,path=/mutate-core-tanzu-vmware-com-v1alpha2-featureactivationrequest,mutating=true,failurePolicy=fail,groups=core.tanzu.vmware.com,resources=featureactivationrequests,versions=v1alpha2,name=mfeatureactivationrequest.kb.io,sideEffects=None,admissionReviewVersions=v1
+//+kubebuilder:webhook:verbs=create;update,path=/validate-core-tanzu-vmware-com-v1alpha2-featureactivationrequest,mutating=false,failurePolicy=fail,groups=core.tanzu.vmware.com,resources=featureactivationrequests,versions=v1alpha2,name=vfeatureactivationrequest.kb.io,sideEffects=None,admissionReviewVersions=v1
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+var _ admission.CustomDefaulter = &featureActivationRequestWebhook{}
+
+// Default records the user creating a request as its requester, and the user deciding on a request as its approver.
+func (w *featureActivationRequestWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	r, ok := obj.(*FeatureActivationRequest)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected FeatureActivationRequest object, but got object of type %T", obj))
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	featureactivationrequestlog.Info("default", "name", r.Name, "operation", req.Operation)
+
+	switch req.Operation {
+	case admissionv1.Create:
+		r.Spec.Requester = req.UserInfo.Username
+	case admissionv1.Update:
+		oldObj := &FeatureActivationRequest{}
+		if err := json.Unmarshal(req.OldObject.Raw, oldObj); err != nil {
+			return apierrors.NewBadRequest(fmt.Sprintf("could not decode the FeatureActivationRequest being updated: %v", err))
+		}
+		if oldObj.Spec.Decision == nil && r.Spec.Decision != nil {
+			r.Spec.Decision.Approver = req.UserInfo.Username
+		}
+	}
+	return nil
+}
+
+var _ admission.CustomValidator = &featureActivationRequestWebhook{}
+
+// ValidateCreate validates the requested change. A request cannot be created with a decision.
+func (w *featureActivationRequestWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	r, ok := obj.(*FeatureActivationRequest)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected FeatureActivationRequest object, but got object of type %T", obj))
+	}
+	featureactivationrequestlog.Info("validate create", "name", r.Name)
+
+	var allErrors field.ErrorList
+	if r.Spec.Decision != nil {
+		allErrors = append(allErrors, field.Forbidden(field.NewPath("spec").Child("decision"),
+			"a FeatureActivationRequest cannot be created with a decision"))
+	}
+	allErrors = append(allErrors, r.validateRequestedChange(ctx, w.client)...)
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("FeatureActivationRequest").GroupKind(), r.Name, allErrors)
+}
+
+// ValidateUpdate validates that the requested change is not changed, and that a decision is made once, by a user
+// holding the approval permission. Approving a change that voids the warranty must be acknowledged by the approver.
+func (w *featureActivationRequestWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	r, ok := newObj.(*FeatureActivationRequest)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected FeatureActivationRequest object, but got object of type %T", newObj))
+	}
+	old, ok := oldObj.(*FeatureActivationRequest)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected FeatureActivationRequest object, but got object of type %T", oldObj))
+	}
+	featureactivationrequestlog.Info("validate update", "name", r.Name)
+
+	specPath := field.NewPath("spec")
+	var allErrors field.ErrorList
+	allErrors = append(allErrors, apivalidation.ValidateImmutableField(r.Spec.Feature, old.Spec.Feature, specPath.Child("feature"))...)
+	allErrors = append(allErrors, apivalidation.ValidateImmutableField(r.Spec.Activate, old.Spec.Activate, specPath.Child("activate"))...)
+	allErrors = append(allErrors, apivalidation.ValidateImmutableField(r.Spec.Justification, old.Spec.Justification, specPath.Child("justification"))...)
+	allErrors = append(allErrors, apivalidation.ValidateImmutableField(r.Spec.Requester, old.Spec.Requester, specPath.Child("requester"))...)
+
+	if old.Spec.Decision != nil {
+		allErrors = append(allErrors, apivalidation.ValidateImmutableField(r.Spec.Decision, old.Spec.Decision, specPath.Child("decision"))...)
+	} else if r.Spec.Decision != nil {
+		req, err := admission.RequestFromContext(ctx)
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		allowed, err := w.canDecide(ctx, req.UserInfo, r.Spec.Feature)
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		if !allowed {
+			return apierrors.NewForbidden(GroupVersion.WithResource("featureactivationrequests").GroupResource(), r.Name,
+				fmt.Errorf("user %q cannot decide on FeatureActivationRequests for Feature %s, as it is not allowed to %s %s.%s %s",
+					req.UserInfo.Username, r.Spec.Feature, w.permission.Verb, w.permission.Resource, w.permission.Group, r.Spec.Feature))
+		}
+		allErrors = append(allErrors, r.validateDecision(ctx, w.client, req.UserInfo.Username)...)
+	}
+
+	if len(allErrors) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("FeatureActivationRequest").GroupKind(), r.Name, allErrors)
+}
+
+// ValidateDelete implements admission.CustomValidator so a webhook will be registered for the type
+func (w *featureActivationRequestWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// canDecide reports whether the user holds the approval permission on the Feature, with a SubjectAccessReview.
+func (w *featureActivationRequestWebhook) canDecide(ctx context.Context, user authenticationv1.UserInfo, featureName string) (bool, error) {
+	return userCan(ctx, w.client, user, authorizationv1.ResourceAttributes{
+		Verb:     w.permission.Verb,
+		Group:    w.permission.Group,
+		Resource: w.permission.Resource,
+		Name:     featureName,
+	})
+}
+
+// userCan reports whether the user is allowed the action described by attributes, with a SubjectAccessReview.
+func userCan(ctx context.Context, c client.Client, user authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}
+	if err := c.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("could not review access of user %q: %w", user.Username, err)
+	}
+	return review.Status.Allowed, nil
+}
+
+// validateRequestedChange checks the Feature exists, and that its stability level allows the requested change. Stable
+// Features are checked first: they are immutable too, but changes are only requested to be approved, and Stable
+// Features do not require approval. Other immutable Features cannot be requested to change.
+func (r *FeatureActivationRequest) validateRequestedChange(ctx context.Context, c client.Client) field.ErrorList {
+	var allErrors field.ErrorList
+
+	featurePath := field.NewPath("spec").Child("feature")
+	feature := &Feature{}
+	if err := c.Get(ctx, client.ObjectKey{Name: r.Spec.Feature}, feature); err != nil {
+		if apierrors.IsNotFound(err) {
+			allErrors = append(allErrors, field.NotFound(featurePath, r.Spec.Feature))
+			return allErrors
+		}
+		allErrors = append(allErrors, field.InternalError(featurePath, err))
+		return allErrors
+	}
+
+	switch {
+	case feature.Spec.Stability == Stable:
+		allErrors = append(allErrors, field.Invalid(featurePath, r.Spec.Feature,
+			"Stable features do not require approval"))
+	case GetPolicyForStabilityLevel(feature.Spec.Stability).Immutable:
+		allErrors = append(allErrors, field.Invalid(featurePath, r.Spec.Feature,
+			fmt.Sprintf("cannot request a change of immutable feature %s", r.Spec.Feature)))
+	}
+	return allErrors
+}
+
+// validateDecision validates a new decision on the request by the user.
+func (r *FeatureActivationRequest) validateDecision(ctx context.Context, c client.Client, username string) field.ErrorList {
+	var allErrors field.ErrorList
+
+	decisionPath := field.NewPath("spec").Child("decision")
+	if r.Spec.Decision.Approver != username {
+		allErrors = append(allErrors, field.Invalid(decisionPath.Child("approver"), r.Spec.Decision.Approver,
+			"must be the user deciding on the request"))
+	}
+	if r.Spec.Decision.Type != FeatureActivationApproved {
+		return allErrors
+	}
+
+	feature := &Feature{}
+	if err := c.Get(ctx, client.ObjectKey{Name: r.Spec.Feature}, feature); err != nil {
+		if apierrors.IsNotFound(err) {
+			allErrors = append(allErrors, field.NotFound(field.NewPath("spec").Child("feature"), r.Spec.Feature))
+			return allErrors
+		}
+		allErrors = append(allErrors, field.InternalError(field.NewPath("spec").Child("feature"), err))
+		return allErrors
+	}
+	if r.VoidsWarranty(feature) && !r.Spec.Decision.PermanentlyVoidAllSupportGuarantees {
+		allErrors = append(allErrors, field.Required(decisionPath.Child("permanentlyVoidAllSupportGuarantees"),
+			fmt.Sprintf("the stability level of feature %s indicates that it should not be activated in production "+
+				"environments. To approve the request, you must agree to permanently void all support guarantees for "+
+				"this environment by setting decision.permanentlyVoidAllSupportGuarantees to true", r.Spec.Feature)))
+	}
+	return allErrors
+}
+
+// VoidsWarranty reports whether the change requested for the Feature permanently voids all support guarantees, as the
+// stability level of the Feature dictates.
+func (r *FeatureActivationRequest) VoidsWarranty(feature *Feature) bool {
+	policy := GetPolicyForStabilityLevel(feature.Spec.Stability)
+	return policy.VoidsWarranty && policy.DefaultActivation != r.Spec.Activate
+}
+
+// RequiresApproval reports whether the change requested for the Feature must be approved before it is applied, which
+// is the case for the changes voiding the warranty and those of Features that are not Stable.
+func (r *FeatureActivationRequest) RequiresApproval(feature *Feature) bool {
+	return r.VoidsWarranty(feature) || feature.Spec.Stability != Stable
+}
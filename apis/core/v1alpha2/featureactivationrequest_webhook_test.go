@@ -0,0 +1,345 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// approverClient allows the approver user to approve requests for the Features in features, as the API server would
+// answer a SubjectAccessReview.
+type approverClient struct {
+	client.Client
+	approver string
+	features map[string]bool
+	reviews  []authorizationv1.SubjectAccessReviewSpec
+}
+
+func (c *approverClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	review, ok := obj.(*authorizationv1.SubjectAccessReview)
+	if !ok {
+		return c.Client.Create(ctx, obj, opts...)
+	}
+	c.reviews = append(c.reviews, review.Spec)
+	attrs := review.Spec.ResourceAttributes
+	review.Status.Allowed = review.Spec.User == c.approver && attrs.Verb == "approve" &&
+		attrs.Group == GroupVersion.Group && attrs.Resource == "features" && c.features[attrs.Name]
+	return nil
+}
+
+func newApproverClient(t *testing.T) *approverClient {
+	s, err := getScheme()
+	if err != nil {
+		t.Fatalf("unable to get scheme: %v", err)
+	}
+	feature := func(name string, stability StabilityLevel) *Feature {
+		return &Feature{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: FeatureSpec{Stability: stability}}
+	}
+	return &approverClient{
+		Client: fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(
+			feature("preview", TechnicalPreview),
+			feature("experiment", Experimental),
+			feature("stable", Stable),
+			feature("deprecated", Deprecated),
+			feature("immutable", immutableStability),
+		).Build(),
+		approver: "admin",
+		features: map[string]bool{"preview": true, "experiment": true},
+	}
+}
+
+func admissionContext(t *testing.T, operation admissionv1.Operation, username string, old runtime.Object) context.Context {
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: operation,
+		UserInfo:  authenticationv1.UserInfo{Username: username},
+	}}
+	if old != nil {
+		raw, err := json.Marshal(old)
+		if err != nil {
+			t.Fatalf("unable to encode object: %v", err)
+		}
+		req.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	return admission.NewContextWithRequest(context.Background(), req)
+}
+
+func activationRequest(feature string, activate bool, decision *FeatureActivationDecision) *FeatureActivationRequest {
+	return &FeatureActivationRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: feature + "-request"},
+		Spec: FeatureActivationRequestSpec{
+			Feature:       feature,
+			Activate:      activate,
+			Justification: "needed",
+			Requester:     "dev",
+			Decision:      decision,
+		},
+	}
+}
+
+func TestFeatureActivationRequestDefault(t *testing.T) {
+	w := &featureActivationRequestWebhook{client: newApproverClient(t), permission: DefaultApprovalPermission}
+
+	r := activationRequest("preview", true, nil)
+	r.Spec.Requester = "someone-else"
+	if err := w.Default(admissionContext(t, admissionv1.Create, "dev", nil), r); err != nil {
+		t.Fatalf("unable to default request: %v", err)
+	}
+	if r.Spec.Requester != "dev" {
+		t.Errorf("got requester: %q, want: %q", r.Spec.Requester, "dev")
+	}
+
+	old := r.DeepCopy()
+	r.Spec.Decision = &FeatureActivationDecision{Type: FeatureActivationApproved}
+	if err := w.Default(admissionContext(t, admissionv1.Update, "admin", old), r); err != nil {
+		t.Fatalf("unable to default request: %v", err)
+	}
+	if r.Spec.Decision.Approver != "admin" {
+		t.Errorf("got approver: %q, want: %q", r.Spec.Decision.Approver, "admin")
+	}
+
+	// The approver of a decision already made is kept.
+	old = r.DeepCopy()
+	r.Labels = map[string]string{"team": "platform"}
+	if err := w.Default(admissionContext(t, admissionv1.Update, "someone-else", old), r); err != nil {
+		t.Fatalf("unable to default request: %v", err)
+	}
+	if r.Spec.Decision.Approver != "admin" {
+		t.Errorf("got approver: %q, want: %q", r.Spec.Decision.Approver, "admin")
+	}
+}
+
+// immutableStability is a stability level other than Stable whose policy forbids toggling its Features.
+const immutableStability StabilityLevel = "Immutable Preview"
+
+func TestFeatureActivationRequestValidateCreate(t *testing.T) {
+	StabilityPolicies[immutableStability] = Policy{Immutable: true, Discoverable: true}
+	defer delete(StabilityPolicies, immutableStability)
+
+	testCases := []struct {
+		description string
+		request     *FeatureActivationRequest
+		wantErr     bool
+		wantMessage string
+	}{
+		{
+			description: "request to activate an existing Feature",
+			request:     activationRequest("preview", true, nil),
+		},
+		{
+			description: "request for a Feature that does not exist",
+			request:     activationRequest("missing", true, nil),
+			wantErr:     true,
+		},
+		{
+			description: "request to deactivate a stable Feature",
+			request:     activationRequest("stable", false, nil),
+			wantErr:     true,
+			wantMessage: "Stable features do not require approval",
+		},
+		{
+			description: "request to activate a stable Feature",
+			request:     activationRequest("stable", true, nil),
+			wantErr:     true,
+			wantMessage: "Stable features do not require approval",
+		},
+		{
+			description: "request to activate an experimental Feature",
+			request:     activationRequest("experiment", true, nil),
+		},
+		{
+			description: "request to deactivate a deprecated Feature",
+			request:     activationRequest("deprecated", false, nil),
+		},
+		{
+			description: "request to activate an immutable Feature",
+			request:     activationRequest("immutable", true, nil),
+			wantErr:     true,
+			wantMessage: "cannot request a change of immutable feature immutable",
+		},
+		{
+			description: "request created with a decision",
+			request:     activationRequest("preview", true, &FeatureActivationDecision{Type: FeatureActivationApproved}),
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			w := &featureActivationRequestWebhook{client: newApproverClient(t), permission: DefaultApprovalPermission}
+			err := w.ValidateCreate(admissionContext(t, admissionv1.Create, "dev", nil), tc.request)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error: %v, want error: %t", err, tc.wantErr)
+			}
+			if err != nil && !apierrors.IsInvalid(err) {
+				t.Errorf("got error: %v, want it to be invalid", err)
+			}
+			if tc.wantMessage != "" && (err == nil || !strings.Contains(err.Error(), tc.wantMessage)) {
+				t.Errorf("got error: %v, want it to contain: %q", err, tc.wantMessage)
+			}
+		})
+	}
+}
+
+func TestFeatureActivationRequestValidateUpdate(t *testing.T) {
+	approved := func(voidWarranty bool) *FeatureActivationDecision {
+		return &FeatureActivationDecision{Type: FeatureActivationApproved, Approver: "admin", PermanentlyVoidAllSupportGuarantees: voidWarranty}
+	}
+
+	testCases := []struct {
+		description   string
+		old           *FeatureActivationRequest
+		new           *FeatureActivationRequest
+		username      string
+		wantForbidden bool
+		wantInvalid   bool
+	}{
+		{
+			description: "approval by an approver",
+			old:         activationRequest("preview", true, nil),
+			new:         activationRequest("preview", true, approved(false)),
+			username:    "admin",
+		},
+		{
+			description: "rejection by an approver",
+			old:         activationRequest("experiment", true, nil),
+			new:         activationRequest("experiment", true, &FeatureActivationDecision{Type: FeatureActivationRejected, Approver: "admin"}),
+			username:    "admin",
+		},
+		{
+			description:   "approval by a user without the approval permission",
+			old:           activationRequest("preview", true, nil),
+			new:           activationRequest("preview", true, &FeatureActivationDecision{Type: FeatureActivationApproved, Approver: "dev"}),
+			username:      "dev",
+			wantForbidden: true,
+		},
+		{
+			description:   "approval for a Feature the approver may not approve",
+			old:           activationRequest("stable", true, nil),
+			new:           activationRequest("stable", true, approved(false)),
+			username:      "admin",
+			wantForbidden: true,
+		},
+		{
+			description: "approval voiding the warranty with the acknowledgement of the approver",
+			old:         activationRequest("experiment", true, nil),
+			new:         activationRequest("experiment", true, approved(true)),
+			username:    "admin",
+		},
+		{
+			description: "approval voiding the warranty without the acknowledgement of the approver",
+			old:         activationRequest("experiment", true, nil),
+			new:         activationRequest("experiment", true, approved(false)),
+			username:    "admin",
+			wantInvalid: true,
+		},
+		{
+			description: "approval recording another approver",
+			old:         activationRequest("preview", true, nil),
+			new:         activationRequest("preview", true, &FeatureActivationDecision{Type: FeatureActivationApproved, Approver: "dev"}),
+			username:    "admin",
+			wantInvalid: true,
+		},
+		{
+			description: "change of a decision",
+			old:         activationRequest("preview", true, approved(false)),
+			new:         activationRequest("preview", true, &FeatureActivationDecision{Type: FeatureActivationRejected, Approver: "admin"}),
+			username:    "admin",
+			wantInvalid: true,
+		},
+		{
+			description: "change of the requested activation",
+			old:         activationRequest("preview", true, nil),
+			new:         activationRequest("preview", false, nil),
+			username:    "dev",
+			wantInvalid: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			c := newApproverClient(t)
+			w := &featureActivationRequestWebhook{client: c, permission: DefaultApprovalPermission}
+			err := w.ValidateUpdate(admissionContext(t, admissionv1.Update, tc.username, tc.old), tc.old, tc.new)
+			if apierrors.IsForbidden(err) != tc.wantForbidden {
+				t.Errorf("got error: %v, want forbidden: %t", err, tc.wantForbidden)
+			}
+			if apierrors.IsInvalid(err) != tc.wantInvalid {
+				t.Errorf("got error: %v, want invalid: %t", err, tc.wantInvalid)
+			}
+			if !tc.wantForbidden && !tc.wantInvalid && err != nil {
+				t.Errorf("got error: %v", err)
+			}
+			if tc.old.Spec.Decision == nil && tc.new.Spec.Decision != nil && len(c.reviews) != 1 {
+				t.Errorf("got %d SubjectAccessReviews, want 1", len(c.reviews))
+			}
+		})
+	}
+}
+
+func TestFeatureActivationRequestVoidsWarranty(t *testing.T) {
+	feature := func(stability StabilityLevel) *Feature {
+		return &Feature{Spec: FeatureSpec{Stability: stability}}
+	}
+	testCases := []struct {
+		description string
+		feature     *Feature
+		activate    bool
+		want        bool
+	}{
+		{description: "activating an experimental Feature", feature: feature(Experimental), activate: true, want: true},
+		{description: "deactivating an experimental Feature", feature: feature(Experimental), activate: false},
+		{description: "activating a technical preview Feature", feature: feature(TechnicalPreview), activate: true},
+		{description: "deactivating a deprecated Feature", feature: feature(Deprecated), activate: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r := activationRequest("foo", tc.activate, nil)
+			if got := r.VoidsWarranty(tc.feature); got != tc.want {
+				t.Errorf("got: %t, want: %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFeatureActivationRequestRequiresApproval(t *testing.T) {
+	feature := func(stability StabilityLevel) *Feature {
+		return &Feature{Spec: FeatureSpec{Stability: stability}}
+	}
+	testCases := []struct {
+		description string
+		feature     *Feature
+		activate    bool
+		want        bool
+	}{
+		{description: "activating an experimental Feature, voiding the warranty", feature: feature(Experimental), activate: true, want: true},
+		{description: "deactivating an experimental Feature", feature: feature(Experimental), activate: false, want: true},
+		{description: "activating a technical preview Feature", feature: feature(TechnicalPreview), activate: true, want: true},
+		{description: "deactivating a deprecated Feature", feature: feature(Deprecated), activate: false, want: true},
+		{description: "activating a stable Feature", feature: feature(Stable), activate: true},
+		{description: "deactivating a stable Feature", feature: feature(Stable), activate: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			r := activationRequest("foo", tc.activate, nil)
+			if got := r.RequiresApproval(tc.feature); got != tc.want {
+				t.Errorf("got: %t, want: %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -18,6 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // log is for logging in this package.
@@ -54,8 +57,50 @@ func (r *FeatureGate) getClient() (client.Client, error) {
 	return client.New(cfg, client.Options{Scheme: s})
 }
 
-// SetupWebhookWithManager adds the webhook to the manager.
+// ActivationPermissions are the verbs a user must be allowed on a Feature to change its reference in a FeatureGate. The
+// webhook checks them with SubjectAccessReviews on each Feature whose reference changes, so teams can be allowed to
+// toggle their own Features without being allowed to void the warranty of the environment. A check is skipped when
+// its verb is empty, so the zero value checks nothing.
+//
+// When RequestApplier is set, changing the activation of a Feature that requires approval, i.e. a Feature that is not
+// Stable, also requires an approved FeatureActivationRequest for the change that is not applied yet. RequestApplier is
+// the user applying approved requests, usually the service account of the controller, and is not subject to this
+// check.
+type ActivationPermissions struct {
+	Group            string
+	Resource         string
+	ActivateVerb     string
+	DeactivateVerb   string
+	VoidWarrantyVerb string
+	RequestApplier   string
+}
+
+// DefaultActivationPermissions are the activate, deactivate and void-warranty verbs on Features. They are not checked
+// unless they are passed to SetupWebhookWithManagerAndPermissions.
+var DefaultActivationPermissions = ActivationPermissions{
+	Group:            GroupVersion.Group,
+	Resource:         "features",
+	ActivateVerb:     "activate",
+	DeactivateVerb:   "deactivate",
+	VoidWarrantyVerb: "void-warranty",
+}
+
+// featureGateWebhook validates FeatureGates, and checks the user changing a FeatureGate holds the permissions on the
+// Features whose references change.
+type featureGateWebhook struct {
+	client      client.Client
+	permissions ActivationPermissions
+}
+
+// SetupWebhookWithManager adds the webhook to the manager, without checking any permission on the Features whose
+// references change.
 func (r *FeatureGate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return r.SetupWebhookWithManagerAndPermissions(mgr, ActivationPermissions{})
+}
+
+// SetupWebhookWithManagerAndPermissions adds the webhook to the manager. Only users holding permissions on a Feature can
+// change its reference, and only with an approved FeatureActivationRequest when permissions require one.
+func (r *FeatureGate) SetupWebhookWithManagerAndPermissions(mgr ctrl.Manager, permissions ActivationPermissions) error {
 	s, err := getScheme()
 	if err != nil {
 		return err
@@ -68,11 +113,230 @@ func (r *FeatureGate) SetupWebhookWithManager(mgr ctrl.Manager) error {
 
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithValidator(&featureGateWebhook{client: cl, permissions: permissions}).
 		Complete()
 }
 
 //+kubebuilder:webhook:verbs=create;update,path=/validate-core-tanzu-vmware-com-v1alpha2-featuregate,mutating=false,failurePolicy=fail,groups=core.tanzu.vmware.com,resources=featuregates,versions=v1alpha2,name=vfeaturegate.kb.io
 
+var _ admission.CustomValidator = &featureGateWebhook{}
+
+// ValidateCreate validates the FeatureGate, and checks the user is allowed to set the activation of its Features and,
+// when required, that the activations are approved.
+func (w *featureGateWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	r, ok := obj.(*FeatureGate)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected FeatureGate object, but got object of type %T", obj))
+	}
+	if err := r.ValidateCreate(); err != nil {
+		return err
+	}
+	if err := w.authorizeFeatureChanges(ctx, r.Name, FeatureGateSpec{}, r.Spec); err != nil {
+		return err
+	}
+	return w.requireApprovedRequests(ctx, r.Name, FeatureGateSpec{}, r.Spec)
+}
+
+// ValidateUpdate validates the FeatureGate, and checks the user is allowed to change the references to its Features
+// and, when required, that the changes are approved.
+func (w *featureGateWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	r, ok := newObj.(*FeatureGate)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected FeatureGate object, but got object of type %T", newObj))
+	}
+	old, ok := oldObj.(*FeatureGate)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected FeatureGate object, but got object of type %T", oldObj))
+	}
+	if err := r.ValidateUpdate(old); err != nil {
+		return err
+	}
+	if err := w.authorizeFeatureChanges(ctx, r.Name, old.Spec, r.Spec); err != nil {
+		return err
+	}
+	return w.requireApprovedRequests(ctx, r.Name, old.Spec, r.Spec)
+}
+
+// ValidateDelete implements admission.CustomValidator so a webhook will be registered for the type
+func (w *featureGateWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	r, ok := obj.(*FeatureGate)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected FeatureGate object, but got object of type %T", obj))
+	}
+	return r.ValidateDelete()
+}
+
+// authorizeFeatureChanges checks, with a SubjectAccessReview per required verb, that the user making the request is
+// allowed to change the references to Features from oldSpec to newSpec.
+func (w *featureGateWebhook) authorizeFeatureChanges(ctx context.Context, name string, oldSpec, newSpec FeatureGateSpec) error {
+	if !w.permissions.enabled() {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	features := &FeatureList{}
+	if err := w.client.List(ctx, features); err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	var denied []string
+	for _, access := range w.permissions.requiredFeatureAccess(oldSpec, newSpec, features) {
+		allowed, err := userCan(ctx, w.client, req.UserInfo, authorizationv1.ResourceAttributes{
+			Verb:     access.verb,
+			Group:    w.permissions.Group,
+			Resource: w.permissions.Resource,
+			Name:     access.feature,
+		})
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		if !allowed {
+			denied = append(denied, fmt.Sprintf("%s %s.%s %s", access.verb, w.permissions.Resource, w.permissions.Group, access.feature))
+		}
+	}
+	if len(denied) == 0 {
+		return nil
+	}
+
+	featuregatelog.Info("denied feature changes", "name", name, "user", req.UserInfo.Username, "denied", denied)
+	return apierrors.NewForbidden(GroupVersion.WithResource("featuregates").GroupResource(), name,
+		fmt.Errorf("user %q is not allowed to %s", req.UserInfo.Username, strings.Join(denied, ", ")))
+}
+
+// enabled reports whether any permission is checked.
+func (p ActivationPermissions) enabled() bool {
+	return p.ActivateVerb != "" || p.DeactivateVerb != "" || p.VoidWarrantyVerb != ""
+}
+
+// featureAccess is a verb a user must be allowed on a Feature.
+type featureAccess struct {
+	feature string
+	verb    string
+}
+
+// requiredFeatureAccess computes and returns the verbs required on the Features whose references change from oldSpec to
+// newSpec. A Feature without a reference has the default activation of its stability level, so adding or removing a
+// reference only requires a verb when it changes the activation. Voiding the warranty requires its own verb.
+func (p ActivationPermissions) requiredFeatureAccess(oldSpec, newSpec FeatureGateSpec, features *FeatureList) []featureAccess {
+	allFeatures := sets.String{}
+	for _, featureRef := range oldSpec.Features {
+		allFeatures.Insert(featureRef.Name)
+	}
+	for _, featureRef := range newSpec.Features {
+		allFeatures.Insert(featureRef.Name)
+	}
+
+	var required []featureAccess
+	for _, featureName := range allFeatures.List() {
+		stabilityLevel, found := getFeatureStabilityLevel(features, featureName)
+		if !found {
+			// Feature doesn't exist and is validated in validateFeatureExistence method
+			continue
+		}
+		policy := GetPolicyForStabilityLevel(stabilityLevel)
+
+		oldRef, _ := getFeatureReference(oldSpec, featureName)
+		newRef, _ := getFeatureReference(newSpec, featureName)
+		oldActivate, newActivate := getFeatureActivations(oldSpec, newSpec, featureName, policy)
+
+		if oldActivate != newActivate {
+			verb := p.DeactivateVerb
+			if newActivate {
+				verb = p.ActivateVerb
+			}
+			if verb != "" {
+				required = append(required, featureAccess{feature: featureName, verb: verb})
+			}
+		}
+		if p.VoidWarrantyVerb != "" && newRef.PermanentlyVoidAllSupportGuarantees && !oldRef.PermanentlyVoidAllSupportGuarantees {
+			required = append(required, featureAccess{feature: featureName, verb: p.VoidWarrantyVerb})
+		}
+	}
+	return required
+}
+
+// requireApprovedRequests checks, when permissions name a RequestApplier, that every change from oldSpec to newSpec to
+// the activation of a Feature requiring approval has an approved FeatureActivationRequest that is not applied yet. The
+// changes made by the RequestApplier are not checked.
+func (w *featureGateWebhook) requireApprovedRequests(ctx context.Context, name string, oldSpec, newSpec FeatureGateSpec) error {
+	if w.permissions.RequestApplier == "" {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if req.UserInfo.Username == w.permissions.RequestApplier {
+		return nil
+	}
+
+	features := &FeatureList{}
+	if err := w.client.List(ctx, features); err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	requests := &FeatureActivationRequestList{}
+	if err := w.client.List(ctx, requests); err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	unapproved := computeUnapprovedFeatureChanges(oldSpec, newSpec, features, requests)
+	if len(unapproved) == 0 {
+		return nil
+	}
+
+	featuregatelog.Info("denied unapproved feature changes", "name", name, "user", req.UserInfo.Username, "unapproved", unapproved)
+	return apierrors.NewForbidden(GroupVersion.WithResource("featuregates").GroupResource(), name,
+		fmt.Errorf("changes to features %v require an approved FeatureActivationRequest", unapproved))
+}
+
+// computeUnapprovedFeatureChanges computes and returns the Features requiring approval whose activation changes from
+// oldSpec to newSpec without an approved FeatureActivationRequest for the change that is not applied yet.
+func computeUnapprovedFeatureChanges(oldSpec, newSpec FeatureGateSpec, features *FeatureList, requests *FeatureActivationRequestList) []string {
+	allFeatures := sets.String{}
+	for _, featureRef := range oldSpec.Features {
+		allFeatures.Insert(featureRef.Name)
+	}
+	for _, featureRef := range newSpec.Features {
+		allFeatures.Insert(featureRef.Name)
+	}
+
+	unapproved := sets.String{}
+	for i := range features.Items {
+		feature := &features.Items[i]
+		if !allFeatures.Has(feature.Name) {
+			continue
+		}
+		policy := GetPolicyForStabilityLevel(feature.Spec.Stability)
+		oldActivate, newActivate := getFeatureActivations(oldSpec, newSpec, feature.Name, policy)
+		if oldActivate == newActivate {
+			continue
+		}
+		request := &FeatureActivationRequest{Spec: FeatureActivationRequestSpec{Feature: feature.Name, Activate: newActivate}}
+		if request.RequiresApproval(feature) && !hasApprovedRequest(requests, feature.Name, newActivate) {
+			unapproved.Insert(feature.Name)
+		}
+	}
+	return unapproved.List()
+}
+
+// hasApprovedRequest reports whether a FeatureActivationRequest for the activation of the Feature is approved, and not
+// applied yet.
+func hasApprovedRequest(requests *FeatureActivationRequestList, featureName string, activate bool) bool {
+	for i := range requests.Items {
+		spec := requests.Items[i].Spec
+		if spec.Feature == featureName && spec.Activate == activate && spec.Decision != nil &&
+			spec.Decision.Type == FeatureActivationApproved && requests.Items[i].Status.Phase != FeatureActivationRequestApplied {
+			return true
+		}
+	}
+	return false
+}
+
 var _ webhook.Validator = &FeatureGate{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
@@ -330,6 +594,29 @@ func getPermanentlyVoidAllSupportGuaranteesFieldForFeature(featureGate *FeatureG
 	return false, false
 }
 
+// getFeatureReference returns the reference to a feature from a FeatureGate resource spec
+func getFeatureReference(spec FeatureGateSpec, featureName string) (FeatureReference, bool) {
+	for _, featureRef := range spec.Features {
+		if featureRef.Name == featureName {
+			return featureRef, true
+		}
+	}
+	return FeatureReference{}, false
+}
+
+// getFeatureActivations returns the activation of a feature in the oldSpec and newSpec FeatureGate resource specs. A
+// feature without a reference has the default activation of its stability policy.
+func getFeatureActivations(oldSpec, newSpec FeatureGateSpec, featureName string, policy Policy) (bool, bool) {
+	oldActivate, newActivate := policy.DefaultActivation, policy.DefaultActivation
+	if oldRef, found := getFeatureReference(oldSpec, featureName); found {
+		oldActivate = oldRef.Activate
+	}
+	if newRef, found := getFeatureReference(newSpec, featureName); found {
+		newActivate = newRef.Activate
+	}
+	return oldActivate, newActivate
+}
+
 // getFeatureStabilityLevel returns feature stability level for a feature from a list of Features
 func getFeatureStabilityLevel(list *FeatureList, featureName string) (StabilityLevel, bool) {
 	for i := range list.Items {
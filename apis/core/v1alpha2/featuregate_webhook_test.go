@@ -4,12 +4,18 @@
 package v1alpha2
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestComputeFeaturesThatVoidSupportWarranty(t *testing.T) {
@@ -376,6 +382,386 @@ func TestComputeFeaturesThatDoNotExist(t *testing.T) {
 	}
 }
 
+func TestRequiredFeatureAccess(t *testing.T) {
+	featureList := &FeatureList{
+		Items: []Feature{
+			{ObjectMeta: metav1.ObjectMeta{Name: "foo"}, Spec: FeatureSpec{Description: "foo", Stability: "Experimental"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "bar"}, Spec: FeatureSpec{Description: "bar", Stability: "Technical Preview"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "baz"}, Spec: FeatureSpec{Description: "baz", Stability: "Stable"}},
+		},
+	}
+
+	testCases := []struct {
+		description string
+		permissions ActivationPermissions
+		oldSpec     FeatureGateSpec
+		newSpec     FeatureGateSpec
+		want        []string
+	}{
+		{
+			description: "Unchanged references require no verbs",
+			permissions: DefaultActivationPermissions,
+			oldSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo"}, {Name: "bar", Activate: true}}},
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo"}, {Name: "bar", Activate: true}}},
+			want:        []string{},
+		},
+		{
+			description: "Activating and deactivating features",
+			permissions: DefaultActivationPermissions,
+			oldSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "bar", Activate: true}}},
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true, PermanentlyVoidAllSupportGuarantees: true}, {Name: "bar"}}},
+			want:        []string{"activate foo", "void-warranty foo", "deactivate bar"},
+		},
+		{
+			description: "Adding references with the default activation requires no verbs",
+			permissions: DefaultActivationPermissions,
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo"}, {Name: "baz", Activate: true}}},
+			want:        []string{},
+		},
+		{
+			description: "Removing a reference returns the feature to its default activation",
+			permissions: DefaultActivationPermissions,
+			oldSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "bar", Activate: true}, {Name: "baz", Activate: true}}},
+			want:        []string{"deactivate bar"},
+		},
+		{
+			description: "Voiding the warranty without changing the activation",
+			permissions: DefaultActivationPermissions,
+			oldSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "bar"}}},
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "bar", PermanentlyVoidAllSupportGuarantees: true}}},
+			want:        []string{"void-warranty bar"},
+		},
+		{
+			description: "Features that do not exist are skipped",
+			permissions: DefaultActivationPermissions,
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "qux", Activate: true}}},
+			want:        []string{},
+		},
+		{
+			description: "Checks with empty verbs are skipped",
+			permissions: ActivationPermissions{Group: GroupVersion.Group, Resource: "features", VoidWarrantyVerb: "void-warranty"},
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true, PermanentlyVoidAllSupportGuarantees: true}}},
+			want:        []string{"void-warranty foo"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var got []string
+			for _, access := range tc.permissions.requiredFeatureAccess(tc.oldSpec, tc.newSpec, featureList) {
+				got = append(got, access.verb+" "+access.feature)
+			}
+			if diff := sliceDiffIgnoreOrder(got, tc.want); diff != "" {
+				t.Errorf("got required access %v, want %v, diff: %s", got, tc.want, diff)
+			}
+		})
+	}
+}
+
+// featureOwnerClient allows users the verbs on Features in allowed, as the API server would answer a
+// SubjectAccessReview.
+type featureOwnerClient struct {
+	client.Client
+	allowed map[string][]string
+}
+
+func (c *featureOwnerClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	review, ok := obj.(*authorizationv1.SubjectAccessReview)
+	if !ok {
+		return c.Client.Create(ctx, obj, opts...)
+	}
+	attrs := review.Spec.ResourceAttributes
+	if attrs.Group != GroupVersion.Group || attrs.Resource != "features" {
+		return nil
+	}
+	for _, allowed := range c.allowed[review.Spec.User] {
+		if allowed == attrs.Verb+" "+attrs.Name {
+			review.Status.Allowed = true
+		}
+	}
+	return nil
+}
+
+func TestFeatureGateAuthorizeFeatureChanges(t *testing.T) {
+	testCases := []struct {
+		description   string
+		username      string
+		newSpec       FeatureGateSpec
+		wantForbidden bool
+	}{
+		{
+			description: "Team activates its own feature",
+			username:    "team",
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "preview", Activate: true}, {Name: "experiment"}}},
+		},
+		{
+			description:   "Team cannot activate a feature it does not own",
+			username:      "team",
+			newSpec:       FeatureGateSpec{Features: []FeatureReference{{Name: "preview"}, {Name: "experiment", Activate: true}}},
+			wantForbidden: true,
+		},
+		{
+			description:   "Team cannot void the warranty of the environment",
+			username:      "team",
+			newSpec:       FeatureGateSpec{Features: []FeatureReference{{Name: "preview", PermanentlyVoidAllSupportGuarantees: true}, {Name: "experiment"}}},
+			wantForbidden: true,
+		},
+		{
+			description: "Admin activates a feature voiding the warranty",
+			username:    "admin",
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "preview"}, {Name: "experiment", Activate: true, PermanentlyVoidAllSupportGuarantees: true}}},
+		},
+	}
+
+	s, err := getScheme()
+	if err != nil {
+		t.Fatalf("unable to get scheme: %v", err)
+	}
+	feature := func(name string, stability StabilityLevel) *Feature {
+		return &Feature{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: FeatureSpec{Stability: stability}}
+	}
+	c := &featureOwnerClient{
+		Client: fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(
+			feature("preview", TechnicalPreview),
+			feature("experiment", Experimental),
+		).Build(),
+		allowed: map[string][]string{
+			"team":  {"activate preview", "deactivate preview"},
+			"admin": {"activate experiment", "void-warranty experiment"},
+		},
+	}
+	oldSpec := FeatureGateSpec{Features: []FeatureReference{{Name: "preview"}, {Name: "experiment"}}}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			w := &featureGateWebhook{client: c, permissions: DefaultActivationPermissions}
+			err := w.authorizeFeatureChanges(admissionContext(t, admissionv1.Update, tc.username, nil), "policies", oldSpec, tc.newSpec)
+			if apierrors.IsForbidden(err) != tc.wantForbidden {
+				t.Errorf("got error: %v, want forbidden: %t", err, tc.wantForbidden)
+			}
+			if !tc.wantForbidden && err != nil {
+				t.Errorf("got error: %v", err)
+			}
+		})
+	}
+
+	t.Run("No permission is checked unless set", func(t *testing.T) {
+		w := &featureGateWebhook{client: c}
+		newSpec := FeatureGateSpec{Features: []FeatureReference{{Name: "preview"}, {Name: "experiment", Activate: true, PermanentlyVoidAllSupportGuarantees: true}}}
+		if err := w.authorizeFeatureChanges(admissionContext(t, admissionv1.Update, "team", nil), "policies", oldSpec, newSpec); err != nil {
+			t.Errorf("got error: %v", err)
+		}
+	})
+}
+
+func TestFeatureGateWebhookValidateUpdateChecksPermissions(t *testing.T) {
+	s, err := getScheme()
+	if err != nil {
+		t.Fatalf("unable to get scheme: %v", err)
+	}
+	old := &FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "policies"},
+		Spec:       FeatureGateSpec{Features: []FeatureReference{{Name: "preview"}}},
+	}
+	c := &featureOwnerClient{
+		Client: fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(
+			&Feature{ObjectMeta: metav1.ObjectMeta{Name: "preview"}, Spec: FeatureSpec{Stability: TechnicalPreview}},
+			old,
+		).Build(),
+		allowed: map[string][]string{"team": {"activate preview"}},
+	}
+
+	// FeatureGate.ValidateUpdate validates the FeatureGate with the cached client.
+	storedClient := cl
+	cl = c
+	defer func() { cl = storedClient }()
+
+	testCases := []struct {
+		description   string
+		permissions   ActivationPermissions
+		username      string
+		wantForbidden bool
+	}{
+		{
+			description: "User allowed to activate the feature",
+			permissions: DefaultActivationPermissions,
+			username:    "team",
+		},
+		{
+			description:   "User not allowed to activate the feature",
+			permissions:   DefaultActivationPermissions,
+			username:      "dev",
+			wantForbidden: true,
+		},
+		{
+			description: "No permission is checked by SetupWebhookWithManager",
+			username:    "dev",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			w := &featureGateWebhook{client: c, permissions: tc.permissions}
+			updated := old.DeepCopy()
+			updated.Spec.Features[0].Activate = true
+			err := w.ValidateUpdate(admissionContext(t, admissionv1.Update, tc.username, nil), old, updated)
+			if apierrors.IsForbidden(err) != tc.wantForbidden {
+				t.Errorf("got error: %v, want forbidden: %t", err, tc.wantForbidden)
+			}
+			if !tc.wantForbidden && err != nil {
+				t.Errorf("got error: %v", err)
+			}
+		})
+	}
+}
+
+func TestComputeUnapprovedFeatureChanges(t *testing.T) {
+	featureList := &FeatureList{
+		Items: []Feature{
+			{ObjectMeta: metav1.ObjectMeta{Name: "foo"}, Spec: FeatureSpec{Description: "foo", Stability: "Experimental"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "bar"}, Spec: FeatureSpec{Description: "bar", Stability: "Technical Preview"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "baz"}, Spec: FeatureSpec{Description: "baz", Stability: "Stable"}},
+		},
+	}
+	request := func(feature string, activate bool, decision FeatureActivationDecisionType, phase FeatureActivationRequestPhase) FeatureActivationRequest {
+		r := FeatureActivationRequest{
+			Spec:   FeatureActivationRequestSpec{Feature: feature, Activate: activate},
+			Status: FeatureActivationRequestStatus{Phase: phase},
+		}
+		if decision != "" {
+			r.Spec.Decision = &FeatureActivationDecision{Type: decision}
+		}
+		return r
+	}
+
+	testCases := []struct {
+		description string
+		oldSpec     FeatureGateSpec
+		newSpec     FeatureGateSpec
+		requests    []FeatureActivationRequest
+		want        []string
+	}{
+		{
+			description: "Unchanged activations require no approval",
+			oldSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo"}, {Name: "bar", Activate: true}}},
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo", PermanentlyVoidAllSupportGuarantees: true}, {Name: "bar", Activate: true}}},
+			want:        []string{},
+		},
+		{
+			description: "Changes without requests are not approved",
+			oldSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo"}, {Name: "bar", Activate: true}}},
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true, PermanentlyVoidAllSupportGuarantees: true}, {Name: "bar"}}},
+			want:        []string{"foo", "bar"},
+		},
+		{
+			description: "Changes with approved requests",
+			oldSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo"}, {Name: "bar", Activate: true}}},
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true, PermanentlyVoidAllSupportGuarantees: true}, {Name: "bar"}}},
+			requests: []FeatureActivationRequest{
+				request("foo", true, FeatureActivationApproved, FeatureActivationRequestPending),
+				request("bar", false, FeatureActivationApproved, FeatureActivationRequestFailed),
+			},
+			want: []string{},
+		},
+		{
+			description: "Requests that are not approved, applied or for another change do not approve a change",
+			oldSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo"}, {Name: "bar", Activate: true}}},
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "foo", Activate: true, PermanentlyVoidAllSupportGuarantees: true}, {Name: "bar"}}},
+			requests: []FeatureActivationRequest{
+				request("foo", true, "", FeatureActivationRequestPending),
+				request("foo", true, FeatureActivationRejected, FeatureActivationRequestRejected),
+				request("foo", true, FeatureActivationApproved, FeatureActivationRequestApplied),
+				request("bar", true, FeatureActivationApproved, FeatureActivationRequestPending),
+			},
+			want: []string{"foo", "bar"},
+		},
+		{
+			description: "Removing a reference returns the feature to its default activation",
+			oldSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "bar", Activate: true}}},
+			want:        []string{"bar"},
+		},
+		{
+			description: "Stable features and features that do not exist are skipped",
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "baz"}, {Name: "qux", Activate: true}}},
+			want:        []string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			got := computeUnapprovedFeatureChanges(tc.oldSpec, tc.newSpec, featureList, &FeatureActivationRequestList{Items: tc.requests})
+			if diff := sliceDiffIgnoreOrder(got, tc.want); diff != "" {
+				t.Errorf("got unapproved features %v, want %v, diff: %s", got, tc.want, diff)
+			}
+		})
+	}
+}
+
+func TestFeatureGateRequireApprovedRequests(t *testing.T) {
+	const applier = "system:serviceaccount:tkg-system:tanzu-featuregates-manager-sa"
+
+	s, err := getScheme()
+	if err != nil {
+		t.Fatalf("unable to get scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(
+		&Feature{ObjectMeta: metav1.ObjectMeta{Name: "preview"}, Spec: FeatureSpec{Stability: TechnicalPreview}},
+		&Feature{ObjectMeta: metav1.ObjectMeta{Name: "deprecated"}, Spec: FeatureSpec{Stability: Deprecated}},
+		&FeatureActivationRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "preview-request"},
+			Spec: FeatureActivationRequestSpec{
+				Feature:  "preview",
+				Activate: true,
+				Decision: &FeatureActivationDecision{Type: FeatureActivationApproved, Approver: "admin"},
+			},
+		},
+	).Build()
+	oldSpec := FeatureGateSpec{Features: []FeatureReference{{Name: "preview"}, {Name: "deprecated", Activate: true}}}
+
+	testCases := []struct {
+		description   string
+		permissions   ActivationPermissions
+		username      string
+		newSpec       FeatureGateSpec
+		wantForbidden bool
+	}{
+		{
+			description: "Approved change",
+			permissions: ActivationPermissions{RequestApplier: applier},
+			username:    "dev",
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "preview", Activate: true}, {Name: "deprecated", Activate: true}}},
+		},
+		{
+			description:   "Change without an approved request",
+			permissions:   ActivationPermissions{RequestApplier: applier},
+			username:      "dev",
+			newSpec:       FeatureGateSpec{Features: []FeatureReference{{Name: "preview"}, {Name: "deprecated"}}},
+			wantForbidden: true,
+		},
+		{
+			description: "Change by the request applier",
+			permissions: ActivationPermissions{RequestApplier: applier},
+			username:    applier,
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "preview"}, {Name: "deprecated"}}},
+		},
+		{
+			description: "No approval is required unless a request applier is set",
+			permissions: DefaultActivationPermissions,
+			username:    "dev",
+			newSpec:     FeatureGateSpec{Features: []FeatureReference{{Name: "preview"}, {Name: "deprecated"}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			w := &featureGateWebhook{client: c, permissions: tc.permissions}
+			err := w.requireApprovedRequests(admissionContext(t, admissionv1.Update, tc.username, nil), "policies", oldSpec, tc.newSpec)
+			if apierrors.IsForbidden(err) != tc.wantForbidden {
+				t.Errorf("got error: %v, want forbidden: %t", err, tc.wantForbidden)
+			}
+			if !tc.wantForbidden && err != nil {
+				t.Errorf("got error: %v", err)
+			}
+		})
+	}
+}
+
 // sliceDiffIgnoreOrder returns a human-readable diff of two string slices.
 // Two slices are considered equal when they have the same length and same elements. The order of the elements is
 // ignored while comparing. Nil and empty slices are considered equal.
@@ -142,6 +142,115 @@ func (in *Feature) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureActivationDecision) DeepCopyInto(out *FeatureActivationDecision) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureActivationDecision.
+func (in *FeatureActivationDecision) DeepCopy() *FeatureActivationDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureActivationDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureActivationRequest) DeepCopyInto(out *FeatureActivationRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureActivationRequest.
+func (in *FeatureActivationRequest) DeepCopy() *FeatureActivationRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureActivationRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FeatureActivationRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureActivationRequestList) DeepCopyInto(out *FeatureActivationRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FeatureActivationRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureActivationRequestList.
+func (in *FeatureActivationRequestList) DeepCopy() *FeatureActivationRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureActivationRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FeatureActivationRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureActivationRequestSpec) DeepCopyInto(out *FeatureActivationRequestSpec) {
+	*out = *in
+	if in.Decision != nil {
+		in, out := &in.Decision, &out.Decision
+		*out = new(FeatureActivationDecision)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureActivationRequestSpec.
+func (in *FeatureActivationRequestSpec) DeepCopy() *FeatureActivationRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureActivationRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureActivationRequestStatus) DeepCopyInto(out *FeatureActivationRequestStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureActivationRequestStatus.
+func (in *FeatureActivationRequestStatus) DeepCopy() *FeatureActivationRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureActivationRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureGate) DeepCopyInto(out *FeatureGate) {
 	*out = *in
@@ -0,0 +1,34 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// getCurrentClusterConfig returns the rest config of the cluster of the current kubernetes context.
+func getCurrentClusterConfig() (*rest.Config, error) {
+	c, err := config.GetCurrentContext(types.TargetK8s)
+	if err != nil {
+		return nil, err
+	}
+	if c.ClusterOpts == nil {
+		return nil, fmt.Errorf("unexpected error: context options is nil")
+	}
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: c.ClusterOpts.Path},
+		&clientcmd.ConfigOverrides{
+			CurrentContext: c.ClusterOpts.Context,
+		}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not get rest config: %w", err)
+	}
+	return restConfig, nil
+}
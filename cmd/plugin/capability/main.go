@@ -0,0 +1,38 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+
+	"github.com/aunum/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	plugintypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+)
+
+var descriptor = plugin.PluginDescriptor{
+	Name:        "capability",
+	Description: "Query the capabilities of a cluster",
+	Target:      plugintypes.TargetK8s,
+	Version:     buildinfo.Version,
+	Group:       plugin.RunCmdGroup,
+	BuildSHA:    buildinfo.SHA,
+}
+
+func main() {
+	p, err := plugin.NewPlugin(&descriptor)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p.AddCommands(
+		CapabilityQueryCmd,
+	)
+
+	if err := p.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+// Query target types.
+const (
+	queryTypeGVR           = "GVR"
+	queryTypeObject        = "Object"
+	queryTypePartialSchema = "PartialSchema"
+)
+
+// QueryResultInfo is the result of a single query target.
+type QueryResultInfo struct {
+	Query          string `json:"query" yaml:"query"`
+	Type           string `json:"type" yaml:"type"`
+	Name           string `json:"name" yaml:"name"`
+	Found          bool   `json:"found" yaml:"found"`
+	NotFoundReason string `json:"notFoundReason,omitempty" yaml:"notFoundReason,omitempty"`
+	Error          string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// queryResultInfos flattens the results of the queries into the results of their targets.
+func queryResultInfos(results []corev1alpha2.Result) []QueryResultInfo {
+	infos := []QueryResultInfo{}
+	add := func(query, queryType string, targetResults []corev1alpha2.QueryResult) {
+		for _, r := range targetResults {
+			info := QueryResultInfo{Query: query, Type: queryType, Name: r.Name, Found: r.Found, NotFoundReason: r.NotFoundReason}
+			if r.Error {
+				info.Error = r.ErrorDetail
+			}
+			infos = append(infos, info)
+		}
+	}
+	for i := range results {
+		add(results[i].Name, queryTypeGVR, results[i].GroupVersionResources)
+		add(results[i].Name, queryTypeObject, results[i].Objects)
+		add(results[i].Name, queryTypePartialSchema, results[i].PartialSchemas)
+	}
+	return infos
+}
+
+// failedQueryTargets returns the number of query targets that were not found or failed.
+func failedQueryTargets(infos []QueryResultInfo) int {
+	failed := 0
+	for _, info := range infos {
+		if !info.Found || info.Error != "" {
+			failed++
+		}
+	}
+	return failed
+}
+
+// printQueryResults renders the results of the query targets as yaml, json or a table where the reason is the error of
+// a failed target or why it was not found.
+func printQueryResults(cmd *cobra.Command, infos []QueryResultInfo, format string) {
+	switch component.OutputType(format) {
+	case component.YAMLOutputType, component.JSONOutputType:
+		component.NewObjectWriter(cmd.OutOrStdout(), format, infos).Render()
+		return
+	}
+
+	t := component.NewOutputWriter(cmd.OutOrStdout(), string(component.TableOutputType), "QUERY", "TYPE", "NAME", "FOUND", "REASON")
+	for _, info := range infos {
+		reason := info.NotFoundReason
+		if info.Error != "" {
+			reason = info.Error
+		}
+		t.AddRow(info.Query, info.Type, info.Name, info.Found, reason)
+	}
+	t.Render()
+}
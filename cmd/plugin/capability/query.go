@@ -0,0 +1,244 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+// flagsQueryName is the name of the query built from the command line flags.
+const flagsQueryName = "flags"
+
+var (
+	gvrQueries           []string
+	objectQueries        []string
+	partialSchemaQueries []string
+	queriesFile          string
+	viaCapability        bool
+	capabilityNamespace  string
+	serviceAccountName   string
+	capabilityTimeout    time.Duration
+	queryOutputFormat    string
+)
+
+// CapabilityQueryCmd is for running queries against a cluster.
+var CapabilityQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Query the resources, objects and schemas of a cluster",
+	Args:  cobra.NoArgs,
+	Example: `
+	# Query whether a group, a version of it or a resource is served by the cluster
+	tanzu capability query --gvr apps
+	tanzu capability query --gvr apps/v1/deployments --gvr core/v1/configmaps
+
+	# Query whether an object exists. The namespace is omitted for cluster scoped objects.
+	tanzu capability query --object v1/Namespace/tkg-system
+	tanzu capability query --object apps/v1/Deployment/kube-system/coredns
+
+	# Query whether the OpenAPI schema of the cluster contains a partial schema
+	tanzu capability query --partial-schema "$(cat partial-schema.txt)"
+
+	# Run the queries of a file holding the spec of a Capability
+	tanzu capability query -f queries.yaml
+
+	# Run the queries by creating a Capability and waiting for the controller to report its status
+	tanzu capability query -f queries.yaml --via-capability --namespace my-namespace`,
+	SilenceUsage: true,
+	RunE:         capabilityQuery,
+}
+
+func init() {
+	CapabilityQueryCmd.Flags().StringArrayVar(&gvrQueries, "gvr", nil, "Query a GROUP[/VERSION[/RESOURCE]], where core is the core group")
+	CapabilityQueryCmd.Flags().StringArrayVar(&objectQueries, "object", nil, "Query an object by APIVERSION/KIND/[NAMESPACE/]NAME")
+	CapabilityQueryCmd.Flags().StringArrayVar(&partialSchemaQueries, "partial-schema", nil, "Query a partial OpenAPI schema")
+	CapabilityQueryCmd.Flags().StringVarP(&queriesFile, "file", "f", "", "Run the queries of a YAML file holding the spec of a Capability")
+	CapabilityQueryCmd.Flags().BoolVar(&viaCapability, "via-capability", false, "Run the queries by creating a Capability and waiting for its status")
+	CapabilityQueryCmd.Flags().StringVarP(&capabilityNamespace, "namespace", "n", "default", "Namespace of the Capability created with --via-capability")
+	CapabilityQueryCmd.Flags().StringVar(&serviceAccountName, "service-account", "", "Service account the Capability created with --via-capability runs its queries as")
+	CapabilityQueryCmd.Flags().DurationVar(&capabilityTimeout, "timeout", 2*time.Minute, "Time to wait for the status of the Capability created with --via-capability")
+	CapabilityQueryCmd.Flags().StringVarP(&queryOutputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+func capabilityQuery(cmd *cobra.Command, _ []string) error {
+	if err := validateOutputFormat(queryOutputFormat); err != nil {
+		return err
+	}
+
+	var queries []corev1alpha2.Query
+	if queriesFile != "" {
+		fileQueries, err := queriesFromFile(queriesFile)
+		if err != nil {
+			return err
+		}
+		queries = append(queries, fileQueries...)
+	}
+	flagsQuery, err := queryFromFlags(gvrQueries, objectQueries, partialSchemaQueries)
+	if err != nil {
+		return err
+	}
+	if flagsQuery != nil {
+		queries = append(queries, *flagsQuery)
+	}
+	if len(queries) == 0 {
+		return fmt.Errorf("no queries given, use --gvr, --object, --partial-schema or --file")
+	}
+
+	restConfig, err := getCurrentClusterConfig()
+	if err != nil {
+		return fmt.Errorf("could not get cluster config: %w", err)
+	}
+
+	var results []corev1alpha2.Result
+	if viaCapability {
+		crClient, err := newCapabilityClient(restConfig)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), capabilityTimeout)
+		defer cancel()
+		results, err = runQueriesViaCapability(ctx, crClient, capabilityNamespace, serviceAccountName, queries)
+		if err != nil {
+			return err
+		}
+	} else {
+		clusterQueryClient, err := discovery.NewClusterQueryClientForConfig(restConfig)
+		if err != nil {
+			return fmt.Errorf("could not get ClusterQueryClient: %w", err)
+		}
+		results = runQueries(clusterQueryClient, queries)
+	}
+
+	infos := queryResultInfos(results)
+	printQueryResults(cmd, infos, queryOutputFormat)
+	if failed := failedQueryTargets(infos); failed > 0 {
+		return fmt.Errorf("%d of %d query targets were not found or failed", failed, len(infos))
+	}
+	return nil
+}
+
+// queriesFromFile reads the queries of a YAML file holding the spec of a Capability.
+func queriesFromFile(path string) ([]corev1alpha2.Query, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read queries file %s: %w", path, err)
+	}
+	spec := corev1alpha2.CapabilitySpec{}
+	if err := yaml.UnmarshalStrict(data, &spec); err != nil {
+		return nil, fmt.Errorf("could not parse queries file %s: %w", path, err)
+	}
+	if len(spec.Queries) == 0 {
+		return nil, fmt.Errorf("could not find any queries in file %s", path)
+	}
+	return spec.Queries, nil
+}
+
+// queryFromFlags builds a query out of the targets given as flags. It returns nil if no target is given.
+func queryFromFlags(gvrs, objects, partialSchemas []string) (*corev1alpha2.Query, error) {
+	if len(gvrs)+len(objects)+len(partialSchemas) == 0 {
+		return nil, nil
+	}
+
+	query := &corev1alpha2.Query{Name: flagsQueryName}
+	for _, value := range gvrs {
+		gvr, err := parseGVR(value)
+		if err != nil {
+			return nil, err
+		}
+		query.GroupVersionResources = append(query.GroupVersionResources, gvr)
+	}
+	for _, value := range objects {
+		object, err := parseObject(value)
+		if err != nil {
+			return nil, err
+		}
+		query.Objects = append(query.Objects, object)
+	}
+	for i, value := range partialSchemas {
+		query.PartialSchemas = append(query.PartialSchemas, corev1alpha2.QueryPartialSchema{
+			Name:          fmt.Sprintf("partial-schema-%d", i+1),
+			PartialSchema: value,
+		})
+	}
+	return query, nil
+}
+
+// parseGVR parses a GROUP[/VERSION[/RESOURCE]] query target, which is named after the value.
+func parseGVR(value string) (corev1alpha2.QueryGVR, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) > 3 || containsEmptyString(parts) {
+		return corev1alpha2.QueryGVR{}, fmt.Errorf("could not parse GVR %q, must be GROUP[/VERSION[/RESOURCE]]", value)
+	}
+
+	gvr := corev1alpha2.QueryGVR{Name: value, Group: parts[0]}
+	if gvr.Group == "core" {
+		gvr.Group = ""
+	}
+	if len(parts) > 1 {
+		gvr.Versions = []string{parts[1]}
+	}
+	if len(parts) > 2 {
+		gvr.Resource = parts[2]
+	}
+	return gvr, nil
+}
+
+// parseObject parses an APIVERSION/KIND/[NAMESPACE/]NAME query target, which is named after the value. The API
+// version has no group for the core group, so the kind is recognized by its first letter being upper case.
+func parseObject(value string) (corev1alpha2.QueryObject, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) < 3 || containsEmptyString(parts) {
+		return corev1alpha2.QueryObject{}, fmt.Errorf("could not parse object %q, must be APIVERSION/KIND/[NAMESPACE/]NAME", value)
+	}
+
+	apiVersion, rest := parts[0], parts[1:]
+	if !startsWithUpper(rest[0]) {
+		apiVersion, rest = parts[0]+"/"+parts[1], parts[2:]
+	}
+	if len(rest) < 2 || len(rest) > 3 || !startsWithUpper(rest[0]) {
+		return corev1alpha2.QueryObject{}, fmt.Errorf("could not parse object %q, must be APIVERSION/KIND/[NAMESPACE/]NAME", value)
+	}
+
+	ref := corev1.ObjectReference{APIVersion: apiVersion, Kind: rest[0], Name: rest[len(rest)-1]}
+	if len(rest) == 3 {
+		ref.Namespace = rest[1]
+	}
+	return corev1alpha2.QueryObject{Name: value, ObjectReference: ref}, nil
+}
+
+func containsEmptyString(values []string) bool {
+	for _, value := range values {
+		if value == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func startsWithUpper(s string) bool {
+	for _, r := range s {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+func validateOutputFormat(format string) error {
+	switch component.OutputType(format) {
+	case "", component.TableOutputType, component.YAMLOutputType, component.JSONOutputType:
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q, must be yaml, json or table", format)
+}
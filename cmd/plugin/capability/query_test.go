@@ -0,0 +1,193 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testapigroup "k8s.io/apimachinery/pkg/apis/testapigroup/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
+)
+
+func TestParseGVR(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    corev1alpha2.QueryGVR
+		wantErr bool
+	}{
+		{value: "apps", want: corev1alpha2.QueryGVR{Name: "apps", Group: "apps"}},
+		{value: "apps/v1", want: corev1alpha2.QueryGVR{Name: "apps/v1", Group: "apps", Versions: []string{"v1"}}},
+		{value: "apps/v1/deployments", want: corev1alpha2.QueryGVR{Name: "apps/v1/deployments", Group: "apps", Versions: []string{"v1"}, Resource: "deployments"}},
+		{value: "core/v1/configmaps", want: corev1alpha2.QueryGVR{Name: "core/v1/configmaps", Versions: []string{"v1"}, Resource: "configmaps"}},
+		{value: "apps//deployments", wantErr: true},
+		{value: "apps/v1/deployments/extra", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := parseGVR(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error: %v, want error: %t", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got: %+v, want: %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseObject(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    corev1.ObjectReference
+		wantErr bool
+	}{
+		{value: "v1/Namespace/tkg-system", want: corev1.ObjectReference{APIVersion: "v1", Kind: "Namespace", Name: "tkg-system"}},
+		{value: "v1/ConfigMap/kube-system/kubeadm-config", want: corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "kubeadm-config"}},
+		{value: "apps/v1/Deployment/kube-system/coredns", want: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "kube-system", Name: "coredns"}},
+		{value: "core.tanzu.vmware.com/v1alpha2/FeatureGate/tkg-system", want: corev1.ObjectReference{APIVersion: "core.tanzu.vmware.com/v1alpha2", Kind: "FeatureGate", Name: "tkg-system"}},
+		{value: "v1/Namespace", wantErr: true},
+		{value: "apps/v1/deployment/kube-system/coredns", wantErr: true},
+		{value: "apps/v1/Deployment/a/b/c", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := parseObject(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error: %v, want error: %t", err, tc.wantErr)
+			}
+			if err == nil && (got.Name != tc.value || got.ObjectReference != tc.want) {
+				t.Errorf("got: %+v, want: %+v named %s", got, tc.want, tc.value)
+			}
+		})
+	}
+}
+
+func TestQueriesFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queries.yaml")
+	data := `queries:
+- name: carps
+  groupVersionResources:
+  - name: carp-resource
+    group: testapigroup.apimachinery.k8s.io
+    versions:
+    - v1
+    resource: carps
+  objects:
+  - name: carp-object
+    objectReference:
+      apiVersion: testapigroup.apimachinery.k8s.io/v1
+      kind: Carp
+      namespace: testns
+      name: test14
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	queries, err := queriesFromFile(path)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if len(queries) != 1 || queries[0].Name != "carps" || len(queries[0].GroupVersionResources) != 1 || len(queries[0].Objects) != 1 {
+		t.Errorf("got unexpected queries: %+v", queries)
+	}
+
+	if err := os.WriteFile(path, []byte("queries: []\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queriesFromFile(path); err == nil {
+		t.Error("got no error for a file without queries")
+	}
+}
+
+func newTestClusterQueryClient(t *testing.T) *discovery.ClusterQueryClient {
+	scheme := runtime.NewScheme()
+	if err := testapigroup.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	resources := []*metav1.APIResourceList{
+		{
+			GroupVersion: testapigroup.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{
+					Name:       "carps",
+					Kind:       "Carp",
+					Group:      testapigroup.SchemeGroupVersion.Group,
+					Version:    testapigroup.SchemeGroupVersion.Version,
+					Namespaced: true,
+				},
+			},
+		},
+	}
+	objs := []runtime.Object{
+		&testapigroup.Carp{ObjectMeta: metav1.ObjectMeta{Name: "test14", Namespace: "testns"}},
+	}
+	c, err := discovery.NewFakeClusterQueryClient(resources, scheme, objs)
+	if err != nil {
+		t.Fatalf("unable to get ClusterQueryClient: %v", err)
+	}
+	return c
+}
+
+func TestRunQueries(t *testing.T) {
+	query, err := queryFromFlags(
+		[]string{"testapigroup.apimachinery.k8s.io/v1/carps", "testapigroup.apimachinery.k8s.io/v2"},
+		[]string{"testapigroup.apimachinery.k8s.io/v1/Carp/testns/test14", "testapigroup.apimachinery.k8s.io/v1/Carp/testns/test15"},
+		[]string{"info:\n  title: example"},
+	)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+
+	infos := queryResultInfos(runQueries(newTestClusterQueryClient(t), []corev1alpha2.Query{*query}))
+
+	want := []struct {
+		queryType string
+		name      string
+		found     bool
+	}{
+		{queryTypeGVR, "testapigroup.apimachinery.k8s.io/v1/carps", true},
+		{queryTypeGVR, "testapigroup.apimachinery.k8s.io/v2", false},
+		{queryTypeObject, "testapigroup.apimachinery.k8s.io/v1/Carp/testns/test14", true},
+		{queryTypeObject, "testapigroup.apimachinery.k8s.io/v1/Carp/testns/test15", false},
+		{queryTypePartialSchema, "partial-schema-1", false},
+	}
+	if len(infos) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(infos), len(want), infos)
+	}
+	for i, w := range want {
+		got := infos[i]
+		if got.Query != flagsQueryName || got.Type != w.queryType || got.Name != w.name || got.Found != w.found {
+			t.Errorf("got result %+v, want %s %s found: %t", got, w.queryType, w.name, w.found)
+		}
+		if !got.Found && got.NotFoundReason == "" && got.Error == "" {
+			t.Errorf("got no reason for result %+v", got)
+		}
+	}
+	if failed := failedQueryTargets(infos); failed != 3 {
+		t.Errorf("got %d failed query targets, want 3", failed)
+	}
+
+	buf := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(buf)
+	printQueryResults(cmd, infos, "")
+	out := buf.String()
+	if !strings.Contains(out, "REASON") || !strings.Contains(out, infos[1].NotFoundReason) {
+		t.Errorf("got output without the reason %q:\n%s", infos[1].NotFoundReason, out)
+	}
+}
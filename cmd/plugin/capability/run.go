@@ -0,0 +1,111 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
+)
+
+// capabilityPollInterval is how often the status of a Capability is checked while waiting for its results.
+var capabilityPollInterval = time.Second
+
+// runQueries runs the queries with the ClusterQueryClient and returns their results the way the Capability controller
+// reports them, keeping the order of the queries and of their targets.
+func runQueries(clusterQueryClient *discovery.ClusterQueryClient, queries []corev1alpha2.Query) []corev1alpha2.Result {
+	results := make([]corev1alpha2.Result, len(queries))
+	for i := range queries {
+		query := &queries[i]
+		results[i].Name = query.Name
+		for j := range query.GroupVersionResources {
+			q := query.GroupVersionResources[j]
+			target := discovery.Group(q.Name, q.Group).WithVersions(q.Versions...).WithResource(q.Resource)
+			results[i].GroupVersionResources = append(results[i].GroupVersionResources, runQueryTarget(clusterQueryClient, target))
+		}
+		for j := range query.Objects {
+			q := query.Objects[j]
+			target := discovery.Object(q.Name, &q.ObjectReference).WithAnnotations(q.WithAnnotations).WithoutAnnotations(q.WithoutAnnotations)
+			results[i].Objects = append(results[i].Objects, runQueryTarget(clusterQueryClient, target))
+		}
+		for j := range query.PartialSchemas {
+			q := query.PartialSchemas[j]
+			results[i].PartialSchemas = append(results[i].PartialSchemas, runQueryTarget(clusterQueryClient, discovery.Schema(q.Name, q.PartialSchema)))
+		}
+	}
+	return results
+}
+
+func runQueryTarget(clusterQueryClient *discovery.ClusterQueryClient, target discovery.QueryTarget) corev1alpha2.QueryResult {
+	result := corev1alpha2.QueryResult{Name: target.Name()}
+	c := clusterQueryClient.Query(target)
+	found, err := c.Execute()
+	if err != nil {
+		result.Error = true
+		result.ErrorDetail = err.Error()
+	}
+	result.Found = found
+	if !found {
+		if qr := c.Results().ForQuery(target.Name()); qr != nil {
+			result.NotFoundReason = qr.NotFoundReason
+		}
+	}
+	return result
+}
+
+// runQueriesViaCapability creates a Capability with the queries, waits until the Capability controller reports their
+// results and deletes the Capability.
+func runQueriesViaCapability(ctx context.Context, crClient client.Client, namespace, serviceAccountName string, queries []corev1alpha2.Query) ([]corev1alpha2.Result, error) {
+	capability := &corev1alpha2.Capability{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "tanzu-capability-query-",
+			Namespace:    namespace,
+		},
+		Spec: corev1alpha2.CapabilitySpec{
+			ServiceAccountName: serviceAccountName,
+			Queries:            queries,
+		},
+	}
+	if err := crClient.Create(ctx, capability); err != nil {
+		return nil, fmt.Errorf("could not create Capability in namespace %s: %w", namespace, err)
+	}
+	defer func() {
+		// The context may be done already, and the Capability must not be left behind.
+		_ = crClient.Delete(context.Background(), capability)
+	}()
+
+	key := client.ObjectKeyFromObject(capability)
+	err := wait.PollImmediateUntilWithContext(ctx, capabilityPollInterval, func(ctx context.Context) (bool, error) {
+		if err := crClient.Get(ctx, key, capability); err != nil {
+			return false, err
+		}
+		return len(capability.Status.Results) == len(capability.Spec.Queries), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get the results of Capability %s/%s: %w", key.Namespace, key.Name, err)
+	}
+	return capability.Status.Results, nil
+}
+
+// newCapabilityClient returns a client for Capability resources.
+func newCapabilityClient(restConfig *rest.Config) (client.Client, error) {
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("could not add core/v1alpha2 types to scheme: %w", err)
+	}
+	crClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("could not get client for Capabilities: %w", err)
+	}
+	return crClient, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// reportCapabilityResults plays the part of the Capability controller, reporting a found result for every query of the
+// Capabilities in the namespace until the context is done.
+func reportCapabilityResults(ctx context.Context, crClient client.Client, namespace string) {
+	for {
+		capabilities := &corev1alpha2.CapabilityList{}
+		if err := crClient.List(ctx, capabilities, client.InNamespace(namespace)); err == nil {
+			for i := range capabilities.Items {
+				capability := &capabilities.Items[i]
+				capability.Status.Results = make([]corev1alpha2.Result, len(capability.Spec.Queries))
+				for j, query := range capability.Spec.Queries {
+					capability.Status.Results[j].Name = query.Name
+					for _, gvr := range query.GroupVersionResources {
+						capability.Status.Results[j].GroupVersionResources = append(capability.Status.Results[j].GroupVersionResources,
+							corev1alpha2.QueryResult{Name: gvr.Name, Found: true})
+					}
+				}
+				_ = crClient.Status().Update(ctx, capability)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestRunQueriesViaCapability(t *testing.T) {
+	capabilityPollInterval = 10 * time.Millisecond
+
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	queries := []corev1alpha2.Query{
+		{Name: "apps", GroupVersionResources: []corev1alpha2.QueryGVR{{Name: "apps/v1", Group: "apps", Versions: []string{"v1"}}}},
+	}
+
+	t.Run("should return the results reported in the Capability status", func(t *testing.T) {
+		crClient := crclient.NewClientBuilder().WithScheme(scheme).Build()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		go reportCapabilityResults(ctx, crClient, "test-ns")
+
+		results, err := runQueriesViaCapability(ctx, crClient, "test-ns", "test-sa", queries)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		infos := queryResultInfos(results)
+		if len(infos) != 1 || infos[0].Query != "apps" || infos[0].Name != "apps/v1" || !infos[0].Found {
+			t.Errorf("got unexpected results: %+v", infos)
+		}
+
+		capabilities := &corev1alpha2.CapabilityList{}
+		if err := crClient.List(context.Background(), capabilities); err != nil {
+			t.Fatal(err)
+		}
+		if len(capabilities.Items) != 0 {
+			t.Errorf("got %d Capabilities left behind, want none", len(capabilities.Items))
+		}
+	})
+
+	t.Run("should time out when the Capability status is not reported", func(t *testing.T) {
+		crClient := crclient.NewClientBuilder().WithScheme(scheme).Build()
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := runQueriesViaCapability(ctx, crClient, "test-ns", "", queries)
+		if err == nil {
+			t.Fatal("got no error, want a timeout")
+		}
+		if !errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			t.Errorf("got error: %v, want a timeout", err)
+		}
+
+		capabilities := &corev1alpha2.CapabilityList{}
+		if err := crClient.List(context.Background(), capabilities); err != nil {
+			t.Fatal(err)
+		}
+		if len(capabilities.Items) != 0 {
+			t.Errorf("got %d Capabilities left behind, want none", len(capabilities.Items))
+		}
+	})
+}
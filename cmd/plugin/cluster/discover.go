@@ -0,0 +1,166 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:staticcheck // The cluster profile is built on the deprecated tkg DiscoveryClient until it has a replacement.
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery/tkg"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+var discoverOutputFormat string
+
+// ClusterDiscoverCmd is for discovering the profile of a cluster.
+var ClusterDiscoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Discover the profile of a cluster",
+	Args:  cobra.NoArgs,
+	Example: `
+	# Report the flavor, type, providers and Tanzu APIs of the cluster of the current context
+	tanzu cluster discover
+
+	# Report the profile for tooling such as support bundle scripts
+	tanzu cluster discover -o json`,
+	RunE: clusterDiscover,
+}
+
+func init() {
+	ClusterDiscoverCmd.Flags().StringVarP(&discoverOutputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+// ClusterProfile is the result of running every check of the cluster profile.
+type ClusterProfile struct {
+	Checks []ClusterCheck `json:"checks" yaml:"checks"`
+}
+
+// ClusterCheck is the result of a single check of the cluster profile.
+type ClusterCheck struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	Result      bool   `json:"result" yaml:"result"`
+	// Error is set if the check could not be run, in which case Result is meaningless.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// clusterCheck is a check of the cluster profile run with the tkg DiscoveryClient.
+type clusterCheck struct {
+	name        string
+	description string
+	run         func(*tkg.DiscoveryClient, context.Context) (bool, error)
+}
+
+func cloudProviderCheck(provider tkg.CloudProvider) clusterCheck {
+	return clusterCheck{
+		name:        fmt.Sprintf("cloud-provider-%s", provider),
+		description: fmt.Sprintf("Nodes are provided by the %s cloud provider", provider),
+		run: func(dc *tkg.DiscoveryClient, ctx context.Context) (bool, error) {
+			return dc.HasCloudProvider(ctx, provider)
+		},
+	}
+}
+
+func infrastructureProviderCheck(provider tkg.InfrastructureProvider) clusterCheck {
+	return clusterCheck{
+		name:        fmt.Sprintf("infrastructure-provider-%s", provider),
+		description: fmt.Sprintf("Cluster API infrastructure provider is %s", provider),
+		run: func(dc *tkg.DiscoveryClient, ctx context.Context) (bool, error) {
+			return dc.HasInfrastructureProvider(ctx, provider)
+		},
+	}
+}
+
+// clusterChecks are the checks of the cluster profile, in the order they are reported.
+var clusterChecks = []clusterCheck{
+	{name: "tkgs", description: "Cluster is a TKGS cluster", run: (*tkg.DiscoveryClient).IsTKGS},
+	{name: "tkgm", description: "Cluster is a TKGm cluster", run: (*tkg.DiscoveryClient).IsTKGm},
+	{name: "management-cluster", description: "Cluster is a TKG management cluster", run: (*tkg.DiscoveryClient).IsManagementCluster},
+	{name: "workload-cluster", description: "Cluster is a TKG workload cluster", run: (*tkg.DiscoveryClient).IsWorkloadCluster},
+	{name: "nsx", description: "Cluster has NSX", run: (*tkg.DiscoveryClient).HasNSX},
+	cloudProviderCheck(tkg.CloudProviderAWS),
+	cloudProviderCheck(tkg.CloudProviderAzure),
+	cloudProviderCheck(tkg.CloudProviderVsphere),
+	infrastructureProviderCheck(tkg.InfrastructureProviderAWS),
+	infrastructureProviderCheck(tkg.InfrastructureProviderAzure),
+	infrastructureProviderCheck(tkg.InfrastructureProviderVsphere),
+	{
+		name:        "tanzu-run-api",
+		description: "run.tanzu.vmware.com API group is served",
+		run: func(dc *tkg.DiscoveryClient, ctx context.Context) (bool, error) {
+			return dc.HasTanzuRunGroup(ctx)
+		},
+	},
+	{name: "tkc-api-v1alpha1", description: "TanzuKubernetesCluster v1alpha1 API is served", run: (*tkg.DiscoveryClient).HasTanzuKubernetesClusterV1alpha1},
+	{name: "tkr-api-v1alpha1", description: "TanzuKubernetesRelease v1alpha1 API is served", run: (*tkg.DiscoveryClient).HasTanzuKubernetesReleaseV1alpha1},
+}
+
+func clusterDiscover(cmd *cobra.Command, _ []string) error {
+	if err := validateOutputFormat(discoverOutputFormat); err != nil {
+		return err
+	}
+
+	restConfig, err := getCurrentClusterConfig()
+	if err != nil {
+		return fmt.Errorf("could not get cluster config: %w", err)
+	}
+	dc, err := tkg.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("could not get DiscoveryClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	printClusterProfile(cmd, discoverClusterProfile(ctx, dc), discoverOutputFormat)
+	return nil
+}
+
+// discoverClusterProfile runs every check of the cluster profile. A check that fails reports its error, and does not
+// keep the other checks from running.
+func discoverClusterProfile(ctx context.Context, dc *tkg.DiscoveryClient) *ClusterProfile {
+	profile := &ClusterProfile{Checks: make([]ClusterCheck, 0, len(clusterChecks))}
+	for _, check := range clusterChecks {
+		result := ClusterCheck{Name: check.name, Description: check.description}
+		found, err := check.run(dc, ctx)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Result = found
+		}
+		profile.Checks = append(profile.Checks, result)
+	}
+	return profile
+}
+
+// printClusterProfile renders the cluster profile as yaml, json or a table where the result of a check that failed is
+// unknown.
+func printClusterProfile(cmd *cobra.Command, profile *ClusterProfile, format string) {
+	switch component.OutputType(format) {
+	case component.YAMLOutputType, component.JSONOutputType:
+		component.NewObjectWriter(cmd.OutOrStdout(), format, profile).Render()
+		return
+	}
+
+	t := component.NewOutputWriter(cmd.OutOrStdout(), string(component.TableOutputType), "CHECK", "RESULT", "DESCRIPTION", "ERROR")
+	for _, check := range profile.Checks {
+		result := fmt.Sprint(check.Result)
+		if check.Error != "" {
+			result = "unknown"
+		}
+		t.AddRow(check.Name, result, check.Description, check.Error)
+	}
+	t.Render()
+}
+
+func validateOutputFormat(format string) error {
+	switch component.OutputType(format) {
+	case "", component.TableOutputType, component.YAMLOutputType, component.JSONOutputType:
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q, must be yaml, json or table", format)
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:staticcheck // The cluster profile is built on the deprecated tkg DiscoveryClient until it has a replacement.
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterctl "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery/tkg"
+)
+
+var testAPIResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "run.tanzu.vmware.com/v1alpha1",
+		APIResources: []metav1.APIResource{
+			{Name: "tanzukubernetesreleases", Kind: "TanzuKubernetesRelease", Group: "run.tanzu.vmware.com", Version: "v1alpha1"},
+			{Name: "tanzukubernetesclusters", Kind: "TanzuKubernetesCluster", Group: "run.tanzu.vmware.com", Version: "v1alpha1", Namespaced: true},
+		},
+	},
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "namespaces", Kind: "Namespace", Version: "v1"},
+		},
+	},
+}
+
+func newTestDiscoveryClient(t *testing.T, resources []*metav1.APIResourceList, objs ...runtime.Object) *tkg.DiscoveryClient {
+	k8sClient := ctrlfake.NewClientBuilder().WithScheme(tkg.Scheme).WithRuntimeObjects(objs...).Build()
+	queryClient, err := discovery.NewFakeClusterQueryClient(resources, tkg.Scheme, objs)
+	if err != nil {
+		t.Fatalf("unable to get ClusterQueryClient: %v", err)
+	}
+	return tkg.NewDiscoveryClient(k8sClient, queryClient)
+}
+
+func TestDiscoverClusterProfile(t *testing.T) {
+	managementClusterObjs := []runtime.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "tkg-metadata", Namespace: "tkg-system-public"},
+			Data:       map[string]string{"metadata.yaml": "cluster:\n  name: mgmt\n  type: management\n"},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Spec:       corev1.NodeSpec{ProviderID: "vsphere://4201-a2c4"},
+		},
+		&clusterctl.Provider{
+			ObjectMeta:   metav1.ObjectMeta{Name: "infrastructure-vsphere", Namespace: "capv-system"},
+			ProviderName: "vsphere",
+			Type:         string(clusterctl.InfrastructureProviderType),
+		},
+	}
+
+	tests := []struct {
+		description string
+		dc          *tkg.DiscoveryClient
+		want        map[string]bool
+		wantErrors  []string
+	}{
+		{
+			description: "should report the profile of a vSphere management cluster",
+			dc:          newTestDiscoveryClient(t, testAPIResources, managementClusterObjs...),
+			want: map[string]bool{
+				"tkgs":                            true,
+				"tkgm":                            true,
+				"management-cluster":              true,
+				"workload-cluster":                false,
+				"nsx":                             false,
+				"cloud-provider-aws":              false,
+				"cloud-provider-azure":            false,
+				"cloud-provider-vsphere":          true,
+				"infrastructure-provider-aws":     false,
+				"infrastructure-provider-azure":   false,
+				"infrastructure-provider-vsphere": true,
+				"tanzu-run-api":                   true,
+				"tkc-api-v1alpha1":                true,
+				"tkr-api-v1alpha1":                true,
+			},
+		},
+		{
+			description: "should report the errors of the checks that fail and run the others",
+			dc:          newTestDiscoveryClient(t, []*metav1.APIResourceList{}),
+			want: map[string]bool{
+				"tkgs":             false,
+				"tanzu-run-api":    false,
+				"tkc-api-v1alpha1": false,
+				"tkr-api-v1alpha1": false,
+			},
+			wantErrors: []string{
+				"tkgm",
+				"management-cluster",
+				"workload-cluster",
+				"cloud-provider-aws",
+				"cloud-provider-azure",
+				"cloud-provider-vsphere",
+				"infrastructure-provider-aws",
+				"infrastructure-provider-azure",
+				"infrastructure-provider-vsphere",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			profile := discoverClusterProfile(context.Background(), tc.dc)
+			if len(profile.Checks) != len(clusterChecks) {
+				t.Fatalf("got %d checks, want %d", len(profile.Checks), len(clusterChecks))
+			}
+
+			wantErrors := map[string]bool{}
+			for _, name := range tc.wantErrors {
+				wantErrors[name] = true
+			}
+			for _, check := range profile.Checks {
+				if wantErrors[check.Name] {
+					if check.Error == "" {
+						t.Errorf("got no error for check %s", check.Name)
+					}
+					continue
+				}
+				want, ok := tc.want[check.Name]
+				if !ok {
+					continue
+				}
+				if check.Error != "" {
+					t.Errorf("got error for check %s: %s", check.Name, check.Error)
+				} else if check.Result != want {
+					t.Errorf("got %t for check %s, want %t", check.Result, check.Name, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPrintClusterProfile(t *testing.T) {
+	profile := &ClusterProfile{Checks: []ClusterCheck{
+		{Name: "tkgs", Description: "Cluster is a TKGS cluster", Result: true},
+		{Name: "management-cluster", Description: "Cluster is a TKG management cluster", Error: "configmaps \"tkg-metadata\" not found"},
+	}}
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{format: "", want: []string{"CHECK", "tkgs", "true", "management-cluster", "unknown", "configmaps \"tkg-metadata\" not found"}},
+		{format: "json", want: []string{`"name": "tkgs"`, `"result": true`, `"error": "configmaps \"tkg-metadata\" not found"`}},
+		{format: "yaml", want: []string{"- name: tkgs", "result: true", "error: configmaps \"tkg-metadata\" not found"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			cmd := &cobra.Command{}
+			cmd.SetOut(buf)
+			printClusterProfile(cmd, profile, tc.format)
+			for _, want := range tc.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("got output without %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
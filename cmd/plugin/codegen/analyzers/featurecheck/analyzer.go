@@ -0,0 +1,309 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featurecheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/vmware-tanzu/tanzu-framework/cmd/plugin/codegen/generators/feature"
+)
+
+const doc = `report references to Features that are not declared
+
+The featurecheck analyzer finds the Feature names passed as constants to the
+Feature APIs of the featuregates client, such as util.IsFeatureActivated,
+FeatureGateClient.ActivateFeature and FeatureGateInterface.ActivateFeature, or
+set in the FeatureChanges passed to FeatureGateClient.ApplyFeatureChanges, and
+the uses of the typed constants and accessors generated by the feature
+generator. Names that are not declared by a +tanzu:feature marker in the
+package or its dependencies, by a Feature manifest in -features-dir or in
+-features are reported. When no Feature is declared at all, nothing is
+reported.
+
+As the analyzer sees one package at a time, it does not report Features that
+are declared but never checked: run tanzu codegen check, or Check, on all the
+packages for that.`
+
+// Analyzer reports references to Features that are not declared. Features declared but never checked are only
+// reported by Check.
+var Analyzer = &analysis.Analyzer{
+	Name:       "featurecheck",
+	Doc:        doc,
+	Run:        run,
+	FactTypes:  []analysis.Fact{new(declaredFeatures), new(featureAccessor)},
+	ResultType: reflect.TypeOf((*Result)(nil)),
+}
+
+var (
+	featuresDir  string
+	featureNames string
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&featuresDir, "features-dir", "", "directory of Feature manifests declaring Features")
+	Analyzer.Flags.StringVar(&featureNames, "features", "", "comma separated names of declared Features")
+}
+
+// Result holds the Features declared and checked by a package.
+type Result struct {
+	// Declared maps the Features declared by markers in the package to the position of their marker.
+	Declared map[string]token.Pos
+	// Checked holds the names of the Features referenced by the package.
+	Checked map[string]bool
+}
+
+// declaredFeatures is the package fact holding the Features declared by markers in a package.
+type declaredFeatures struct {
+	Names []string
+}
+
+// AFact implements analysis.Fact.
+func (*declaredFeatures) AFact() {}
+
+func (f *declaredFeatures) String() string {
+	return fmt.Sprintf("declaredFeatures(%s)", strings.Join(f.Names, ", "))
+}
+
+// featureAccessor is the object fact marking a generated accessor function of a Feature.
+type featureAccessor struct {
+	Name string
+}
+
+// AFact implements analysis.Fact.
+func (*featureAccessor) AFact() {}
+
+func (f *featureAccessor) String() string {
+	return fmt.Sprintf("featureAccessor(%s)", f.Name)
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	result := &Result{Declared: map[string]token.Pos{}, Checked: map[string]bool{}}
+	collectMarkers(pass, result)
+	if len(result.Declared) > 0 {
+		names := make([]string, 0, len(result.Declared))
+		for name := range result.Declared {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		pass.ExportPackageFact(&declaredFeatures{Names: names})
+	}
+
+	known, err := knownFeatures(pass, result)
+	if err != nil {
+		return nil, err
+	}
+
+	// A feature is reported once per line, as a typed constant passed to a Feature API is both a constant argument
+	// and a use of the constant.
+	reported := map[string]bool{}
+	check := func(pos token.Pos, name string) {
+		result.Checked[name] = true
+		if len(known) == 0 || known[name] {
+			return
+		}
+		key := fmt.Sprintf("%s:%d", name, pass.Fset.Position(pos).Line)
+		if !reported[key] {
+			reported[key] = true
+			pass.Reportf(pos, "feature %q is not declared", name)
+		}
+	}
+
+	var files []*ast.File
+	for _, file := range pass.Files {
+		if isGeneratedFeaturesFile(pass, file) {
+			exportAccessorFacts(pass, file)
+			continue
+		}
+		files = append(files, file)
+	}
+
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.CallExpr:
+				for _, arg := range featureArguments(pass, node) {
+					for _, name := range constantStrings(pass, arg) {
+						check(arg.Pos(), name)
+					}
+				}
+			case *ast.Ident:
+				if name, ok := referencedFeature(pass, node); ok {
+					check(node.Pos(), name)
+				}
+			}
+			return true
+		})
+	}
+	return result, nil
+}
+
+// collectMarkers records the Features declared by +tanzu:feature markers on types, and the Features gating fields as
+// checked.
+func collectMarkers(pass *analysis.Pass, result *Result) {
+	for _, file := range pass.Files {
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+				if !strings.HasPrefix(text, "+"+feature.RuleDefinition.Name+":") {
+					continue
+				}
+				if rule, err := feature.RuleDefinition.Parse(text); err == nil {
+					if _, found := result.Declared[rule.(feature.Rule).Name]; !found {
+						result.Declared[rule.(feature.Rule).Name] = comment.Pos()
+					}
+					continue
+				}
+				if rule, err := feature.FieldRuleDefinition.Parse(text); err == nil {
+					result.Checked[rule.(feature.FieldRule).Name] = true
+				}
+			}
+		}
+	}
+}
+
+// knownFeatures returns the Features declared by the package, its dependencies and the flags of the analyzer.
+func knownFeatures(pass *analysis.Pass, result *Result) (map[string]bool, error) {
+	known := map[string]bool{}
+	for name := range result.Declared {
+		known[name] = true
+	}
+	for _, fact := range pass.AllPackageFacts() {
+		if declared, ok := fact.Fact.(*declaredFeatures); ok {
+			for _, name := range declared.Names {
+				known[name] = true
+			}
+		}
+	}
+	for _, name := range strings.Split(featureNames, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			known[name] = true
+		}
+	}
+	if featuresDir != "" {
+		features, err := feature.LoadFeatures(featuresDir)
+		if err != nil {
+			return nil, err
+		}
+		for i := range features {
+			known[features[i].Name] = true
+		}
+	}
+	return known, nil
+}
+
+// featureArguments returns the arguments holding Feature names of a call to a Feature API, or the receiver of a call
+// to a method of a generated FeatureName.
+func featureArguments(pass *analysis.Pass, call *ast.CallExpr) []ast.Expr {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return nil
+	}
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil && isFeatureNameType(sig.Recv().Type()) {
+		// A method of a generated FeatureName, such as FeatureName("foo").IsActivated(ctx, c).
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+			return []ast.Expr{sel.X}
+		}
+		return nil
+	}
+	index, found := featureAPIs[fn.FullName()]
+	if !found || index >= len(call.Args) {
+		return nil
+	}
+	arg := call.Args[index]
+	lit, ok := arg.(*ast.CompositeLit)
+	if !ok {
+		return []ast.Expr{arg}
+	}
+	var args []ast.Expr
+	for _, elt := range lit.Elts {
+		if change, ok := elt.(*ast.CompositeLit); ok {
+			// A FeatureChange, such as {Name: "foo", Activate: true}.
+			if name := featureChangeName(change); name != nil {
+				args = append(args, name)
+			}
+			continue
+		}
+		args = append(args, elt)
+	}
+	return args
+}
+
+// featureChangeName returns the expression of the Name field of a FeatureChange literal.
+func featureChangeName(change *ast.CompositeLit) ast.Expr {
+	for _, elt := range change.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Name" {
+				return kv.Value
+			}
+		}
+	}
+	return nil
+}
+
+// constantStrings returns the value of a constant string expression.
+func constantStrings(pass *analysis.Pass, expr ast.Expr) []string {
+	tv, found := pass.TypesInfo.Types[expr]
+	if !found || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return nil
+	}
+	return []string{constant.StringVal(tv.Value)}
+}
+
+// referencedFeature returns the Feature referenced by an identifier using a generated FeatureName constant or
+// accessor function.
+func referencedFeature(pass *analysis.Pass, ident *ast.Ident) (string, bool) {
+	switch obj := pass.TypesInfo.Uses[ident].(type) {
+	case *types.Const:
+		if isFeatureNameType(obj.Type()) && obj.Val().Kind() == constant.String {
+			return constant.StringVal(obj.Val()), true
+		}
+	case *types.Func:
+		var accessor featureAccessor
+		if pass.ImportObjectFact(obj, &accessor) {
+			return accessor.Name, true
+		}
+	}
+	return "", false
+}
+
+// exportAccessorFacts marks the accessor functions of a generated features file with the Feature they check.
+func exportAccessorFacts(pass *analysis.Pass, file *ast.File) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil {
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			ident, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if c, ok := pass.TypesInfo.Uses[ident].(*types.Const); ok && isFeatureNameType(c.Type()) {
+				pass.ExportObjectFact(pass.TypesInfo.Defs[fn.Name], &featureAccessor{Name: constant.StringVal(c.Val())})
+				return false
+			}
+			return true
+		})
+	}
+}
+
+func isFeatureNameType(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Name() == generatedFeatureNameType
+}
+
+func isGeneratedFeaturesFile(pass *analysis.Pass, file *ast.File) bool {
+	return filepath.Base(pass.Fset.Position(file.Pos()).Filename) == generatedFeaturesFileName
+}
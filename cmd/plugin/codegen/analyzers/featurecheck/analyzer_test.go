@@ -0,0 +1,56 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featurecheck
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	diagnostics, err := Check("./testdata/apis", "./testdata/controller")
+	if err != nil {
+		t.Fatalf("unable to check packages: %v", err)
+	}
+
+	want := map[string]string{
+		`feature "super-taoster" is not declared`:                              "controller.go:21",
+		`feature "tuna" is not declared`:                                       "controller.go:22",
+		`feature "tuner" is not declared`:                                      "controller.go:23",
+		`feature "tuber" is not declared`:                                      "controller.go:26",
+		`feature "batch-toaster" is not declared`:                              "controller.go:30",
+		`feature "deactivated-toaster" is not declared`:                        "controller.go:31",
+		`feature "changed-toaster" is not declared`:                            "controller.go:32",
+		`feature "dry-toaster" is not declared`:                                "controller.go:33",
+		`feature "reset-toaster" is not declared`:                              "controller.go:34",
+		`feature "waiting-toaster" is not declared`:                            "controller.go:35",
+		`feature "requested-toaster" is not declared`:                          "controller.go:36",
+		`feature "toaster" is not declared`:                                    "controller.go:41",
+		`feature "fake-toaster" is not declared`:                               "controller.go:43",
+		`feature "dodgy-experimental-periscope" is declared but never checked`: "types.go:15",
+	}
+	got := map[string]string{}
+	for _, diagnostic := range diagnostics {
+		got[diagnostic.Message] = filepath.Base(diagnostic.Position.Filename) + ":" + strconv.Itoa(diagnostic.Position.Line)
+	}
+	for message, position := range want {
+		if got[message] != position {
+			t.Errorf("got diagnostic %q at %q, want at %q", message, got[message], position)
+		}
+	}
+	if len(diagnostics) != len(want) {
+		t.Errorf("got %d diagnostics, want %d: %v", len(diagnostics), len(want), diagnostics)
+	}
+}
+
+func TestCheckWithoutDeclarations(t *testing.T) {
+	diagnostics, err := Check("./testdata/controller")
+	if err != nil {
+		t.Fatalf("unable to check packages: %v", err)
+	}
+	if len(diagnostics) != 0 {
+		t.Errorf("got diagnostics without any declared Feature: %v", diagnostics)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featurecheck
+
+const (
+	utilPackage                  = "github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/util"
+	featureGateClientPackage     = "github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	fakeFeatureGateClientPackage = featureGateClientPackage + "/fake"
+	featureGatedPackage          = "github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregated"
+	generatedFeatureNameType     = "FeatureName"
+	generatedFeaturesFileName    = "zz_generated.features.go"
+)
+
+// featureAPIs maps the full names of the functions and methods taking the name of a Feature to the index of the
+// argument holding it.
+var featureAPIs = map[string]int{
+	utilPackage + ".IsFeatureActivated":                                                    2,
+	utilPackage + ".FeatureActivatedInNamespace":                                           3,
+	utilPackage + ".FeaturesActivatedInNamespacesMatchingSelector":                         3,
+	utilPackage + ".GetFeatureGateForFeature":                                              2,
+	utilPackage + ".GetFeatureGateWithFeatureInStatus":                                     2,
+	utilPackage + ".GetFeatureReferenceFromFeatureGate":                                    1,
+	"(*" + featureGateClientPackage + ".FeatureGateClient).GetFeature":                     1,
+	"(*" + featureGateClientPackage + ".FeatureGateClient).ActivateFeature":                1,
+	"(*" + featureGateClientPackage + ".FeatureGateClient).DeactivateFeature":              1,
+	"(*" + featureGateClientPackage + ".FeatureGateClient).ActivateFeatures":               1,
+	"(*" + featureGateClientPackage + ".FeatureGateClient).DeactivateFeatures":             1,
+	"(*" + featureGateClientPackage + ".FeatureGateClient).ApplyFeatureChanges":            1,
+	"(*" + featureGateClientPackage + ".FeatureGateClient).DryRunFeatureChanges":           1,
+	"(*" + featureGateClientPackage + ".FeatureGateClient).ResetFeature":                   1,
+	"(*" + featureGateClientPackage + ".FeatureGateClient).WaitForFeatureState":            1,
+	"(*" + featureGateClientPackage + ".FeatureGateClient).CreateFeatureActivationRequest": 1,
+	"(" + featureGateClientPackage + ".FeatureGateInterface).GetFeature":                   1,
+	"(" + featureGateClientPackage + ".FeatureGateInterface).ActivateFeature":              1,
+	"(" + featureGateClientPackage + ".FeatureGateInterface).DeactivateFeature":            1,
+	"(*" + fakeFeatureGateClientPackage + ".FeatureGateClient).GetFeature":                 1,
+	"(*" + fakeFeatureGateClientPackage + ".FeatureGateClient).ActivateFeature":            1,
+	"(*" + fakeFeatureGateClientPackage + ".FeatureGateClient).DeactivateFeature":          1,
+	featureGateClientPackage + ".FeatureRefFromGateList":                                   1,
+	featureGatedPackage + ".NewControllerManagedBy":                                        1,
+	featureGatedPackage + ".NewWebhookManagedBy":                                           1,
+	featureGatedPackage + ".FeatureToggled":                                                0,
+	featureGatedPackage + ".GateHandler":                                                   1,
+}
@@ -0,0 +1,263 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featurecheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"runtime"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+	"sigs.k8s.io/controller-tools/pkg/loader"
+)
+
+// Diagnostic is a problem with a Feature reference or declaration.
+type Diagnostic struct {
+	Position token.Position
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s", d.Position, d.Message)
+}
+
+// Check runs the Analyzer on the packages matching the patterns. Unlike the Analyzer on its own, which sees a single
+// package at a time, Check also reports the Features declared by markers in these packages that none of them checks.
+// Packages are loaded and type checked like the generators load them.
+func Check(patterns ...string) ([]Diagnostic, error) {
+	fset := token.NewFileSet()
+	// The loader excludes generated files with the ignore_autogenerated tag, but the analysis needs complete packages.
+	roots, err := loader.LoadRootsWithConfig(&packages.Config{Fset: fset, BuildFlags: []string{"-tags="}}, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("could not load packages: %w", err)
+	}
+
+	isRoot := map[*loader.Package]bool{}
+	for _, pkg := range roots {
+		isRoot[pkg] = true
+	}
+
+	facts := newFactStore()
+	checked := map[*loader.Package]bool{}
+	visited := map[*loader.Package]bool{}
+	var diagnostics []Diagnostic
+	type analyzed struct {
+		pkg    *loader.Package
+		result *Result
+	}
+	var results []analyzed
+	var visit func(pkg *loader.Package) error
+	// Imported roots are analyzed before the roots importing them, so that their facts are available.
+	visit = func(pkg *loader.Package) error {
+		if visited[pkg] {
+			return nil
+		}
+		visited[pkg] = true
+		for _, imported := range pkg.Imports() {
+			if isRoot[imported] {
+				if err := visit(imported); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := typeCheck(pkg, fset, checked); err != nil {
+			return err
+		}
+		pass := facts.pass(pkg, func(d analysis.Diagnostic) {
+			diagnostics = append(diagnostics, Diagnostic{Position: pkg.Fset.Position(d.Pos), Message: d.Message})
+		})
+		result, err := Analyzer.Run(pass)
+		if err != nil {
+			return fmt.Errorf("could not analyze %s: %w", pkg.PkgPath, err)
+		}
+		results = append(results, analyzed{pkg: pkg, result: result.(*Result)})
+		return nil
+	}
+	for _, pkg := range roots {
+		if err := visit(pkg); err != nil {
+			return nil, err
+		}
+	}
+
+	checkedFeatures := map[string]bool{}
+	for _, a := range results {
+		for name := range a.result.Checked {
+			checkedFeatures[name] = true
+		}
+	}
+	for _, a := range results {
+		for name, pos := range a.result.Declared {
+			if !checkedFeatures[name] {
+				diagnostics = append(diagnostics, Diagnostic{
+					Position: a.pkg.Fset.Position(pos),
+					Message:  fmt.Sprintf("feature %q is declared but never checked", name),
+				})
+			}
+		}
+	}
+
+	sort.Slice(diagnostics, func(i, j int) bool {
+		return diagnostics[i].String() < diagnostics[j].String()
+	})
+	return diagnostics, nil
+}
+
+// typeCheck type checks a root package including its function bodies, which the loader skips, after type checking
+// the declarations of the packages it transitively imports.
+func typeCheck(pkg *loader.Package, fset *token.FileSet, checked map[*loader.Package]bool) error {
+	if err := typeCheckImports(pkg, checked); err != nil {
+		return err
+	}
+
+	ensureSizes(pkg)
+	pkg.NeedSyntax()
+	pkg.Fset = fset
+	pkg.Types = types.NewPackage(pkg.PkgPath, pkg.Name)
+	pkg.TypesInfo = &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Implicits:  map[ast.Node]types.Object{},
+		Scopes:     map[ast.Node]*types.Scope{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+	}
+	config := &types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			if path == "unsafe" {
+				return types.Unsafe, nil
+			}
+			imported := pkg.Imports()[path]
+			if imported == nil || imported.Types == nil {
+				return nil, fmt.Errorf("package %q is not loaded", path)
+			}
+			return imported.Types, nil
+		}),
+		Sizes: pkg.TypesSizes,
+		Error: func(err error) {
+			pkg.AddError(err)
+		},
+	}
+	_ = types.NewChecker(config, pkg.Fset, pkg.Types, pkg.TypesInfo).Files(pkg.Syntax)
+	checked[pkg] = true
+
+	if len(pkg.Errors) > 0 {
+		loader.PrintErrors([]*loader.Package{pkg})
+		return fmt.Errorf("could not load package %s", pkg.PkgPath)
+	}
+	return nil
+}
+
+// typeCheckImports type checks the declarations of the packages imported by pkg, dependencies first.
+func typeCheckImports(pkg *loader.Package, checked map[*loader.Package]bool) error {
+	for _, imported := range pkg.Imports() {
+		if checked[imported] {
+			continue
+		}
+		checked[imported] = true
+		ensureSizes(imported)
+		if err := typeCheckImports(imported, checked); err != nil {
+			return err
+		}
+		imported.NeedTypesInfo()
+		if imported.IllTyped {
+			loader.PrintErrors([]*loader.Package{imported})
+			return fmt.Errorf("could not load package %s", imported.PkgPath)
+		}
+	}
+	return nil
+}
+
+// ensureSizes defaults the type sizes of a package to those of the gc compiler for the current architecture when the
+// go command did not report them.
+func ensureSizes(pkg *loader.Package) {
+	if sizes, ok := pkg.TypesSizes.(*types.StdSizes); pkg.TypesSizes == nil || (ok && sizes == nil) {
+		pkg.TypesSizes = types.SizesFor("gc", runtime.GOARCH)
+	}
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+// factStore holds the facts exported by the packages analyzed by Check.
+type factStore struct {
+	packageFacts map[string][]analysis.Fact
+	objectFacts  map[string][]analysis.ObjectFact
+}
+
+func newFactStore() *factStore {
+	return &factStore{packageFacts: map[string][]analysis.Fact{}, objectFacts: map[string][]analysis.ObjectFact{}}
+}
+
+// objectKey identifies a package level object across type checker runs.
+func objectKey(obj types.Object) string {
+	if obj.Pkg() == nil {
+		return obj.Name()
+	}
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// pass returns the analysis pass of a package, backed by the fact store.
+func (s *factStore) pass(pkg *loader.Package, report func(analysis.Diagnostic)) *analysis.Pass {
+	return &analysis.Pass{
+		Analyzer:   Analyzer,
+		Fset:       pkg.Fset,
+		Files:      pkg.Syntax,
+		OtherFiles: pkg.OtherFiles,
+		Pkg:        pkg.Types,
+		TypesInfo:  pkg.TypesInfo,
+		TypesSizes: pkg.TypesSizes,
+		ResultOf:   map[*analysis.Analyzer]interface{}{},
+		Report:     report,
+		ImportObjectFact: func(obj types.Object, fact analysis.Fact) bool {
+			var stored []analysis.Fact
+			for _, objectFact := range s.objectFacts[objectKey(obj)] {
+				stored = append(stored, objectFact.Fact)
+			}
+			return importFact(stored, fact)
+		},
+		ExportObjectFact: func(obj types.Object, fact analysis.Fact) {
+			s.objectFacts[objectKey(obj)] = append(s.objectFacts[objectKey(obj)], analysis.ObjectFact{Object: obj, Fact: fact})
+		},
+		ImportPackageFact: func(p *types.Package, fact analysis.Fact) bool {
+			return importFact(s.packageFacts[p.Path()], fact)
+		},
+		ExportPackageFact: func(fact analysis.Fact) {
+			s.packageFacts[pkg.PkgPath] = append(s.packageFacts[pkg.PkgPath], fact)
+		},
+		AllPackageFacts: func() []analysis.PackageFact {
+			var all []analysis.PackageFact
+			for path, facts := range s.packageFacts {
+				for _, fact := range facts {
+					all = append(all, analysis.PackageFact{Package: types.NewPackage(path, ""), Fact: fact})
+				}
+			}
+			return all
+		},
+		AllObjectFacts: func() []analysis.ObjectFact {
+			var all []analysis.ObjectFact
+			for _, facts := range s.objectFacts {
+				all = append(all, facts...)
+			}
+			return all
+		},
+	}
+}
+
+// importFact copies the stored fact of the same type as fact into fact.
+func importFact(stored []analysis.Fact, fact analysis.Fact) bool {
+	for _, f := range stored {
+		if reflect.TypeOf(f) == reflect.TypeOf(fact) {
+			reflect.ValueOf(fact).Elem().Set(reflect.ValueOf(f).Elem())
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main provides the featurecheck vet tool
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/vmware-tanzu/tanzu-framework/cmd/plugin/codegen/analyzers/featurecheck"
+)
+
+func main() {
+	singlechecker.Main(featurecheck.Analyzer)
+}
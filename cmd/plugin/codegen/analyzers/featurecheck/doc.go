@@ -0,0 +1,5 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package featurecheck provides an analyzer that reports references to Features that are not declared
+package featurecheck
@@ -0,0 +1,20 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apis
+
+// WidgetSpec defines the desired state of Widget
+type WidgetSpec struct {
+	//+tanzu:feature:name=widget-colors
+	Color string `json:"color,omitempty"`
+}
+
+//+tanzu:feature:name=widget-colors,stability=Technical Preview
+//+tanzu:feature:name=super-toaster,stability=Stable
+//+tanzu:feature:name=periscope,stability=Experimental
+//+tanzu:feature:name=dodgy-experimental-periscope,stability=Experimental
+
+// Widget is the Schema for the widgets API
+type Widget struct {
+	Spec WidgetSpec `json:"spec,omitempty"`
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/tanzu-framework/cmd/plugin/codegen/analyzers/featurecheck/testdata/apis"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/util"
+)
+
+const misspelled = "super-taoster"
+
+func reconcile(ctx context.Context, fgc *featuregateclient.FeatureGateClient) {
+	_, _ = util.IsFeatureActivated(ctx, nil, "super-toaster")
+	_, _ = util.IsFeatureActivated(ctx, nil, misspelled)
+	_, _ = fgc.ActivateFeature(ctx, "tuna", false)
+	_, _ = util.FeaturesActivatedInNamespacesMatchingSelector(ctx, nil, metav1.LabelSelector{}, []string{"super-toaster", "tuner"})
+	_, _ = util.IsFeatureActivated(ctx, nil, string(apis.SuperToasterFeature))
+	_, _ = apis.IsPeriscopeActivated(ctx, nil)
+	_, _ = apis.FeatureName("tuber").IsActivated(ctx, nil)
+}
+
+func applyChanges(ctx context.Context, fgc *featuregateclient.FeatureGateClient) {
+	_, _ = fgc.ActivateFeatures(ctx, []string{"periscope", "batch-toaster"}, false)
+	_, _ = fgc.DeactivateFeatures(ctx, []string{"deactivated-toaster"})
+	_, _ = fgc.ApplyFeatureChanges(ctx, []featuregateclient.FeatureChange{{Name: "super-toaster", Activate: true}, {Name: "changed-toaster"}})
+	_, _ = fgc.DryRunFeatureChanges(ctx, []featuregateclient.FeatureChange{{Name: "dry-toaster"}})
+	_, _ = fgc.ResetFeature(ctx, "reset-toaster")
+	_ = fgc.WaitForFeatureState(ctx, "waiting-toaster", true)
+	_, _ = fgc.CreateFeatureActivationRequest(ctx, "requested-toaster", true, "")
+}
+
+func reconcileThroughInterface(ctx context.Context, fgi featuregateclient.FeatureGateInterface, fgc *fake.FeatureGateClient) {
+	_, _ = fgi.GetFeature(ctx, "periscope")
+	_, _ = fgi.ActivateFeature(ctx, "toaster", false)
+	_, _ = fgi.DeactivateFeature(ctx, "super-toaster")
+	_, _ = fgc.ActivateFeature(ctx, "fake-toaster", false)
+}
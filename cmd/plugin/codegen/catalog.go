@@ -0,0 +1,57 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/cmd/plugin/codegen/generators/feature"
+)
+
+var (
+	catalogFeaturesDir string
+	catalogFormat      string
+	catalogOutput      string
+)
+
+// CatalogCmd renders a catalog of the Features defined in a directory of Feature manifests.
+var CatalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Generate a catalog of Features from Feature manifests.",
+	Long: "Generate a Markdown or HTML catalog of the Features defined in a directory of Feature manifests, " +
+		"grouped by stability level.",
+	Example: `
+	# Render the Features generated by the feature generator as Markdown
+	tanzu codegen catalog --features-dir config/features --output docs/features.md`,
+	Args: cobra.NoArgs,
+	RunE: runCatalog,
+}
+
+func init() {
+	CatalogCmd.Flags().StringVar(&catalogFeaturesDir, "features-dir", "", "Directory holding the Feature manifests")
+	CatalogCmd.Flags().StringVar(&catalogFormat, "format", feature.CatalogMarkdown, "Format of the catalog, markdown or html")
+	CatalogCmd.Flags().StringVarP(&catalogOutput, "output", "o", "", "File to write the catalog to, standard output if not set")
+	_ = CatalogCmd.MarkFlagRequired("features-dir")
+}
+
+func runCatalog(cmd *cobra.Command, _ []string) error {
+	features, err := feature.LoadFeatures(catalogFeaturesDir)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if catalogOutput != "" {
+		f, err := os.Create(catalogOutput)
+		if err != nil {
+			return fmt.Errorf("could not create %s: %w", catalogOutput, err)
+		}
+		defer f.Close()
+		out = f
+	}
+	return feature.RenderCatalog(out, features, catalogFormat)
+}
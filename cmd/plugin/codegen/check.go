@@ -0,0 +1,61 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/cmd/plugin/codegen/analyzers/featurecheck"
+)
+
+var (
+	checkFeaturesDir string
+	checkFeatures    string
+)
+
+// CheckCmd reports references to undeclared Features and declared Features that are never checked.
+var CheckCmd = &cobra.Command{
+	Use:   "check [packages]",
+	Short: "Check references to Features in Go packages.",
+	Long: "Report Feature names passed to the Feature APIs that are not declared by a +tanzu:feature marker or " +
+		"manifest, and Features declared by markers that are never checked.",
+	Example: `
+	# Check all packages of the current module
+	tanzu codegen check ./...
+
+	# Also accept the Features of a directory of Feature manifests
+	tanzu codegen check --features-dir config/features ./...`,
+	RunE: runCheck,
+}
+
+func init() {
+	CheckCmd.Flags().StringVar(&checkFeaturesDir, "features-dir", "", "Directory of Feature manifests declaring Features")
+	CheckCmd.Flags().StringVar(&checkFeatures, "features", "", "Comma separated names of declared Features")
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		args = []string{"./..."}
+	}
+	if err := featurecheck.Analyzer.Flags.Set("features-dir", checkFeaturesDir); err != nil {
+		return err
+	}
+	if err := featurecheck.Analyzer.Flags.Set("features", checkFeatures); err != nil {
+		return err
+	}
+
+	diagnostics, err := featurecheck.Check(args...)
+	if err != nil {
+		return err
+	}
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintln(cmd.OutOrStdout(), diagnostic)
+	}
+	if len(diagnostics) > 0 {
+		return fmt.Errorf("found %d problems with Feature references", len(diagnostics))
+	}
+	return nil
+}
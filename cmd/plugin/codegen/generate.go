@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/controller-tools/pkg/genall"
 	"sigs.k8s.io/controller-tools/pkg/markers"
 
+	"github.com/vmware-tanzu/tanzu-framework/cmd/plugin/codegen/generators/capability"
 	"github.com/vmware-tanzu/tanzu-framework/cmd/plugin/codegen/generators/feature"
 )
 
@@ -24,7 +25,8 @@ var (
 
 	// allGenerators maintains the list of all known generators
 	allGenerators = map[string]genall.Generator{
-		"feature": feature.Generator{},
+		"feature":    feature.Generator{},
+		"capability": capability.Generator{},
 	}
 
 	// allOutputRules defines the list of all known output rules
@@ -0,0 +1,6 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fakedata provides data needed for testing
+// +tanzu:capability:gvr:capability=megacache-requirements,namespace=tkg-system,group=cluster.x-k8s.io
+package fakedata
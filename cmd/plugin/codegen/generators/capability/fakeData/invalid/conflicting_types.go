@@ -0,0 +1,13 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package invalid
+
+//+tanzu:capability:gvr:capability=conflicting,namespace=default,group=apps
+//+tanzu:capability:gvr:capability=conflicting,namespace=kube-system,group=batch
+//+tanzu:capability:gvr:capability=conflicting,name=workloads,group=apps,versions=v1
+//+tanzu:capability:gvr:capability=conflicting,name=workloads,group=apps,versions=v2
+//+tanzu:capability:gvr:capability=conflicting,resource=deployments
+
+// Conflicting declares conflicting and invalid capability queries
+type Conflicting struct{}
@@ -0,0 +1,23 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fakedata
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// MegaCacheSpec defines the desired state of MegaCache
+type MegaCacheSpec struct {
+	Size int `json:"size,omitempty"`
+}
+
+//+tanzu:capability:gvr:capability=megacache-requirements,group=apps,versions=v1,resource=deployments
+//+tanzu:capability:gvr:capability=megacache-requirements,group=apps,versions=v1,resource=deployments
+//+tanzu:capability:object:capability=megacache-requirements,apiVersion=v1,kind=Namespace,objectName=tkg-system,withAnnotations={"tkg.tanzu.vmware.com/managed": "true"}
+//+tanzu:capability:partialSchema:capability=megacache-requirements,query=schemas,schema=`type: object`
+
+// MegaCache is the Schema for the megacaches API
+type MegaCache struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              MegaCacheSpec `json:"spec,omitempty"`
+}
@@ -0,0 +1,325 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package capability
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-tools/pkg/genall"
+	"sigs.k8s.io/controller-tools/pkg/loader"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// Generator is capability generator that registers capability markers and produces Capability resources
+type Generator struct{}
+
+// Generate generates the Capability resources declared by capability markers.
+func (Generator) Generate(ctx *genall.GenerationContext) error {
+	capabilities := generateCapabilities(ctx)
+	for i := range capabilities {
+		if err := ctx.WriteYAML(capabilities[i].Name+".yaml", capabilities[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// capabilityBuilder accumulates the queries of a Capability declared by markers.
+type capabilityBuilder struct {
+	capability corev1alpha2.Capability
+	queries    map[string]*corev1alpha2.Query
+}
+
+// builders accumulates the Capabilities declared by markers, by name.
+type builders map[string]*capabilityBuilder
+
+// generateCapabilities returns the Capabilities declared by capability markers on the packages and types of the
+// roots. Queries are named after the type or package carrying the marker unless named explicitly, and Capabilities,
+// queries and their GVR, object and partial schema queries are sorted by name so that the output is deterministic.
+// Invalid and conflicting markers are reported as errors of the package declaring them.
+func generateCapabilities(ctx *genall.GenerationContext) []corev1alpha2.Capability {
+	b := builders{}
+	for _, root := range ctx.Roots {
+		pkgMarkers, err := markers.PackageMarkers(ctx.Collector, root)
+		if err != nil {
+			root.AddError(err)
+			continue
+		}
+		for _, value := range capabilityMarkerValues(pkgMarkers) {
+			if err := b.add(value, strings.ToLower(root.Name)); err != nil {
+				root.AddError(fmt.Errorf("package %s: %w", root.Name, err))
+			}
+		}
+
+		if err := markers.EachType(ctx.Collector, root, func(info *markers.TypeInfo) {
+			for _, value := range capabilityMarkerValues(info.Markers) {
+				if err := b.add(value, strings.ToLower(info.Name)); err != nil {
+					root.AddError(loader.ErrFromNode(err, info.RawSpec))
+				}
+			}
+		}); err != nil {
+			root.AddError(err)
+		}
+	}
+	return b.capabilities()
+}
+
+// capabilityMarkerValues returns the values of the capability markers, in a fixed order of marker kinds.
+func capabilityMarkerValues(markerValues markers.MarkerValues) []interface{} {
+	var values []interface{}
+	for _, name := range []string{gvrMarkerName, objectMarkerName, partialSchemaMarkerName} {
+		values = append(values, markerValues[name]...)
+	}
+	return values
+}
+
+// add adds the query declared by a marker value to its Capability.
+func (b builders) add(value interface{}, defaultQuery string) error {
+	switch rule := value.(type) {
+	case GVRRule:
+		if err := validateGVRRule(rule); err != nil {
+			return err
+		}
+		query, err := b.query(rule.Capability, rule.Namespace, rule.ServiceAccountName, rule.Query, defaultQuery)
+		if err != nil {
+			return err
+		}
+		gvr := corev1alpha2.QueryGVR{
+			Name:     rule.Name,
+			Group:    rule.Group,
+			Versions: rule.Versions,
+			Resource: rule.Resource,
+		}
+		if gvr.Name == "" {
+			gvr.Name = gvrQueryName(rule)
+		}
+		for _, existing := range query.GroupVersionResources {
+			if existing.Name == gvr.Name {
+				return conflictOrNil(reflect.DeepEqual(existing, gvr), "GVR", gvr.Name, query.Name, rule.Capability)
+			}
+		}
+		query.GroupVersionResources = append(query.GroupVersionResources, gvr)
+	case ObjectRule:
+		if err := validateObjectRule(rule); err != nil {
+			return err
+		}
+		query, err := b.query(rule.Capability, rule.Namespace, rule.ServiceAccountName, rule.Query, defaultQuery)
+		if err != nil {
+			return err
+		}
+		object := corev1alpha2.QueryObject{
+			Name: rule.Name,
+			ObjectReference: corev1.ObjectReference{
+				APIVersion: rule.APIVersion,
+				Kind:       rule.Kind,
+				Name:       rule.ObjectName,
+				Namespace:  rule.ObjectNamespace,
+			},
+			WithAnnotations:    rule.WithAnnotations,
+			WithoutAnnotations: rule.WithoutAnnotations,
+		}
+		if object.Name == "" {
+			object.Name = objectQueryName(rule)
+		}
+		for _, existing := range query.Objects {
+			if existing.Name == object.Name {
+				return conflictOrNil(reflect.DeepEqual(existing, object), "object", object.Name, query.Name, rule.Capability)
+			}
+		}
+		query.Objects = append(query.Objects, object)
+	case PartialSchemaRule:
+		if err := validatePartialSchemaRule(rule); err != nil {
+			return err
+		}
+		query, err := b.query(rule.Capability, rule.Namespace, rule.ServiceAccountName, rule.Query, defaultQuery)
+		if err != nil {
+			return err
+		}
+		schema := corev1alpha2.QueryPartialSchema{
+			Name:          rule.Name,
+			PartialSchema: rule.Schema,
+		}
+		if schema.Name == "" {
+			schema.Name = partialSchemaQueryName(rule)
+		}
+		for _, existing := range query.PartialSchemas {
+			if existing.Name == schema.Name {
+				return conflictOrNil(existing == schema, "partial schema", schema.Name, query.Name, rule.Capability)
+			}
+		}
+		query.PartialSchemas = append(query.PartialSchemas, schema)
+	}
+	return nil
+}
+
+// query returns the query of a Capability, creating both if needed. The namespace and service account name of a
+// Capability may be specified by any of its markers, but must not conflict.
+func (b builders) query(capabilityName, namespace, serviceAccountName, queryName, defaultQuery string) (*corev1alpha2.Query, error) {
+	cb, found := b[capabilityName]
+	if !found {
+		cb = &capabilityBuilder{
+			capability: corev1alpha2.Capability{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Capability",
+					APIVersion: corev1alpha2.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: capabilityName,
+				},
+			},
+			queries: map[string]*corev1alpha2.Query{},
+		}
+		b[capabilityName] = cb
+	}
+
+	if err := mergeField(&cb.capability.Namespace, namespace, "namespace", capabilityName); err != nil {
+		return nil, err
+	}
+	if err := mergeField(&cb.capability.Spec.ServiceAccountName, serviceAccountName, "service account name", capabilityName); err != nil {
+		return nil, err
+	}
+
+	if queryName == "" {
+		queryName = defaultQuery
+	}
+	query, found := cb.queries[queryName]
+	if !found {
+		query = &corev1alpha2.Query{Name: queryName}
+		cb.queries[queryName] = query
+	}
+	return query, nil
+}
+
+// capabilities returns the accumulated Capabilities sorted by name, with their queries sorted by name.
+func (b builders) capabilities() []corev1alpha2.Capability {
+	capabilities := make([]corev1alpha2.Capability, 0, len(b))
+	for _, cb := range b {
+		capability := cb.capability
+		for _, query := range cb.queries {
+			sort.Slice(query.GroupVersionResources, func(i, j int) bool {
+				return query.GroupVersionResources[i].Name < query.GroupVersionResources[j].Name
+			})
+			sort.Slice(query.Objects, func(i, j int) bool {
+				return query.Objects[i].Name < query.Objects[j].Name
+			})
+			sort.Slice(query.PartialSchemas, func(i, j int) bool {
+				return query.PartialSchemas[i].Name < query.PartialSchemas[j].Name
+			})
+			capability.Spec.Queries = append(capability.Spec.Queries, *query)
+		}
+		sort.Slice(capability.Spec.Queries, func(i, j int) bool {
+			return capability.Spec.Queries[i].Name < capability.Spec.Queries[j].Name
+		})
+		capabilities = append(capabilities, capability)
+	}
+	sort.Slice(capabilities, func(i, j int) bool {
+		return capabilities[i].Name < capabilities[j].Name
+	})
+	return capabilities
+}
+
+// mergeField sets field to value if it is unset, and reports a conflict if it is set to a different value.
+func mergeField(field *string, value, description, capabilityName string) error {
+	if value == "" || *field == value {
+		return nil
+	}
+	if *field != "" {
+		return fmt.Errorf("conflicting %s %q and %q for capability %q", description, *field, value, capabilityName)
+	}
+	*field = value
+	return nil
+}
+
+// conflictOrNil returns an error if a query of the same kind and name with a different spec is already declared.
+func conflictOrNil(equal bool, kind, name, queryName, capabilityName string) error {
+	if equal {
+		return nil
+	}
+	return fmt.Errorf("conflicting %s queries named %q in query %q of capability %q", kind, name, queryName, capabilityName)
+}
+
+// gvrQueryName derives the name of a GVR query from its resource, group and versions, e.g. deployments.apps-v1.
+func gvrQueryName(rule GVRRule) string {
+	group := rule.Group
+	if group == "" {
+		group = "core"
+	}
+	name := group
+	if rule.Resource != "" {
+		name = rule.Resource + "." + group
+	}
+	if len(rule.Versions) > 0 {
+		name += "-" + strings.Join(rule.Versions, "-")
+	}
+	return name
+}
+
+// objectQueryName derives the name of an object query from the kind, namespace and name of the object, e.g.
+// configmap-kube-system-foo.
+func objectQueryName(rule ObjectRule) string {
+	parts := []string{strings.ToLower(rule.Kind)}
+	if rule.ObjectNamespace != "" {
+		parts = append(parts, rule.ObjectNamespace)
+	}
+	return strings.Join(append(parts, rule.ObjectName), "-")
+}
+
+// partialSchemaQueryName derives the name of a partial schema query from a hash of the schema.
+func partialSchemaQueryName(rule PartialSchemaRule) string {
+	return fmt.Sprintf("partialschema-%x", sha256.Sum256([]byte(rule.Schema)))[:len("partialschema-")+10]
+}
+
+func validateCapability(capabilityName, namespace string) error {
+	if msgs := validation.IsDNS1123Subdomain(capabilityName); len(msgs) > 0 {
+		return fmt.Errorf("invalid capability name %q: %s", capabilityName, strings.Join(msgs, ", "))
+	}
+	if namespace != "" {
+		if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+			return fmt.Errorf("invalid namespace %q of capability %q: %s", namespace, capabilityName, strings.Join(msgs, ", "))
+		}
+	}
+	return nil
+}
+
+func validateGVRRule(rule GVRRule) error {
+	if err := validateCapability(rule.Capability, rule.Namespace); err != nil {
+		return err
+	}
+	if rule.Group == "" && len(rule.Versions) == 0 && rule.Resource == "" {
+		return fmt.Errorf("GVR query of capability %q must specify a group, versions or a resource", rule.Capability)
+	}
+	if rule.Resource != "" && len(rule.Versions) == 0 {
+		return fmt.Errorf("GVR query of capability %q specifying a resource must specify at least one version", rule.Capability)
+	}
+	return nil
+}
+
+func validateObjectRule(rule ObjectRule) error {
+	if err := validateCapability(rule.Capability, rule.Namespace); err != nil {
+		return err
+	}
+	if rule.APIVersion == "" || rule.Kind == "" || rule.ObjectName == "" {
+		return fmt.Errorf("object query of capability %q must specify apiVersion, kind and objectName", rule.Capability)
+	}
+	return nil
+}
+
+func validatePartialSchemaRule(rule PartialSchemaRule) error {
+	if err := validateCapability(rule.Capability, rule.Namespace); err != nil {
+		return err
+	}
+	if rule.Schema == "" {
+		return fmt.Errorf("partial schema query of capability %q must specify a schema", rule.Capability)
+	}
+	return nil
+}
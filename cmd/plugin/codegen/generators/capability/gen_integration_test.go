@@ -0,0 +1,91 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package capability
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-tools/pkg/genall"
+	"sigs.k8s.io/controller-tools/pkg/loader"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+	"sigs.k8s.io/yaml"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+var _ = Describe("Capability CR generated by the Capability Generator", func() {
+	It("should generate a Capability CR from package and type markers", func() {
+		cwd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir("./fakeData")).To(Succeed())
+		defer func() { Expect(os.Chdir(cwd)).To(Succeed()) }()
+
+		By("loading the roots")
+		pkgs, err := loader.LoadRoots("./doc.go", "./megacache_types.go")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("registering Capability markers")
+		reg := &markers.Registry{}
+		Expect(Generator{}.RegisterMarkers(reg)).To(Succeed())
+
+		By("creating GenerationContext")
+		ctx := &genall.GenerationContext{
+			Collector: &markers.Collector{Registry: reg},
+			Roots:     pkgs,
+		}
+
+		By("generating a Capability")
+		capabilities := generateCapabilities(ctx)
+		Expect(pkgs[0].Errors).To(BeEmpty())
+		Expect(len(capabilities)).To(Equal(1))
+
+		By("loading the desired YAML")
+		capabilityCRBytes, err := os.ReadFile("./megacache-requirements.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("comparing the generated Capability and expected Capability")
+		var expectedCapability corev1alpha2.Capability
+		Expect(yaml.Unmarshal(capabilityCRBytes, &expectedCapability)).To(Succeed())
+		Expect(capabilities[0]).To(Equal(expectedCapability))
+	})
+
+	It("should reject invalid and conflicting Capability markers", func() {
+		cwd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir("./fakeData")).To(Succeed())
+		defer func() { Expect(os.Chdir(cwd)).To(Succeed()) }()
+
+		By("loading the roots")
+		pkgs, err := loader.LoadRoots("./invalid/...")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("registering Capability markers")
+		reg := &markers.Registry{}
+		Expect(Generator{}.RegisterMarkers(reg)).To(Succeed())
+
+		By("creating GenerationContext")
+		ctx := &genall.GenerationContext{
+			Collector: &markers.Collector{Registry: reg},
+			Roots:     pkgs,
+		}
+
+		By("generating the Capabilities")
+		capabilities := generateCapabilities(ctx)
+		Expect(len(capabilities)).To(Equal(1))
+		Expect(capabilities[0].Namespace).To(Equal("default"))
+
+		By("checking the errors")
+		var messages []string
+		for _, err := range pkgs[0].Errors {
+			messages = append(messages, err.Error())
+		}
+		Expect(messages).To(ConsistOf(
+			MatchRegexp(`conflicting_types.go:13:6: conflicting namespace "default" and "kube-system"`),
+			MatchRegexp(`conflicting_types.go:13:6: conflicting GVR queries named "workloads"`),
+			MatchRegexp(`conflicting_types.go:13:6: GVR query .* specifying a resource must specify at least one version`),
+		))
+	})
+})
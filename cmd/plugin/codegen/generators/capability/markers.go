@@ -0,0 +1,182 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package capability
+
+import (
+	"sigs.k8s.io/controller-tools/pkg/markers"
+)
+
+const (
+	gvrMarkerName           = "tanzu:capability:gvr"
+	objectMarkerName        = "tanzu:capability:object"
+	partialSchemaMarkerName = "tanzu:capability:partialSchema"
+)
+
+var (
+	// GVRRuleDefinition is a marker for requiring an API group, versions or resource on types.
+	GVRRuleDefinition = markers.Must(markers.MakeDefinition(gvrMarkerName, markers.DescribesType, GVRRule{}))
+	// PackageGVRRuleDefinition is a marker for requiring an API group, versions or resource on packages.
+	PackageGVRRuleDefinition = markers.Must(markers.MakeDefinition(gvrMarkerName, markers.DescribesPackage, GVRRule{}))
+
+	// ObjectRuleDefinition is a marker for requiring an object on types.
+	ObjectRuleDefinition = markers.Must(markers.MakeDefinition(objectMarkerName, markers.DescribesType, ObjectRule{}))
+	// PackageObjectRuleDefinition is a marker for requiring an object on packages.
+	PackageObjectRuleDefinition = markers.Must(markers.MakeDefinition(objectMarkerName, markers.DescribesPackage, ObjectRule{}))
+
+	// PartialSchemaRuleDefinition is a marker for requiring a partial OpenAPI schema on types.
+	PartialSchemaRuleDefinition = markers.Must(markers.MakeDefinition(partialSchemaMarkerName, markers.DescribesType, PartialSchemaRule{}))
+	// PackagePartialSchemaRuleDefinition is a marker for requiring a partial OpenAPI schema on packages.
+	PackagePartialSchemaRuleDefinition = markers.Must(markers.MakeDefinition(partialSchemaMarkerName, markers.DescribesPackage, PartialSchemaRule{}))
+)
+
+// GVRRule is the output type of the GVR marker value
+type GVRRule struct {
+	// Capability is the name of the Capability the query belongs to.
+	Capability string
+	// Namespace of the Capability.
+	Namespace string `marker:",optional"`
+	// ServiceAccountName is the name of the service account used to evaluate the queries of the Capability.
+	ServiceAccountName string `marker:",optional"`
+	// Query is the name of the query. It defaults to the lower cased name of the type or package carrying the marker.
+	Query string `marker:",optional"`
+	// Name of the GVR query. It defaults to a name derived from the resource, group and versions.
+	Name string `marker:",optional"`
+	// Group is the API group to check for.
+	Group string `marker:",optional"`
+	// Versions are the versions to check for in the API group.
+	Versions []string `marker:",optional"`
+	// Resource is the API resource to check for.
+	Resource string `marker:",optional"`
+}
+
+// ObjectRule is the output type of the object marker value
+type ObjectRule struct {
+	// Capability is the name of the Capability the query belongs to.
+	Capability string
+	// Namespace of the Capability.
+	Namespace string `marker:",optional"`
+	// ServiceAccountName is the name of the service account used to evaluate the queries of the Capability.
+	ServiceAccountName string `marker:",optional"`
+	// Query is the name of the query. It defaults to the lower cased name of the type or package carrying the marker.
+	Query string `marker:",optional"`
+	// Name of the object query. It defaults to a name derived from the kind, namespace and name of the object.
+	Name string `marker:",optional"`
+	// APIVersion of the object.
+	APIVersion string `marker:"apiVersion"`
+	// Kind of the object.
+	Kind string
+	// ObjectName is the name of the object.
+	ObjectName string
+	// ObjectNamespace is the namespace of the object.
+	ObjectNamespace string `marker:",optional"`
+	// WithAnnotations are the annotations the object must have.
+	WithAnnotations map[string]string `marker:",optional"`
+	// WithoutAnnotations are the annotations the object must not have.
+	WithoutAnnotations map[string]string `marker:",optional"`
+}
+
+// PartialSchemaRule is the output type of the partial schema marker value
+type PartialSchemaRule struct {
+	// Capability is the name of the Capability the query belongs to.
+	Capability string
+	// Namespace of the Capability.
+	Namespace string `marker:",optional"`
+	// ServiceAccountName is the name of the service account used to evaluate the queries of the Capability.
+	ServiceAccountName string `marker:",optional"`
+	// Query is the name of the query. It defaults to the lower cased name of the type or package carrying the marker.
+	Query string `marker:",optional"`
+	// Name of the partial schema query. It defaults to a name derived from a hash of the schema.
+	Name string `marker:",optional"`
+	// Schema is the partial OpenAPI schema to match.
+	Schema string
+}
+
+// capabilityHelp is the help of the fields common to all capability markers.
+var capabilityHelp = map[string]markers.DetailedHelp{
+	"Capability": {
+		Summary: "specifies name of the Capability the query belongs to.",
+		Details: "",
+	},
+	"Namespace": {
+		Summary: "specifies namespace of the Capability.",
+		Details: "",
+	},
+	"ServiceAccountName": {
+		Summary: "specifies the service account used to evaluate the queries of the Capability.",
+		Details: "",
+	},
+	"Query": {
+		Summary: "specifies name of the query.",
+		Details: "Defaults to the lower cased name of the type or package carrying the marker.",
+	},
+}
+
+func withCapabilityHelp(fields map[string]markers.DetailedHelp) map[string]markers.DetailedHelp {
+	for name, help := range capabilityHelp {
+		fields[name] = help
+	}
+	return fields
+}
+
+// RegisterMarkers registers all markers needed by this Generator
+func (Generator) RegisterMarkers(reg *markers.Registry) error {
+	gvrHelp := &markers.DefinitionHelp{
+		Category: "capability",
+		DetailedHelp: markers.DetailedHelp{
+			Summary: "requires an API group, versions or resource in the cluster",
+			Details: "",
+		},
+		FieldHelp: withCapabilityHelp(map[string]markers.DetailedHelp{
+			"Name":     {Summary: "specifies name of the GVR query.", Details: "Defaults to a name derived from the resource, group and versions."},
+			"Group":    {Summary: "specifies the API group to check for.", Details: ""},
+			"Versions": {Summary: "specifies the versions to check for in the API group.", Details: ""},
+			"Resource": {Summary: "specifies the API resource to check for.", Details: "Requires at least one version."},
+		}),
+	}
+	objectHelp := &markers.DefinitionHelp{
+		Category: "capability",
+		DetailedHelp: markers.DetailedHelp{
+			Summary: "requires an object in the cluster",
+			Details: "",
+		},
+		FieldHelp: withCapabilityHelp(map[string]markers.DetailedHelp{
+			"Name":               {Summary: "specifies name of the object query.", Details: "Defaults to a name derived from the kind, namespace and name of the object."},
+			"APIVersion":         {Summary: "specifies API version of the object.", Details: ""},
+			"Kind":               {Summary: "specifies kind of the object.", Details: ""},
+			"ObjectName":         {Summary: "specifies name of the object.", Details: ""},
+			"ObjectNamespace":    {Summary: "specifies namespace of the object.", Details: ""},
+			"WithAnnotations":    {Summary: "specifies annotations the object must have.", Details: ""},
+			"WithoutAnnotations": {Summary: "specifies annotations the object must not have.", Details: ""},
+		}),
+	}
+	partialSchemaHelp := &markers.DefinitionHelp{
+		Category: "capability",
+		DetailedHelp: markers.DetailedHelp{
+			Summary: "requires a partial OpenAPI schema in the cluster",
+			Details: "",
+		},
+		FieldHelp: withCapabilityHelp(map[string]markers.DetailedHelp{
+			"Name":   {Summary: "specifies name of the partial schema query.", Details: "Defaults to a name derived from a hash of the schema."},
+			"Schema": {Summary: "specifies the partial OpenAPI schema to match.", Details: ""},
+		}),
+	}
+
+	for _, def := range []struct {
+		definition *markers.Definition
+		help       *markers.DefinitionHelp
+	}{
+		{GVRRuleDefinition, gvrHelp},
+		{PackageGVRRuleDefinition, gvrHelp},
+		{ObjectRuleDefinition, objectHelp},
+		{PackageObjectRuleDefinition, objectHelp},
+		{PartialSchemaRuleDefinition, partialSchemaHelp},
+		{PackagePartialSchemaRuleDefinition, partialSchemaHelp},
+	} {
+		if err := reg.Register(def.definition); err != nil {
+			return err
+		}
+		reg.AddHelp(def.definition, def.help)
+	}
+	return nil
+}
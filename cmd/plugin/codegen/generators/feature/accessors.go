@@ -0,0 +1,248 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package feature
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"sigs.k8s.io/controller-tools/pkg/genall"
+	"sigs.k8s.io/controller-tools/pkg/loader"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// accessorsFileName is the name of the Go file the feature accessors of a package are written to.
+const accessorsFileName = "zz_generated.features.go"
+
+// stabilityConstants maps the known stability levels to the names of their constants in the core API package.
+var stabilityConstants = map[corev1alpha2.StabilityLevel]string{
+	corev1alpha2.WorkInProgress:   "WorkInProgress",
+	corev1alpha2.Experimental:     "Experimental",
+	corev1alpha2.TechnicalPreview: "TechnicalPreview",
+	corev1alpha2.Stable:           "Stable",
+	corev1alpha2.Deprecated:       "Deprecated",
+}
+
+var accessorsTemplate = template.Must(template.New("accessors").Parse(`{{ .Header }}
+// Code generated by tanzu codegen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	featureutil "github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/util"
+)
+
+// FeatureName is the name of a Feature declared in this package.
+type FeatureName string
+
+const (
+{{- range .Features }}
+	// {{ .Constant }} is the name of the {{ .Name }} Feature.
+	{{ .Constant }} FeatureName = {{ printf "%q" .Name }}
+{{- end }}
+)
+
+// DeclaredFeatures is the registry of the Features declared in this package.
+var DeclaredFeatures = map[FeatureName]corev1alpha2.FeatureSpec{
+{{- range .Features }}
+	{{ .Constant }}: {
+		Description: {{ printf "%q" .Description }},
+		Stability:   {{ .Stability }},
+	},
+{{- end }}
+}
+
+// IsActivated returns true only if the Feature is activated.
+func (f FeatureName) IsActivated(ctx context.Context, c client.Client) (bool, error) {
+	return featureutil.IsFeatureActivated(ctx, c, string(f))
+}
+{{ range .Features }}
+// {{ .Accessor }} returns true only if the {{ .Name }} Feature is activated.
+func {{ .Accessor }}(ctx context.Context, c client.Client) (bool, error) {
+	return {{ .Constant }}.IsActivated(ctx, c)
+}
+{{ end }}`))
+
+// accessorsData is the input of the accessors template for a package.
+type accessorsData struct {
+	Header   string
+	Package  string
+	Features []featureAccessor
+}
+
+// featureAccessor holds the identifiers generated for a Feature.
+type featureAccessor struct {
+	Name        string
+	Description string
+	// Stability is the Go expression of the stability level.
+	Stability string
+	Constant  string
+	Accessor  string
+}
+
+// generateAccessors writes a Go file with typed constants, accessors and a registry of the Features declared by
+// markers in every root package that declares any.
+func (g Generator) generateAccessors(ctx *genall.GenerationContext) error {
+	header := ""
+	if g.HeaderFile != "" {
+		headerBytes, err := ctx.ReadFile(g.HeaderFile)
+		if err != nil {
+			return fmt.Errorf("could not read header file: %w", err)
+		}
+		header = strings.ReplaceAll(string(headerBytes), " YEAR", " "+g.Year)
+	}
+
+	for _, root := range ctx.Roots {
+		rules, valid, err := packageFeatureRules(ctx.Collector, root)
+		if err != nil {
+			root.AddError(err)
+			continue
+		}
+		if !valid || len(rules) == 0 {
+			continue
+		}
+
+		src, err := generateAccessorsSource(header, root.Name, rules)
+		if err != nil {
+			root.AddError(err)
+			continue
+		}
+		if err := writeAccessors(ctx, root, src); err != nil {
+			root.AddError(err)
+		}
+	}
+	return nil
+}
+
+func writeAccessors(ctx *genall.GenerationContext, root *loader.Package, src []byte) error {
+	out, err := ctx.Open(root, accessorsFileName)
+	if err != nil {
+		return fmt.Errorf("could not open %s: %w", accessorsFileName, err)
+	}
+	defer out.Close()
+
+	if _, err := out.Write(src); err != nil {
+		return fmt.Errorf("could not write %s: %w", accessorsFileName, err)
+	}
+	return nil
+}
+
+// packageFeatureRules returns the feature markers of the types in the package, in order of declaration. Invalid markers
+// and Features declared more than once are reported as errors of the package, at the position of the type carrying the
+// marker, and valid is false when any is reported, so that no accessors are generated from them.
+func packageFeatureRules(col *markers.Collector, pkg *loader.Package) (rules []Rule, valid bool, err error) {
+	var nodes []loader.Node
+	valid = true
+	err = markers.EachType(col, pkg, func(info *markers.TypeInfo) {
+		for _, value := range getMarkerValues(markerName, info.Markers) {
+			rule, ok := value.(Rule)
+			if !ok {
+				continue
+			}
+			if err := validateRule(rule); err != nil {
+				pkg.AddError(loader.ErrFromNode(err, info.RawSpec))
+				valid = false
+				continue
+			}
+			rules = append(rules, rule)
+			nodes = append(nodes, info.RawSpec)
+		}
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	declared := map[string]int{}
+	for _, rule := range rules {
+		declared[rule.Name]++
+	}
+	for i, rule := range rules {
+		if declared[rule.Name] > 1 {
+			pkg.AddError(loader.ErrFromNode(fmt.Errorf("feature %q is declared %d times", rule.Name, declared[rule.Name]), nodes[i]))
+			valid = false
+		}
+	}
+	return rules, valid, nil
+}
+
+// generateAccessorsSource renders and formats the accessors file of a package. Features declared more than once, with
+// an unknown stability level, or whose names map to the same Go identifier are reported as an error.
+func generateAccessorsSource(header, pkgName string, rules []Rule) ([]byte, error) {
+	identifiers := map[string]string{}
+	var features []featureAccessor
+	for _, rule := range rules {
+		identifier := goIdentifier(rule.Name)
+		if name, found := identifiers[identifier]; found {
+			if name == rule.Name {
+				return nil, fmt.Errorf("feature %q is declared more than once", rule.Name)
+			}
+			return nil, fmt.Errorf("features %q and %q both map to the Go identifier %s", name, rule.Name, identifier)
+		}
+		identifiers[identifier] = rule.Name
+
+		stability, err := stabilityExpression(rule.Stability)
+		if err != nil {
+			return nil, fmt.Errorf("could not generate accessors of feature %q: %w", rule.Name, err)
+		}
+		features = append(features, featureAccessor{
+			Name:        rule.Name,
+			Description: rule.Description,
+			Stability:   stability,
+			Constant:    identifier + "Feature",
+			Accessor:    "Is" + identifier + "Activated",
+		})
+	}
+	sort.Slice(features, func(i, j int) bool {
+		return features[i].Name < features[j].Name
+	})
+
+	var buf bytes.Buffer
+	if err := accessorsTemplate.Execute(&buf, accessorsData{Header: header, Package: pkgName, Features: features}); err != nil {
+		return nil, fmt.Errorf("could not render feature accessors: %w", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("could not format feature accessors: %w", err)
+	}
+	return src, nil
+}
+
+// goIdentifier converts a Feature name such as "super-toaster.v2" to an exported Go identifier such as SuperToasterV2.
+func goIdentifier(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, word := range words {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	identifier := b.String()
+	if identifier == "" || !unicode.IsLetter([]rune(identifier)[0]) {
+		identifier = "Feature" + identifier
+	}
+	return identifier
+}
+
+// stabilityExpression returns the Go expression of a known stability level in the generated file.
+func stabilityExpression(stability corev1alpha2.StabilityLevel) (string, error) {
+	constant, found := stabilityConstants[stability]
+	if !found {
+		return "", fmt.Errorf("unknown stability level %q", stability)
+	}
+	return "corev1alpha2." + constant, nil
+}
@@ -0,0 +1,256 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package feature
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-tools/pkg/genall"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+const (
+	// CatalogMarkdown renders the feature catalog as Markdown.
+	CatalogMarkdown = "markdown"
+	// CatalogHTML renders the feature catalog as HTML.
+	CatalogHTML = "html"
+)
+
+// catalogFileNames are the names of the files the feature catalog is written to by the generator, by format.
+var catalogFileNames = map[string]string{
+	CatalogMarkdown: "features.md",
+	CatalogHTML:     "features.html",
+}
+
+// catalogSection holds the Features of a stability level with the support implications of its policy.
+type catalogSection struct {
+	Stability    corev1alpha2.StabilityLevel
+	Implications []string
+	Deprecated   bool
+	Features     []catalogEntry
+}
+
+// catalogEntry is a Feature as listed in the catalog.
+type catalogEntry struct {
+	Name         string
+	Description  string
+	Owner        string
+	DocsURL      string
+	Dependencies string
+}
+
+const markdownCatalogTemplate = `# Feature Catalog
+
+This catalog is generated from the Feature definitions. Do not edit it by hand.
+{{ range .Sections }}
+## {{ .Stability }}
+{{ range .Implications }}
+* {{ . }}
+{{- end }}
+{{ if .Deprecated }}
+Deprecated features are destined for removal. Deactivate them prior to upgrading to a release which has removed them.
+{{ end }}
+| Name | Description | Owner | Depends on |
+| ---- | ----------- | ----- | ---------- |
+{{- range .Features }}
+| {{ if .DocsURL }}[{{ cell .Name }}]({{ .DocsURL }}){{ else }}{{ cell .Name }}{{ end }} | {{ cell .Description }} | {{ cell .Owner }} | {{ cell .Dependencies }} |
+{{- end }}
+{{ end }}`
+
+const htmlCatalogTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Feature Catalog</title>
+</head>
+<body>
+<h1>Feature Catalog</h1>
+<p>This catalog is generated from the Feature definitions. Do not edit it by hand.</p>
+{{- range .Sections }}
+<h2>{{ .Stability }}</h2>
+<ul>
+{{- range .Implications }}
+<li>{{ . }}</li>
+{{- end }}
+</ul>
+{{- if .Deprecated }}
+<p>Deprecated features are destined for removal. Deactivate them prior to upgrading to a release which has removed them.</p>
+{{- end }}
+<table>
+<tr><th>Name</th><th>Description</th><th>Owner</th><th>Depends on</th></tr>
+{{- range .Features }}
+<tr><td>{{ if .DocsURL }}<a href="{{ .DocsURL }}">{{ .Name }}</a>{{ else }}{{ .Name }}{{ end }}</td><td>{{ .Description }}</td><td>{{ .Owner }}</td><td>{{ .Dependencies }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+</body>
+</html>
+`
+
+var (
+	markdownCatalog = texttemplate.Must(texttemplate.New("catalog").Funcs(texttemplate.FuncMap{
+		"cell": markdownCell,
+	}).Parse(markdownCatalogTemplate))
+
+	htmlCatalog = htmltemplate.Must(htmltemplate.New("catalog").Parse(htmlCatalogTemplate))
+)
+
+// RenderCatalog writes a catalog of the Features in the given format, grouped by stability level. Every group lists
+// the support implications of the policy of its stability level.
+func RenderCatalog(w io.Writer, features []corev1alpha2.Feature, format string) error {
+	data := struct{ Sections []catalogSection }{Sections: catalogSections(features)}
+	switch format {
+	case CatalogMarkdown:
+		return markdownCatalog.Execute(w, data)
+	case CatalogHTML:
+		return htmlCatalog.Execute(w, data)
+	default:
+		return fmt.Errorf("unknown catalog format %q, must be %q or %q", format, CatalogMarkdown, CatalogHTML)
+	}
+}
+
+// writeCatalog writes the catalog of the Features generated from markers.
+func writeCatalog(ctx *genall.GenerationContext, objs []interface{}, format string) error {
+	fileName, found := catalogFileNames[format]
+	if !found {
+		return fmt.Errorf("unknown catalog format %q, must be %q or %q", format, CatalogMarkdown, CatalogHTML)
+	}
+
+	features := make([]corev1alpha2.Feature, 0, len(objs))
+	for _, obj := range objs {
+		features = append(features, obj.(corev1alpha2.Feature))
+	}
+
+	out, err := ctx.Open(nil, fileName)
+	if err != nil {
+		return fmt.Errorf("could not open %s: %w", fileName, err)
+	}
+	defer out.Close()
+	return RenderCatalog(out, features, format)
+}
+
+// catalogSections groups the Features by stability level, in order of the stability levels, followed by unknown
+// stability levels in alphabetical order. Features are sorted by name within a group.
+func catalogSections(features []corev1alpha2.Feature) []catalogSection {
+	byStability := map[corev1alpha2.StabilityLevel][]catalogEntry{}
+	for i := range features {
+		feature := &features[i]
+		byStability[feature.Spec.Stability] = append(byStability[feature.Spec.Stability], catalogEntry{
+			Name:         feature.Name,
+			Description:  feature.Spec.Description,
+			Owner:        feature.Annotations[corev1alpha2.FeatureOwnerAnnotation],
+			DocsURL:      feature.Annotations[corev1alpha2.FeatureDocsURLAnnotation],
+			Dependencies: strings.ReplaceAll(feature.Annotations[corev1alpha2.FeatureDependenciesAnnotation], ",", ", "),
+		})
+	}
+
+	levels := append([]corev1alpha2.StabilityLevel{}, stabilityLevels...)
+	var unknown []corev1alpha2.StabilityLevel
+	for stability := range byStability {
+		if !isKnownStabilityLevel(stability) {
+			unknown = append(unknown, stability)
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i] < unknown[j] })
+	levels = append(levels, unknown...)
+
+	var sections []catalogSection
+	for _, stability := range levels {
+		entries := byStability[stability]
+		if len(entries) == 0 {
+			continue
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+		sections = append(sections, catalogSection{
+			Stability:    stability,
+			Implications: supportImplications(stability),
+			Deprecated:   stability == corev1alpha2.Deprecated,
+			Features:     entries,
+		})
+	}
+	return sections
+}
+
+// supportImplications describes the policy of a stability level.
+func supportImplications(stability corev1alpha2.StabilityLevel) []string {
+	policy, found := corev1alpha2.StabilityPolicies[stability]
+	if !found {
+		return []string{"Unknown stability level, no support policy applies."}
+	}
+
+	var implications []string
+	if policy.DefaultActivation {
+		implications = append(implications, "Activated by default.")
+	} else {
+		implications = append(implications, "Deactivated by default.")
+	}
+	if policy.Immutable {
+		implications = append(implications, "Cannot be deactivated.")
+	} else {
+		implications = append(implications, "Can be activated and deactivated.")
+	}
+	if policy.VoidsWarranty {
+		implications = append(implications, "Activating permanently voids all support guarantees of the environment.")
+	} else {
+		implications = append(implications, "Activating does not affect the support of the environment.")
+	}
+	if !policy.Discoverable {
+		implications = append(implications, "Not discoverable, hidden from feature listings.")
+	}
+	return implications
+}
+
+// markdownCell escapes text for use in a Markdown table cell.
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// LoadFeatures reads the Features from the YAML files in a directory, such as the Feature manifests written by the
+// feature generator. Files may hold several documents, and documents of other kinds are skipped.
+func LoadFeatures(dir string) ([]corev1alpha2.Feature, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read Features directory: %w", err)
+	}
+
+	var features []corev1alpha2.Feature
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", entry.Name(), err)
+		}
+
+		decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+		for {
+			var feature corev1alpha2.Feature
+			if err := decoder.Decode(&feature); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, fmt.Errorf("could not decode %s: %w", entry.Name(), err)
+			}
+			if feature.Kind != "Feature" || feature.GroupVersionKind().Group != corev1alpha2.GroupVersion.Group {
+				continue
+			}
+			features = append(features, feature)
+		}
+	}
+	return features, nil
+}
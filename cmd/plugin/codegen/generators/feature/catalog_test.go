@@ -0,0 +1,69 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package feature
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+var _ = Describe("Feature catalog", func() {
+	It("should load the Features of a directory of manifests", func() {
+		features, err := LoadFeatures("./fakeData")
+		Expect(err).NotTo(HaveOccurred())
+
+		var names []string
+		for i := range features {
+			names = append(names, features[i].Name)
+		}
+		Expect(names).To(ConsistOf("bar", "baz", "foo", "gadget-gears"))
+	})
+
+	It("should group Features by stability level with their support implications", func() {
+		features := []corev1alpha2.Feature{
+			{ObjectMeta: metav1.ObjectMeta{Name: "old-toaster"}, Spec: corev1alpha2.FeatureSpec{Description: "Toasts | slowly", Stability: corev1alpha2.Deprecated}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "toaster"}, Spec: corev1alpha2.FeatureSpec{Stability: corev1alpha2.Stable}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "periscope"}, Spec: corev1alpha2.FeatureSpec{Stability: corev1alpha2.WorkInProgress}},
+		}
+
+		var out bytes.Buffer
+		Expect(RenderCatalog(&out, features, CatalogMarkdown)).To(Succeed())
+		catalog := out.String()
+
+		By("ordering the groups by stability level")
+		wip := strings.Index(catalog, "## Work In Progress")
+		stable := strings.Index(catalog, "## Stable")
+		deprecated := strings.Index(catalog, "## Deprecated")
+		Expect(wip).To(BeNumerically(">", 0))
+		Expect(stable).To(BeNumerically(">", wip))
+		Expect(deprecated).To(BeNumerically(">", stable))
+
+		By("listing the support implications and deprecation status")
+		Expect(catalog[wip:stable]).To(ContainSubstring("Activating permanently voids all support guarantees"))
+		Expect(catalog[wip:stable]).To(ContainSubstring("Not discoverable"))
+		Expect(catalog[stable:deprecated]).To(ContainSubstring("Cannot be deactivated."))
+		Expect(catalog[deprecated:]).To(ContainSubstring("Deprecated features are destined for removal"))
+		Expect(catalog[deprecated:]).To(ContainSubstring(`| old-toaster | Toasts \| slowly |`))
+	})
+
+	It("should escape the HTML catalog", func() {
+		features := []corev1alpha2.Feature{
+			{ObjectMeta: metav1.ObjectMeta{Name: "toaster"}, Spec: corev1alpha2.FeatureSpec{Description: "<b>toasts</b>", Stability: corev1alpha2.Stable}},
+		}
+
+		var out bytes.Buffer
+		Expect(RenderCatalog(&out, features, CatalogHTML)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("&lt;b&gt;toasts&lt;/b&gt;"))
+	})
+
+	It("should reject an unknown format", func() {
+		Expect(RenderCatalog(&bytes.Buffer{}, nil, "pdf")).NotTo(Succeed())
+	})
+})
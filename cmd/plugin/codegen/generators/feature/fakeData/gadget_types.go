@@ -0,0 +1,20 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fakedata
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// GadgetSpec defines the desired state of Gadget
+type GadgetSpec struct {
+	Gears int `json:"gears,omitempty"`
+}
+
+//+tanzu:feature:name=gadget-gears,stability=Experimental,description="Gears for gadgets",owner=gadget-team,docsURL="https://example.com/docs/gadget-gears",dependencies={foo,widget-colors},labels={"category": "gadgets"},component=gadget-controller,category=mechanics
+
+// Gadget is the Schema for the gadgets API
+type Gadget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              GadgetSpec `json:"spec,omitempty"`
+}
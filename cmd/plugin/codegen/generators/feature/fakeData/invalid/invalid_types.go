@@ -0,0 +1,24 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package invalid
+
+//+tanzu:feature:name=sprocket,stability=Beta
+
+// Sprocket declares a feature with an unknown stability level
+type Sprocket struct{}
+
+//+tanzu:feature:name=cog,stability=Stable
+
+// Cog declares a feature
+type Cog struct{}
+
+//+tanzu:feature:name=cog,stability=Experimental
+
+// CogV2 declares the same feature as Cog
+type CogV2 struct{}
+
+//+tanzu:feature:name=gear,stability=Stable,category="Big Gears",labels={"core.tanzu.vmware.com/feature-component": "gearbox"},component=gearbox
+
+// Gear declares a feature with an invalid category and a well-known label set in labels
+type Gear struct{}
@@ -0,0 +1,36 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// +groupName=fake.tanzu.vmware.com
+package fakedata
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// WidgetSpec defines the desired state of Widget
+type WidgetSpec struct {
+	Size int `json:"size,omitempty"`
+	//+tanzu:feature:name=widget-colors
+	Color string       `json:"color,omitempty"`
+	Parts []WidgetPart `json:"parts,omitempty"`
+}
+
+// WidgetPart is a part of a Widget
+type WidgetPart struct {
+	Name string `json:"name"`
+	//+tanzu:feature:name=widget-finishes
+	Finish string `json:"finish,omitempty"`
+}
+
+// WidgetStatus defines the observed state of Widget
+type WidgetStatus struct {
+}
+
+//+tanzu:feature:name=widget-colors,stability=Technical Preview
+
+// Widget is the Schema for the widgets API
+type Widget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              WidgetSpec   `json:"spec,omitempty"`
+	Status            WidgetStatus `json:"status,omitempty"`
+}
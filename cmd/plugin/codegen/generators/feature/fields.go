@@ -0,0 +1,200 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package feature
+
+import (
+	"go/ast"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-tools/pkg/genall"
+	"sigs.k8s.io/controller-tools/pkg/loader"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/fieldgate"
+)
+
+const groupNameMarkerName = "groupName"
+
+var (
+	// FieldRuleDefinition is a marker for gating an API field behind a Feature.
+	FieldRuleDefinition = markers.Must(markers.MakeDefinition(markerName, markers.DescribesField, FieldRule{}))
+
+	// groupNameDefinition is the package marker holding the API group of the types in a package.
+	groupNameDefinition = markers.Must(markers.MakeDefinition(groupNameMarkerName, markers.DescribesPackage, ""))
+)
+
+// FieldRule is the output type of the field marker value
+type FieldRule struct {
+	// Name of the feature gating the field.
+	Name string
+}
+
+// gatedFieldsFileName returns the name of the file the gated fields manifest of a kind is written to.
+func gatedFieldsFileName(kind *fieldgate.GatedKind) string {
+	parts := []string{kind.Group, kind.Version, strings.ToLower(kind.Kind), "gatedfields"}
+	if kind.Group == "" {
+		parts = parts[1:]
+	}
+	return strings.Join(parts, "_") + ".yaml"
+}
+
+// generateGatedFields collects the fields gated by field markers for every kind in the roots. A field of a nested
+// type is reported with its path from the root of the kind.
+func generateGatedFields(ctx *genall.GenerationContext) []*fieldgate.GatedKind {
+	var kinds []*fieldgate.GatedKind
+	for _, root := range ctx.Roots {
+		typeInfos := map[string]*markers.TypeInfo{}
+		if err := markers.EachType(ctx.Collector, root, func(info *markers.TypeInfo) {
+			typeInfos[info.Name] = info
+		}); err != nil {
+			root.AddError(err)
+			continue
+		}
+
+		group, err := packageGroupName(ctx.Collector, root)
+		if err != nil {
+			root.AddError(err)
+			continue
+		}
+
+		typeNames := make([]string, 0, len(typeInfos))
+		for name := range typeInfos {
+			typeNames = append(typeNames, name)
+		}
+		sort.Strings(typeNames)
+
+		for _, name := range typeNames {
+			info := typeInfos[name]
+			if !isKind(info) {
+				continue
+			}
+			fields := gatedFieldsOfType(typeInfos, info, nil, map[string]bool{name: true})
+			if len(fields) == 0 {
+				continue
+			}
+			kinds = append(kinds, &fieldgate.GatedKind{
+				Group:   group,
+				Version: root.Name,
+				Kind:    name,
+				Fields:  fields,
+			})
+		}
+	}
+	return kinds
+}
+
+// packageGroupName returns the API group declared by the +groupName marker of the package, if any.
+func packageGroupName(col *markers.Collector, pkg *loader.Package) (string, error) {
+	pkgMarkers, err := markers.PackageMarkers(col, pkg)
+	if err != nil {
+		return "", err
+	}
+	if group, ok := pkgMarkers.Get(groupNameMarkerName).(string); ok {
+		return group, nil
+	}
+	return "", nil
+}
+
+// isKind checks if the type is the root type of a kind, that is, it embeds both metav1.TypeMeta and metav1.ObjectMeta.
+func isKind(info *markers.TypeInfo) bool {
+	var typeMeta, objectMeta bool
+	for _, field := range info.Fields {
+		if field.Name != "" {
+			continue
+		}
+		selector, ok := field.RawField.Type.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		switch selector.Sel.Name {
+		case "TypeMeta":
+			typeMeta = true
+		case "ObjectMeta":
+			objectMeta = true
+		}
+	}
+	return typeMeta && objectMeta
+}
+
+// gatedFieldsOfType returns the gated fields of the type and, recursively, of the types of its fields declared in
+// the same package. Types already being visited are skipped to stop at recursive types.
+func gatedFieldsOfType(typeInfos map[string]*markers.TypeInfo, info *markers.TypeInfo, prefix []string, visiting map[string]bool) []fieldgate.GatedField {
+	var fields []fieldgate.GatedField
+	for i := range info.Fields {
+		field := &info.Fields[i]
+		name, inline, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+
+		path := append([]string{}, prefix...)
+		if !inline {
+			path = append(path, name)
+		}
+
+		for _, value := range getMarkerValues(markerName, field.Markers) {
+			rule, ok := value.(FieldRule)
+			if !ok {
+				continue
+			}
+			fields = append(fields, fieldgate.GatedField{Path: strings.Join(path, "."), Feature: rule.Name})
+		}
+
+		typeName, elements := localTypeName(field.RawField.Type)
+		nested, found := typeInfos[typeName]
+		if !found || visiting[typeName] {
+			continue
+		}
+		visiting[typeName] = true
+		fields = append(fields, gatedFieldsOfType(typeInfos, nested, append(path, elements...), visiting)...)
+		delete(visiting, typeName)
+	}
+	return fields
+}
+
+// jsonFieldName returns the JSON name of the field and whether the field is inlined into its parent. ok is false for
+// fields that are not serialized.
+func jsonFieldName(field *markers.FieldInfo) (name string, inline, ok bool) {
+	tag, hasTag := field.Tag.Lookup("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	for _, opt := range parts[1:] {
+		if opt == "inline" {
+			inline = true
+		}
+	}
+	if field.Name == "" && (!hasTag || name == "") {
+		// Embedded fields without a JSON name are inlined.
+		inline = true
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, inline, true
+}
+
+// localTypeName unwraps pointers, slices, arrays and maps to find the name of a type declared in the same package.
+// elements holds a fieldgate.AnyElement segment for every slice, array or map unwrapped on the way.
+func localTypeName(expr ast.Expr) (name string, elements []string) {
+	for {
+		switch t := expr.(type) {
+		case *ast.Ident:
+			return t.Name, elements
+		case *ast.StarExpr:
+			expr = t.X
+		case *ast.ArrayType:
+			elements = append(elements, fieldgate.AnyElement)
+			expr = t.Elt
+		case *ast.MapType:
+			elements = append(elements, fieldgate.AnyElement)
+			expr = t.Value
+		default:
+			return "", nil
+		}
+	}
+}
@@ -4,8 +4,14 @@
 package feature
 
 import (
+	"fmt"
+	"path"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-tools/pkg/genall"
+	"sigs.k8s.io/controller-tools/pkg/loader"
 	"sigs.k8s.io/controller-tools/pkg/markers"
 
 	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
@@ -13,13 +19,37 @@ import (
 
 const markerName = "tanzu:feature"
 
+const (
+	// LayoutCombined writes all Features to a single multi-document manifest.
+	LayoutCombined = "combined"
+	// LayoutCarvel writes every Feature to its own file in the config/upstream directory of a Carvel package bundle.
+	LayoutCarvel = "carvel"
+
+	combinedManifestFileName = "features.yaml"
+	carvelFeaturesDir        = "config/upstream/features"
+)
+
 var (
 	// RuleDefinition is a marker for defining Feature rules.
 	RuleDefinition = markers.Must(markers.MakeDefinition(markerName, markers.DescribesType, Rule{}))
 )
 
 // Generator is feature generator that registers feature markers and produces output artifacts
-type Generator struct{}
+type Generator struct {
+	// Accessors generates a zz_generated.features.go file in every package declaring features, with typed constants,
+	// activation accessors and a registry of the declared features.
+	Accessors bool `marker:",optional"`
+	// HeaderFile specifies the header text (e.g. license) to prepend to generated Go files.
+	HeaderFile string `marker:",optional"`
+	// Year specifies the year to substitute for " YEAR" in the header file.
+	Year string `marker:",optional"`
+	// Layout specifies how Features are written: one file per Feature by default, "combined" for a single
+	// multi-document manifest or "carvel" for the config/upstream directory of a Carvel package bundle.
+	Layout string `marker:",optional"`
+	// Catalog writes a catalog of the features grouped by stability level to features.md with "markdown", or to
+	// features.html with "html".
+	Catalog string `marker:",optional"`
+}
 
 // Rule is the output type of the marker value
 type Rule struct {
@@ -29,20 +59,67 @@ type Rule struct {
 	Description string `marker:",optional"`
 	// Stability indicates stability level of this feature.
 	Stability corev1alpha2.StabilityLevel
+	// Owner is the team or person owning the feature.
+	Owner string `marker:",optional"`
+	// DocsURL is the URL of the documentation of the feature.
+	DocsURL string `marker:",optional"`
+	// Dependencies are the names of the features this feature depends on.
+	Dependencies []string `marker:",optional"`
+	// Labels are set on the Feature.
+	Labels map[string]string `marker:",optional"`
+	// Component is the component the feature belongs to.
+	Component string `marker:",optional"`
+	// Category is the category the feature is grouped in.
+	Category string `marker:",optional"`
 }
 
 // Generate generates artifacts produced by feature marker.
 func (g Generator) Generate(ctx *genall.GenerationContext) error {
 	objs := generateFeatures(ctx)
 
-	if len(objs) == 0 {
-		return nil
+	switch g.Layout {
+	case "":
+		for _, obj := range objs {
+			if err := ctx.WriteYAML(obj.(corev1alpha2.Feature).Name+".yaml", obj); err != nil {
+				return err
+			}
+		}
+	case LayoutCombined:
+		if len(objs) > 0 {
+			if err := ctx.WriteYAML(combinedManifestFileName, objs...); err != nil {
+				return err
+			}
+		}
+	case LayoutCarvel:
+		if len(objs) > 0 {
+			if err := makeConfigDir(ctx.OutputRule, carvelFeaturesDir); err != nil {
+				return err
+			}
+		}
+		for _, obj := range objs {
+			if err := ctx.WriteYAML(path.Join(carvelFeaturesDir, obj.(corev1alpha2.Feature).Name+".yaml"), obj); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown layout %q, must be one of %q or %q", g.Layout, LayoutCombined, LayoutCarvel)
 	}
-	for _, obj := range objs {
-		if err := ctx.WriteYAML(obj.(corev1alpha2.Feature).Name+".yaml", obj); err != nil {
+
+	if g.Catalog != "" {
+		if err := writeCatalog(ctx, objs, g.Catalog); err != nil {
 			return err
 		}
 	}
+
+	for _, kind := range generateGatedFields(ctx) {
+		if err := ctx.WriteYAML(gatedFieldsFileName(kind), kind); err != nil {
+			return err
+		}
+	}
+
+	if g.Accessors {
+		return g.generateAccessors(ctx)
+	}
 	return nil
 }
 
@@ -68,42 +145,159 @@ func (Generator) RegisterMarkers(reg *markers.Registry) error {
 			},
 			"Stability": {
 				Summary: "indicates stability level of this feature.",
+				Details: "One of Work In Progress, Experimental, Technical Preview, Stable or Deprecated.",
+			},
+			"Owner": {
+				Summary: "specifies the team or person owning the feature.",
+				Details: "Also set as the core.tanzu.vmware.com/feature-owner-team label when it is a valid label value, so that features can be selected by team.",
+			},
+			"DocsURL": {
+				Summary: "specifies the URL of the documentation of the feature.",
+				Details: "",
+			},
+			"Dependencies": {
+				Summary: "specifies the names of the features this feature depends on.",
+				Details: "",
+			},
+			"Labels": {
+				Summary: "specifies the labels set on the Feature.",
 				Details: "",
 			},
+			"Component": {
+				Summary: "specifies the component the feature belongs to.",
+				Details: "Set as the core.tanzu.vmware.com/feature-component label, so that features can be selected by component.",
+			},
+			"Category": {
+				Summary: "specifies the category the feature is grouped in.",
+				Details: "Set as the core.tanzu.vmware.com/feature-category label, so that features can be selected by category.",
+			},
 		},
 	})
-	return nil
+
+	if err := reg.Register(FieldRuleDefinition); err != nil {
+		return err
+	}
+	reg.AddHelp(FieldRuleDefinition, &markers.DefinitionHelp{
+		Category: "feature",
+		DetailedHelp: markers.DetailedHelp{
+			Summary: "gates the field behind a feature",
+			Details: "The field may only be set while the feature is activated. Gated fields are listed per kind in a gated fields manifest.",
+		},
+		FieldHelp: map[string]markers.DetailedHelp{
+			"Name": {
+				Summary: "specifies name of the feature gating the field.",
+				Details: "",
+			},
+		},
+	})
+
+	return reg.Register(groupNameDefinition)
 }
 
+// generateFeatures returns the Features declared by markers in the roots. Invalid markers and Features declared more
+// than once are reported as errors of the package declaring them, at the position of the type carrying the marker.
 func generateFeatures(ctx *genall.GenerationContext) []interface{} {
-	var objs []interface{}
+	type declaration struct {
+		root *loader.Package
+		node loader.Node
+		rule Rule
+	}
+	var names []string
+	declarations := map[string][]declaration{}
 	for _, root := range ctx.Roots {
+		root := root
 		if err := markers.EachType(ctx.Collector, root, func(info *markers.TypeInfo) {
 			markerValues := getMarkerValues(markerName, info.Markers)
 			for _, markerValue := range markerValues {
 				val := markerValue.(Rule)
-				objs = append(objs, corev1alpha2.Feature{
-					TypeMeta: metav1.TypeMeta{
-						Kind:       "Feature",
-						APIVersion: corev1alpha2.GroupVersion.String(),
-					},
-					ObjectMeta: metav1.ObjectMeta{
-						Name: val.Name,
-					},
-					Spec: corev1alpha2.FeatureSpec{
-						Description: val.Description,
-						Stability:   val.Stability,
-					},
-					Status: corev1alpha2.FeatureStatus{},
-				})
+				if err := validateRule(val); err != nil {
+					root.AddError(loader.ErrFromNode(err, info.RawSpec))
+					continue
+				}
+				if _, found := declarations[val.Name]; !found {
+					names = append(names, val.Name)
+				}
+				declarations[val.Name] = append(declarations[val.Name], declaration{root: root, node: info.RawSpec, rule: val})
 			}
 		}); err != nil {
 			root.AddError(err)
 		}
 	}
+
+	var objs []interface{}
+	for _, name := range names {
+		if len(declarations[name]) > 1 {
+			for _, d := range declarations[name] {
+				d.root.AddError(loader.ErrFromNode(fmt.Errorf("feature %q is declared %d times", name, len(declarations[name])), d.node))
+			}
+			continue
+		}
+		objs = append(objs, featureFromRule(declarations[name][0].rule))
+	}
 	return objs
 }
 
+// featureFromRule returns the Feature declared by a marker. The owner, docs URL and dependencies of the feature are
+// set as annotations, and the component, owner team and category as well-known labels.
+func featureFromRule(val Rule) corev1alpha2.Feature {
+	labels := map[string]string{}
+	for key, value := range val.Labels {
+		labels[key] = value
+	}
+	for key, value := range wellKnownLabels(val) {
+		labels[key] = value
+	}
+	if len(labels) == 0 {
+		labels = nil
+	}
+
+	annotations := map[string]string{}
+	if val.Owner != "" {
+		annotations[corev1alpha2.FeatureOwnerAnnotation] = val.Owner
+	}
+	if val.DocsURL != "" {
+		annotations[corev1alpha2.FeatureDocsURLAnnotation] = val.DocsURL
+	}
+	if len(val.Dependencies) > 0 {
+		annotations[corev1alpha2.FeatureDependenciesAnnotation] = strings.Join(val.Dependencies, ",")
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+
+	return corev1alpha2.Feature{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Feature",
+			APIVersion: corev1alpha2.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        val.Name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1alpha2.FeatureSpec{
+			Description: val.Description,
+			Stability:   val.Stability,
+		},
+		Status: corev1alpha2.FeatureStatus{},
+	}
+}
+
+// wellKnownLabels returns the well-known labels the marker sets on the Feature.
+func wellKnownLabels(val Rule) map[string]string {
+	labels := map[string]string{}
+	if val.Component != "" {
+		labels[corev1alpha2.FeatureComponentLabel] = val.Component
+	}
+	if val.Owner != "" && len(validation.IsValidLabelValue(val.Owner)) == 0 {
+		labels[corev1alpha2.FeatureOwnerTeamLabel] = val.Owner
+	}
+	if val.Category != "" {
+		labels[corev1alpha2.FeatureCategoryLabel] = val.Category
+	}
+	return labels
+}
+
 func getMarkerValues(name string, markerValues map[string][]interface{}) []interface{} {
 	values := markerValues[name]
 	if len(values) == 0 {
@@ -5,6 +5,8 @@ package feature
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -14,6 +16,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/fieldgate"
 )
 
 var _ = Describe("Feature CR generated by the Feature Generator", func() {
@@ -121,4 +124,243 @@ var _ = Describe("Feature CR generated by the Feature Generator", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(len(features)).To(BeZero())
 	})
+
+	It("should generate a Feature CR with optional metadata", func() {
+		cwd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir("./fakeData")).To(Succeed())
+		defer func() { Expect(os.Chdir(cwd)).To(Succeed()) }()
+
+		By("loading the roots")
+		pkgs, err := loader.LoadRoots("./gadget_types.go")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("registering Feature rule marker")
+		reg := &markers.Registry{}
+		Expect(reg.Register(RuleDefinition)).To(Succeed())
+
+		By("creating GenerationContext")
+		ctx := &genall.GenerationContext{
+			Collector: &markers.Collector{Registry: reg},
+			Roots:     pkgs,
+		}
+
+		By("generating a Feature")
+		features := generateFeatures(ctx)
+		Expect(pkgs[0].Errors).To(BeEmpty())
+		Expect(len(features)).To(Equal(1))
+
+		By("loading the desired YAML")
+		featureCRBytes, err := os.ReadFile("./gadget-gears.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("comparing the generated Feature and expected Feature")
+		var expectedFeature corev1alpha2.Feature
+		Expect(yaml.Unmarshal(featureCRBytes, &expectedFeature)).To(Succeed())
+		Expect(features[0]).To(Equal(expectedFeature))
+	})
+
+	It("should set the owner team label only for owners that are valid label values", func() {
+		team := featureFromRule(Rule{Name: "gadget-gears", Stability: corev1alpha2.Experimental, Owner: "gadget-team"})
+		Expect(team.Labels).To(HaveKeyWithValue(corev1alpha2.FeatureOwnerTeamLabel, "gadget-team"))
+		Expect(team.Annotations).To(HaveKeyWithValue(corev1alpha2.FeatureOwnerAnnotation, "gadget-team"))
+
+		person := featureFromRule(Rule{Name: "gadget-gears", Stability: corev1alpha2.Experimental, Owner: "Jane Doe"})
+		Expect(person.Labels).NotTo(HaveKey(corev1alpha2.FeatureOwnerTeamLabel))
+		Expect(person.Annotations).To(HaveKeyWithValue(corev1alpha2.FeatureOwnerAnnotation, "Jane Doe"))
+	})
+
+	It("should reject invalid and duplicate Feature markers", func() {
+		cwd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir("./fakeData")).To(Succeed())
+		defer func() { Expect(os.Chdir(cwd)).To(Succeed()) }()
+
+		By("loading the roots")
+		pkgs, err := loader.LoadRoots("./invalid/...")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(pkgs)).To(Equal(1))
+
+		By("registering Feature rule marker")
+		reg := &markers.Registry{}
+		Expect(reg.Register(RuleDefinition)).To(Succeed())
+
+		By("creating GenerationContext")
+		ctx := &genall.GenerationContext{
+			Collector: &markers.Collector{Registry: reg},
+			Roots:     pkgs,
+		}
+
+		By("generating the Features")
+		features := generateFeatures(ctx)
+		Expect(features).To(BeEmpty())
+
+		By("checking the errors and their positions")
+		var messages []string
+		for _, err := range pkgs[0].Errors {
+			messages = append(messages, err.Error())
+		}
+		Expect(messages).To(ConsistOf(
+			MatchRegexp(`invalid_types.go:9:6: unknown stability level "Beta"`),
+			MatchRegexp(`invalid_types.go:14:6: feature "cog" is declared 2 times`),
+			MatchRegexp(`invalid_types.go:19:6: feature "cog" is declared 2 times`),
+			MatchRegexp(`invalid_types.go:24:6: .*invalid value "Big Gears" of label "core.tanzu.vmware.com/feature-category".*label "core.tanzu.vmware.com/feature-component" is set by the marker`),
+		))
+	})
+
+	It("should write Features in the requested layout", func() {
+		cwd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir("./fakeData")).To(Succeed())
+		defer func() { Expect(os.Chdir(cwd)).To(Succeed()) }()
+
+		By("loading the roots")
+		pkgs, err := loader.LoadRoots("./cronjob_types.go")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("registering Feature markers")
+		reg := &markers.Registry{}
+		Expect(Generator{}.RegisterMarkers(reg)).To(Succeed())
+
+		By("generating a combined manifest")
+		combinedDir, err := os.MkdirTemp("", "features")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(combinedDir)
+		ctx := &genall.GenerationContext{
+			Collector:  &markers.Collector{Registry: reg},
+			Roots:      pkgs,
+			OutputRule: genall.OutputToDirectory(combinedDir),
+		}
+		Expect(Generator{Layout: LayoutCombined}.Generate(ctx)).To(Succeed())
+		manifest, err := os.ReadFile(filepath.Join(combinedDir, "features.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Count(string(manifest), "kind: Feature")).To(Equal(2))
+
+		By("generating a Carvel package layout")
+		carvelDir, err := os.MkdirTemp("", "features")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(carvelDir)
+		ctx.OutputRule = genall.OutputArtifacts{Config: genall.OutputToDirectory(carvelDir)}
+		Expect(Generator{Layout: LayoutCarvel}.Generate(ctx)).To(Succeed())
+		Expect(filepath.Join(carvelDir, "config", "upstream", "features", "bar.yaml")).To(BeAnExistingFile())
+		Expect(filepath.Join(carvelDir, "config", "upstream", "features", "baz.yaml")).To(BeAnExistingFile())
+
+		By("rejecting an unknown layout")
+		Expect(Generator{Layout: "flat"}.Generate(ctx)).NotTo(Succeed())
+	})
+
+	It("should generate the gated fields of a kind", func() {
+		cwd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir("./fakeData")).To(Succeed())
+		defer func() { Expect(os.Chdir(cwd)).To(Succeed()) }()
+
+		By("loading the roots")
+		pkgs, err := loader.LoadRoots("./widget_types.go")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("registering Feature markers")
+		reg := &markers.Registry{}
+		Expect(Generator{}.RegisterMarkers(reg)).To(Succeed())
+
+		By("creating GenerationContext")
+		ctx := &genall.GenerationContext{
+			Collector: &markers.Collector{Registry: reg},
+			Roots:     pkgs,
+		}
+
+		By("generating the gated fields")
+		kinds := generateGatedFields(ctx)
+		Expect(len(kinds)).To(Equal(1))
+		Expect(gatedFieldsFileName(kinds[0])).To(Equal("fake.tanzu.vmware.com_fakedata_widget_gatedfields.yaml"))
+
+		By("loading the desired YAML")
+		gatedFieldsBytes, err := os.ReadFile("./widget_gatedfields.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("comparing the generated and expected gated fields")
+		expectedKind, err := fieldgate.Parse(gatedFieldsBytes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kinds[0]).To(Equal(expectedKind))
+	})
+	It("should generate typed Feature constants and accessors", func() {
+		cwd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir("./fakeData")).To(Succeed())
+		defer func() { Expect(os.Chdir(cwd)).To(Succeed()) }()
+
+		By("loading the roots")
+		pkgs, err := loader.LoadRoots("./cronjob_types.go")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(pkgs)).To(Equal(1))
+
+		By("registering Feature rule marker")
+		reg := &markers.Registry{}
+		Expect(reg.Register(RuleDefinition)).To(Succeed())
+
+		By("collecting the Feature markers")
+		rules, valid, err := packageFeatureRules(&markers.Collector{Registry: reg}, pkgs[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(valid).To(BeTrue())
+
+		By("generating the accessors")
+		src, err := generateAccessorsSource("", pkgs[0].Name, rules)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("comparing the generated and expected accessors")
+		expected, err := os.ReadFile("./cronjob_features.go.golden")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(src)).To(Equal(string(expected)))
+	})
+
+	It("should fail to generate accessors for Features mapping to the same identifier", func() {
+		_, err := generateAccessorsSource("", "fakedata", []Rule{
+			{Name: "super-toaster", Stability: corev1alpha2.Stable},
+			{Name: "super.toaster", Stability: corev1alpha2.Stable},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject invalid and duplicate Feature markers instead of generating accessors", func() {
+		cwd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir("./fakeData")).To(Succeed())
+		defer func() { Expect(os.Chdir(cwd)).To(Succeed()) }()
+
+		By("loading the roots")
+		pkgs, err := loader.LoadRoots("./invalid/...")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(pkgs)).To(Equal(1))
+
+		By("registering Feature rule marker")
+		reg := &markers.Registry{}
+		Expect(reg.Register(RuleDefinition)).To(Succeed())
+
+		By("collecting the Feature markers")
+		_, valid, err := packageFeatureRules(&markers.Collector{Registry: reg}, pkgs[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(valid).To(BeFalse())
+
+		By("checking the errors and their positions")
+		var messages []string
+		for _, err := range pkgs[0].Errors {
+			messages = append(messages, err.Error())
+		}
+		Expect(messages).To(ContainElements(
+			MatchRegexp(`invalid_types.go:9:6: unknown stability level "Beta"`),
+			MatchRegexp(`invalid_types.go:14:6: feature "cog" is declared 2 times`),
+			MatchRegexp(`invalid_types.go:19:6: feature "cog" is declared 2 times`),
+		))
+	})
+
+	It("should fail to generate accessors for duplicate Features and unknown stability levels", func() {
+		_, err := generateAccessorsSource("", "fakedata", []Rule{
+			{Name: "super-toaster", Stability: corev1alpha2.Stable},
+			{Name: "super-toaster", Stability: corev1alpha2.Stable},
+		})
+		Expect(err).To(MatchError(ContainSubstring("declared more than once")))
+
+		_, err = generateAccessorsSource("", "fakedata", []Rule{{Name: "super-toaster", Stability: "Beta"}})
+		Expect(err).To(MatchError(ContainSubstring(`unknown stability level "Beta"`)))
+	})
 })
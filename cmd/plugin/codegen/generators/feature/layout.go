@@ -0,0 +1,34 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package feature
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/controller-tools/pkg/genall"
+)
+
+// makeConfigDir creates the directory dir below the config directory of the output rule, since the output rules of
+// controller-tools only create the config directory itself. Output rules that do not write to a directory are ignored.
+func makeConfigDir(rule genall.OutputRule, dir string) error {
+	var base string
+	switch r := rule.(type) {
+	case genall.OutputArtifacts:
+		base = string(r.Config)
+	case *genall.OutputArtifacts:
+		base = string(r.Config)
+	case genall.OutputToDirectory:
+		base = string(r)
+	case *genall.OutputToDirectory:
+		base = string(*r)
+	default:
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Join(base, dir), os.ModePerm); err != nil {
+		return fmt.Errorf("could not create directory %s: %w", dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// stabilityLevels are the stability levels a feature marker may specify.
+var stabilityLevels = []corev1alpha2.StabilityLevel{
+	corev1alpha2.WorkInProgress,
+	corev1alpha2.Experimental,
+	corev1alpha2.TechnicalPreview,
+	corev1alpha2.Stable,
+	corev1alpha2.Deprecated,
+}
+
+// validateRule checks that a feature marker declares a valid Feature.
+func validateRule(rule Rule) error {
+	var errs []string
+	if msgs := validation.IsDNS1123Subdomain(rule.Name); len(msgs) > 0 {
+		errs = append(errs, fmt.Sprintf("invalid feature name %q: %s", rule.Name, strings.Join(msgs, ", ")))
+	}
+
+	if !isKnownStabilityLevel(rule.Stability) {
+		levels := make([]string, 0, len(stabilityLevels))
+		for _, level := range stabilityLevels {
+			levels = append(levels, string(level))
+		}
+		errs = append(errs, fmt.Sprintf("unknown stability level %q, must be one of: %s", rule.Stability, strings.Join(levels, ", ")))
+	}
+
+	if rule.DocsURL != "" {
+		if u, err := url.ParseRequestURI(rule.DocsURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			errs = append(errs, fmt.Sprintf("invalid docs URL %q: must be an absolute http or https URL", rule.DocsURL))
+		}
+	}
+
+	for _, dependency := range rule.Dependencies {
+		if dependency == rule.Name {
+			errs = append(errs, fmt.Sprintf("feature %q cannot depend on itself", rule.Name))
+			continue
+		}
+		if msgs := validation.IsDNS1123Subdomain(dependency); len(msgs) > 0 {
+			errs = append(errs, fmt.Sprintf("invalid dependency %q: %s", dependency, strings.Join(msgs, ", ")))
+		}
+	}
+
+	for key, value := range rule.Labels {
+		if msgs := validation.IsQualifiedName(key); len(msgs) > 0 {
+			errs = append(errs, fmt.Sprintf("invalid label key %q: %s", key, strings.Join(msgs, ", ")))
+		}
+		if msgs := validation.IsValidLabelValue(value); len(msgs) > 0 {
+			errs = append(errs, fmt.Sprintf("invalid value %q of label %q: %s", value, key, strings.Join(msgs, ", ")))
+		}
+	}
+
+	labels := wellKnownLabels(rule)
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := labels[key]
+		if msgs := validation.IsValidLabelValue(value); len(msgs) > 0 {
+			errs = append(errs, fmt.Sprintf("invalid value %q of label %q: %s", value, key, strings.Join(msgs, ", ")))
+		}
+		if _, ok := rule.Labels[key]; ok {
+			errs = append(errs, fmt.Sprintf("label %q is set by the marker and cannot be set in labels", key))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func isKnownStabilityLevel(stability corev1alpha2.StabilityLevel) bool {
+	for _, level := range stabilityLevels {
+		if stability == level {
+			return true
+		}
+	}
+	return false
+}
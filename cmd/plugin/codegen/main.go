@@ -28,6 +28,8 @@ func main() {
 
 	p.AddCommands(
 		GenerateCmd,
+		CatalogCmd,
+		CheckCmd,
 	)
 
 	if err := p.Execute(); err != nil {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -17,26 +18,44 @@ import (
 
 var (
 	userAllowsVoidingWarranty bool
+	activateDryRun            bool
+	activateWait              bool
+	activateWaitTimeout       time.Duration
+	activateBypassApproval    bool
 )
 
 // FeatureActivateCmd is for activating Features
 var FeatureActivateCmd = &cobra.Command{
-	Use:   "activate <feature>",
+	Use:   "activate <feature>...",
 	Short: "Activate features",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.MinimumNArgs(1),
 	Example: `
 	# Activate a cluster Feature
-	tanzu feature activate myfeature`,
+	tanzu feature activate myfeature
+
+	# Activate several cluster Features at once. Either all of them are activated or none is.
+	tanzu feature activate myfeature myotherfeature
+
+	# Report the FeatureGate changes activating a Feature requires without making them
+	tanzu feature activate myfeature --dry-run
+
+	# Activate a cluster Feature and wait until it is activated in the cluster
+	tanzu feature activate myfeature --wait --timeout 2m
+
+	# Activate a cluster Feature that is not Stable without requesting approval
+	tanzu feature activate myfeature --bypass-approval`,
 	RunE: featureActivate,
 }
 
 func init() {
 	FeatureActivateCmd.Flags().BoolVar(&userAllowsVoidingWarranty, "permanentlyVoidAllSupportGuarantees", false, "Allow for the permanent voiding of all support guarantees for this environment. For some features, e.g. experimental features, if a user sets the activation status to one that does not match the default activation, all support guarantees for this environment will be permanently voided.")
+	FeatureActivateCmd.Flags().BoolVar(&activateDryRun, "dry-run", false, "Report the FeatureGate changes, whether they void support guarantees and whether they would be accepted, without making them")
+	FeatureActivateCmd.Flags().BoolVar(&activateWait, "wait", false, "Wait until the Features are activated in the cluster, and fail if their FeatureGate reports the activation as invalid")
+	FeatureActivateCmd.Flags().DurationVar(&activateWaitTimeout, "timeout", defaultWaitTimeout, "How long to wait for the Features with --wait")
+	FeatureActivateCmd.Flags().BoolVar(&activateBypassApproval, "bypass-approval", false, "Change the FeatureGate directly for Features that are not Stable, rather than requesting approval with \"tanzu feature request\". Clusters enforcing approval reject the change")
 }
 
 func featureActivate(cmd *cobra.Command, args []string) error {
-	featureName := args[0]
-
 	fgClient, err := featuregateclient.NewFeatureGateClient()
 	if err != nil {
 		return fmt.Errorf("could not get FeatureGateClient: %w", err)
@@ -50,24 +69,69 @@ func featureActivate(cmd *cobra.Command, args []string) error {
 		userAllows = &userAllowsVoidingWarranty
 	}
 
-	gateName, err := activateFeature(ctx, fgClient, featureName, userAllows)
-	if err != nil {
-		return fmt.Errorf("could not activate Feature %s: %w", featureName, err)
+	if activateDryRun {
+		// Voiding support guarantees is reported rather than asked for, unless the user disallows it by flag.
+		changes := make([]featuregateclient.FeatureChange, 0, len(args))
+		for _, featureName := range args {
+			changes = append(changes, featuregateclient.FeatureChange{Name: featureName, Activate: true, WarrantyVoidAllowed: userAllows == nil || *userAllows})
+		}
+		return dryRunFeatureChanges(ctx, cmd, fgClient, changes)
+	}
+
+	if err := requireApprovalBypass(ctx, fgClient, args, true, activateBypassApproval); err != nil {
+		return err
+	}
+
+	if len(args) > 1 {
+		gateNames, err := activateFeatures(ctx, fgClient, args, userAllows)
+		if err != nil {
+			return fmt.Errorf("could not activate Features %s: %w", strings.Join(args, ", "), err)
+		}
+		if len(gateNames) == 0 {
+			cmd.Printf("Features %s are already activated.\n", strings.Join(args, ", "))
+		} else {
+			cmd.Printf("Features %s are activated in FeatureGates %s.\n", strings.Join(args, ", "), strings.Join(gateNames, ", "))
+		}
+	} else {
+		featureName := args[0]
+		result, err := activateFeature(ctx, fgClient, featureName, userAllows)
+		if err != nil {
+			return fmt.Errorf("could not activate Feature %s: %w", featureName, err)
+		}
+		printActivationResult(cmd, result)
 	}
 
-	cmd.Printf("Feature %s gated by FeatureGate %s is activated.\n", featureName, gateName)
+	if activateWait {
+		return waitForFeatures(cmd, fgClient, args, true, activateWaitTimeout)
+	}
 	return nil
 }
 
-func activateFeature(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, featureName string, userAllows *bool) (string, error) {
+// printActivationResult reports the outcome of setting the activation intent of a Feature.
+func printActivationResult(cmd *cobra.Command, result *featuregateclient.ActivationResult) {
+	state := "deactivated"
+	if result.Activate {
+		state = "activated"
+	}
+	if result.NoOp {
+		cmd.Printf("Feature %s gated by FeatureGate %s is already %s.\n", result.Feature, result.FeatureGate, state)
+		return
+	}
+	cmd.Printf("Feature %s gated by FeatureGate %s is %s.\n", result.Feature, result.FeatureGate, state)
+	if result.WarrantyVoided {
+		cmd.Println("All support guarantees for this environment are permanently voided.")
+	}
+}
+
+func activateFeature(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, featureName string, userAllows *bool) (*featuregateclient.ActivationResult, error) {
 	feature, err := fgClient.GetFeature(ctx, featureName)
 	if err != nil {
-		return "", fmt.Errorf("could not get Feature %s: %w", featureName, err)
+		return nil, fmt.Errorf("could not get Feature %s: %w", featureName, err)
 	}
 
 	gates, err := fgClient.GetFeatureGateList(ctx)
 	if err != nil {
-		return "", fmt.Errorf("could not get FeatureGate List: %w", err)
+		return nil, fmt.Errorf("could not get FeatureGate List: %w", err)
 	}
 
 	gateName, featRef := featuregateclient.FeatureRefFromGateList(gates, featureName)
@@ -75,20 +139,62 @@ func activateFeature(ctx context.Context, fgClient *featuregateclient.FeatureGat
 	var proceedWithVoidingWarranty bool
 	if willWarrantyBeVoided(featRef, feature) {
 		// The warranty will be voided with the request, so check that user allows it.
-		proceedWithVoidingWarranty, err = userGivesPermissionToVoidWarranty(feature, userAllows)
+		proceedWithVoidingWarranty, err = featuregateclient.UserGivesPermissionToVoidWarranty(os.Stdin, os.Stdout, feature, userAllows)
 		if err != nil {
-			return gateName, fmt.Errorf("could not get user permission to void warranty for Feature %s: %w", featureName, err)
+			return nil, fmt.Errorf("could not get user permission to void warranty for Feature %s: %w", featureName, err)
 		}
 	}
 
-	err = fgClient.ActivateFeature(ctx, featureName, proceedWithVoidingWarranty)
+	result, err := fgClient.ActivateFeature(ctx, featureName, proceedWithVoidingWarranty)
 	if err != nil {
-		return gateName, fmt.Errorf("could not activate Feature %s gated by FeatureGate %s: %w", featureName, gateName, err)
+		return nil, fmt.Errorf("could not activate Feature %s gated by FeatureGate %s: %w", featureName, gateName, err)
 	}
 
-	displayActivationWarnings(feature)
+	if !result.NoOp {
+		displayActivationWarnings(feature)
+	}
 
-	return gateName, nil
+	return result, nil
+}
+
+// activateFeatures activates several Features in as few FeatureGate updates as possible. The user is asked for
+// permission for every Feature whose activation voids the warranty before any FeatureGate is updated. It returns the
+// names of the updated FeatureGates.
+func activateFeatures(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, featureNames []string, userAllows *bool) ([]string, error) {
+	gates, err := fgClient.GetFeatureGateList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureGate List: %w", err)
+	}
+
+	var features []*corev1alpha2.Feature
+	changes := make([]featuregateclient.FeatureChange, 0, len(featureNames))
+	for _, featureName := range featureNames {
+		change := featuregateclient.FeatureChange{Name: featureName, Activate: true}
+
+		// Features that cannot be retrieved are reported when applying the changes.
+		feature, err := fgClient.GetFeature(ctx, featureName)
+		if err == nil {
+			features = append(features, feature)
+			_, featRef := featuregateclient.FeatureRefFromGateList(gates, featureName)
+			if willWarrantyBeVoided(featRef, feature) {
+				change.WarrantyVoidAllowed, err = featuregateclient.UserGivesPermissionToVoidWarranty(os.Stdin, os.Stdout, feature, userAllows)
+				if err != nil {
+					return nil, fmt.Errorf("could not get user permission to void warranty for Feature %s: %w", featureName, err)
+				}
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	gateNames, err := fgClient.ApplyFeatureChanges(ctx, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, feature := range features {
+		displayActivationWarnings(feature)
+	}
+	return gateNames, nil
 }
 
 // displayActivationWarnings warns the user that technical preview features are
@@ -119,38 +225,3 @@ func willWarrantyBeVoided(ref corev1alpha2.FeatureReference, feature *corev1alph
 	policy := corev1alpha2.GetPolicyForStabilityLevel(stability)
 	return policy.VoidsWarranty && !ref.PermanentlyVoidAllSupportGuarantees && !ref.Activate && !policy.DefaultActivation
 }
-
-func userGivesPermissionToVoidWarranty(feature *corev1alpha2.Feature, userAllowsByFlag *bool) (bool, error) {
-	if userAllowsByFlag == nil {
-		// Request user permission interactively to void the warranty if not already set by flag.
-		fmt.Printf("Warning: activating %q, a %s Feature will irrevocably void all support guarantees for this environment. You will need to recreate the environment to return to a supported state.\nWould you like to continue [y/N]?", feature.Name, feature.Spec.Stability)
-		return userAllowsByInteractiveCLI(nil)
-	}
-	// If the user did pass a value to the flag, use the user's input. No interactive user input is needed.
-	return *userAllowsByFlag, nil
-}
-
-func userAllowsByInteractiveCLI(in *os.File) (bool, error) {
-	if in == nil {
-		in = os.Stdin
-	}
-
-	var userAnswer string
-	if n, err := fmt.Fscanln(in, &userAnswer); err != nil {
-		if n == 0 {
-			return false, nil
-		}
-		return false, fmt.Errorf("could not read user input: %w", err)
-	}
-
-	userAnswer = strings.TrimSpace(strings.ToLower(userAnswer))
-	switch {
-	case userAnswer == "yes" || userAnswer == "y":
-		return true, nil
-	case userAnswer == "" || userAnswer == "no" || userAnswer == "n":
-		return false, nil
-	default:
-		fmt.Printf("Please respond with %q or %q\n", "y", "n")
-		return userAllowsByInteractiveCLI(nil)
-	}
-}
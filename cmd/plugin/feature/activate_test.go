@@ -4,6 +4,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -134,71 +135,117 @@ func TestActivateFeature(t *testing.T) {
 	}
 }
 
-func TestUserAllowsByInteractiveCLI(t *testing.T) {
+func TestPrintActivationResult(t *testing.T) {
 	tests := []struct {
 		description string
-		userInput   string
-		want        bool
+		result      *featuregateclient.ActivationResult
+		want        []string
+		notWant     string
 	}{
 		{
-			description: "return true for user input yes",
-			userInput:   "yes",
-			want:        true,
+			description: "should report an activated Feature",
+			result:      &featuregateclient.ActivationResult{FeatureGate: "tanzu-fg", Feature: "tuna", Activate: true},
+			want:        []string{"Feature tuna gated by FeatureGate tanzu-fg is activated."},
+			notWant:     "voided",
 		},
 		{
-			description: "return true for user input Y",
-			userInput:   "Y",
-			want:        true,
+			description: "should report a Feature that was already activated",
+			result:      &featuregateclient.ActivationResult{FeatureGate: "tanzu-fg", Feature: "tuner", PreviousActivate: true, Activate: true, NoOp: true},
+			want:        []string{"Feature tuner gated by FeatureGate tanzu-fg is already activated."},
 		},
 		{
-			description: "return true for user input no",
-			userInput:   "no",
-			want:        false,
+			description: "should report voided support guarantees",
+			result:      &featuregateclient.ActivationResult{FeatureGate: "tkg-system", Feature: "cloud-event-speaker", Activate: true, WarrantyVoided: true},
+			want:        []string{"is activated.", "support guarantees for this environment are permanently voided"},
 		},
 		{
-			description: "return true for user input N",
-			userInput:   "N",
-			want:        false,
+			description: "should report a deactivated Feature",
+			result:      &featuregateclient.ActivationResult{FeatureGate: "tanzu-fg", Feature: "tuner", PreviousActivate: true},
+			want:        []string{"Feature tuner gated by FeatureGate tanzu-fg is deactivated."},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			printActivationResult(cmd, tc.result)
+			for _, want := range tc.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("got output: %q, want it to contain: %q", out.String(), want)
+				}
+			}
+			if tc.notWant != "" && strings.Contains(out.String(), tc.notWant) {
+				t.Errorf("got output: %q, want it not to contain: %q", out.String(), tc.notWant)
+			}
+		})
+	}
+}
+
+func TestActivateFeatures(t *testing.T) {
+	allowed, disallowed := true, false
+	tests := []struct {
+		description  string
+		featureNames []string
+		userAllows   *bool
+		wantErr      error
+		wantActivate map[string]bool
+	}{
+		{
+			description:  "activate features gated by different feature gates",
+			featureNames: []string{"bar", "tuna"},
+			wantActivate: map[string]bool{"bar": true, "tuna": true},
+		},
+		{
+			description:  "activate experimental feature with user permission",
+			featureNames: []string{"bar", "cloud-event-speaker"},
+			userAllows:   &allowed,
+			wantActivate: map[string]bool{"bar": true, "cloud-event-speaker": true},
 		},
 		{
-			description: "return false for no user input",
-			userInput:   "",
-			want:        false,
+			description:  "don't activate any feature when user disallows voiding the warranty of one",
+			featureNames: []string{"bar", "cloud-event-relayer"},
+			userAllows:   &disallowed,
+			wantErr:      featuregateclient.ErrTypeForbidden,
+			wantActivate: map[string]bool{"bar": false, "cloud-event-relayer": false},
 		},
 		{
-			description: "return false for bad user input on first try and no user input on second",
-			userInput:   "bad-input",
-			want:        false,
+			description:  "don't activate any feature when one was not found in cluster",
+			featureNames: []string{"bar", "hard-to-get"},
+			wantErr:      featuregateclient.ErrTypeNotFound,
+			wantActivate: map[string]bool{"bar": false},
 		},
 	}
 
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
 	for _, tc := range tests {
 		t.Run(tc.description, func(t *testing.T) {
-			in, err := os.CreateTemp("", "tmp_test_feature_activate")
+			objs, _, _ := fake.GetTestObjects()
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+			fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
 			if err != nil {
-				t.Fatal(err)
-			}
-			if _, err := in.Write([]byte(tc.userInput)); err != nil {
-				t.Fatal(err)
-			}
-			if _, err := in.Seek(0, 0); err != nil {
-				t.Fatal(err)
+				t.Fatalf("unable to get FeatureGate client: %v", err)
 			}
 
-			got, err := userAllowsByInteractiveCLI(in)
-			if err != nil {
-				t.Fatal(err)
-			}
-			if got != tc.want {
-				t.Errorf("got: %t, want: %t", got, tc.want)
+			_, err = activateFeatures(context.Background(), fgClient, tc.featureNames, tc.userAllows)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
 			}
 
-			// Clean up temporary file.
-			if err := in.Close(); err != nil {
-				t.Fatal(err)
+			gates, err := fgClient.GetFeatureGateList(context.Background())
+			if err != nil {
+				t.Fatalf("get FeatureGate List: %v", err)
 			}
-			if err := os.Remove(in.Name()); err != nil {
-				t.Fatal(err)
+			for name, want := range tc.wantActivate {
+				_, ref := featuregateclient.FeatureRefFromGateList(gates, name)
+				if ref.Activate != want {
+					t.Errorf("got Feature %s activate: %t, want: %t", name, ref.Activate, want)
+				}
 			}
 		})
 	}
@@ -0,0 +1,102 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+var (
+	approverAllowsVoidingWarranty bool
+	approveReason                 string
+)
+
+// FeatureApproveCmd is for approving a request to change the activation of a Feature
+var FeatureApproveCmd = &cobra.Command{
+	Use:   "approve <request>",
+	Short: "Approve a feature activation request",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+	# Approve a FeatureActivationRequest, so that the FeatureGate gating the Feature is changed as requested
+	tanzu feature approve myfeature-x7k2p --reason "Approved for the evaluation"
+
+	# Approve a FeatureActivationRequest whose change voids all support guarantees for this environment
+	tanzu feature approve myexperimentalfeature-q9w4d --permanentlyVoidAllSupportGuarantees=true`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requestName := args[0]
+
+		fgClient, err := featuregateclient.NewFeatureGateClient()
+		if err != nil {
+			return fmt.Errorf("could not get FeatureGateClient: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+		defer cancel()
+
+		var approverAllows *bool
+		if cmd.Flags().Changed("permanentlyVoidAllSupportGuarantees") {
+			approverAllows = &approverAllowsVoidingWarranty
+		}
+
+		request, err := approveFeatureActivationRequest(ctx, fgClient, requestName, approveReason, approverAllows)
+		if err != nil {
+			return fmt.Errorf("could not approve FeatureActivationRequest %s: %w", requestName, err)
+		}
+		printDecision(cmd, request)
+		return nil
+	},
+}
+
+func init() {
+	FeatureApproveCmd.Flags().BoolVar(&approverAllowsVoidingWarranty, "permanentlyVoidAllSupportGuarantees", false, "Acknowledge that approving the request permanently voids all support guarantees for this environment. For some features, e.g. experimental features, activating them permanently voids all support guarantees for this environment.")
+	FeatureApproveCmd.Flags().StringVar(&approveReason, "reason", "", "Why the request is approved")
+}
+
+// approveFeatureActivationRequest approves the request. If approving it voids the warranty, the approver is asked for
+// permission unless given by flag.
+func approveFeatureActivationRequest(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, requestName, reason string, approverAllows *bool) (*corev1alpha2.FeatureActivationRequest, error) {
+	request, err := fgClient.GetFeatureActivationRequest(ctx, requestName)
+	if err != nil {
+		return nil, err
+	}
+
+	feature, err := fgClient.GetFeature(ctx, request.Spec.Feature)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Feature %s: %w", request.Spec.Feature, err)
+	}
+
+	var proceedWithVoidingWarranty bool
+	if request.Spec.Decision == nil && request.VoidsWarranty(feature) {
+		// The warranty will be voided with the approval, so check that the approver allows it.
+		proceedWithVoidingWarranty, err = featuregateclient.UserGivesPermissionToVoidWarranty(os.Stdin, os.Stdout, feature, approverAllows)
+		if err != nil {
+			return nil, fmt.Errorf("could not get approver permission to void warranty for Feature %s: %w", feature.Name, err)
+		}
+	}
+
+	return fgClient.ApproveFeatureActivationRequest(ctx, requestName, reason, proceedWithVoidingWarranty)
+}
+
+// printDecision reports the decision on a FeatureActivationRequest.
+func printDecision(cmd *cobra.Command, request *corev1alpha2.FeatureActivationRequest) {
+	state := "deactivated"
+	if request.Spec.Activate {
+		state = "activated"
+	}
+	decision := "rejected"
+	if request.Spec.Decision.Type == corev1alpha2.FeatureActivationApproved {
+		decision = "approved"
+	}
+	cmd.Printf("FeatureActivationRequest %s for Feature %s to be %s is %s.\n", request.Name, request.Spec.Feature, state, decision)
+	if request.Spec.Decision.PermanentlyVoidAllSupportGuarantees {
+		cmd.Println("All support guarantees for this environment are permanently voided once the request is applied.")
+	}
+}
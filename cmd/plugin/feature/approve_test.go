@@ -0,0 +1,98 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+)
+
+func TestApproveFeatureActivationRequest(t *testing.T) {
+	allowed, disallowed := true, false
+	tests := []struct {
+		description      string
+		featureName      string
+		approverAllows   *bool
+		wantErr          error
+		wantVoidWarranty bool
+		wantOutput       []string
+	}{
+		{
+			description: "approve a request for a technical preview feature",
+			featureName: "tuna",
+			wantOutput:  []string{"for Feature tuna to be activated is approved."},
+		},
+		{
+			description:      "approve a request for an experimental feature with approver permission",
+			featureName:      "cloud-event-speaker",
+			approverAllows:   &allowed,
+			wantVoidWarranty: true,
+			wantOutput:       []string{"is approved.", "support guarantees for this environment are permanently voided"},
+		},
+		{
+			description:    "don't approve a request for an experimental feature when approver disallows it",
+			featureName:    "cloud-event-speaker",
+			approverAllows: &disallowed,
+			wantErr:        featuregateclient.ErrTypeForbidden,
+		},
+		{
+			description: "don't approve a request for an experimental feature when approver does not give permission",
+			featureName: "cloud-event-speaker",
+			wantErr:     featuregateclient.ErrTypeForbidden,
+		},
+	}
+
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			objs, _, _ := fake.GetTestObjects()
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+			fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate client: %v", err)
+			}
+			request, err := fgClient.CreateFeatureActivationRequest(context.Background(), tc.featureName, true, "needed")
+			if err != nil {
+				t.Fatalf("unable to create FeatureActivationRequest: %v", err)
+			}
+
+			got, err := approveFeatureActivationRequest(context.Background(), fgClient, request.Name, "looks good", tc.approverAllows)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if got.Spec.Decision.Type != corev1alpha2.FeatureActivationApproved ||
+				got.Spec.Decision.PermanentlyVoidAllSupportGuarantees != tc.wantVoidWarranty {
+				t.Errorf("got decision: %+v, want approved with warranty voided: %t", got.Spec.Decision, tc.wantVoidWarranty)
+			}
+
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			printDecision(cmd, got)
+			for _, want := range tc.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("got output: %q, want it to contain: %q", out.String(), want)
+				}
+			}
+		})
+	}
+}
@@ -6,23 +6,42 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
 )
 
+var (
+	deactivateDryRun         bool
+	deactivateWait           bool
+	deactivateWaitTimeout    time.Duration
+	deactivateBypassApproval bool
+)
+
 // FeatureDeactivateCmd is for deactivating Features
 var FeatureDeactivateCmd = &cobra.Command{
-	Use:   "deactivate <feature>",
+	Use:   "deactivate <feature>...",
 	Short: "Deactivate Features",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.MinimumNArgs(1),
 	Example: `
 	# Deactivate a cluster Feature
-	tanzu feature deactivate myfeature`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		featureName := args[0]
+	tanzu feature deactivate myfeature
+
+	# Deactivate several cluster Features at once. Either all of them are deactivated or none is.
+	tanzu feature deactivate myfeature myotherfeature
 
+	# Report the FeatureGate changes deactivating a Feature requires without making them
+	tanzu feature deactivate myfeature --dry-run
+
+	# Deactivate a cluster Feature and wait until it is deactivated in the cluster
+	tanzu feature deactivate myfeature --wait --timeout 2m
+
+	# Deactivate a cluster Feature that is not Stable without requesting approval
+	tanzu feature deactivate myfeature --bypass-approval`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		fgClient, err := featuregateclient.NewFeatureGateClient()
 		if err != nil {
 			return fmt.Errorf("could not get FeatureGateClient: %w", err)
@@ -31,16 +50,51 @@ var FeatureDeactivateCmd = &cobra.Command{
 		ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 		defer cancel()
 
-		gateName, err := deactivateFeature(ctx, fgClient, featureName)
-		if err != nil {
-			return fmt.Errorf("could not deactivate Feature %s gated by FeatureGate %s: %w", featureName, gateName, err)
+		if deactivateDryRun {
+			changes := make([]featuregateclient.FeatureChange, 0, len(args))
+			for _, featureName := range args {
+				changes = append(changes, featuregateclient.FeatureChange{Name: featureName, Activate: false})
+			}
+			return dryRunFeatureChanges(ctx, cmd, fgClient, changes)
 		}
 
-		cmd.Printf("Feature %s gated by FeatureGate %s is deactivated.\n", featureName, gateName)
+		if err := requireApprovalBypass(ctx, fgClient, args, false, deactivateBypassApproval); err != nil {
+			return err
+		}
+
+		if len(args) > 1 {
+			gateNames, err := fgClient.DeactivateFeatures(ctx, args)
+			if err != nil {
+				return fmt.Errorf("could not deactivate Features %s: %w", strings.Join(args, ", "), err)
+			}
+			if len(gateNames) == 0 {
+				cmd.Printf("Features %s are already deactivated.\n", strings.Join(args, ", "))
+			} else {
+				cmd.Printf("Features %s are deactivated in FeatureGates %s.\n", strings.Join(args, ", "), strings.Join(gateNames, ", "))
+			}
+		} else {
+			featureName := args[0]
+			result, err := deactivateFeature(ctx, fgClient, featureName)
+			if err != nil {
+				return fmt.Errorf("could not deactivate Feature %s: %w", featureName, err)
+			}
+			printActivationResult(cmd, result)
+		}
+
+		if deactivateWait {
+			return waitForFeatures(cmd, fgClient, args, false, deactivateWaitTimeout)
+		}
 		return nil
 	},
 }
 
-func deactivateFeature(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, featureName string) (string, error) {
+func init() {
+	FeatureDeactivateCmd.Flags().BoolVar(&deactivateDryRun, "dry-run", false, "Report the FeatureGate changes and whether they would be accepted, without making them")
+	FeatureDeactivateCmd.Flags().BoolVar(&deactivateWait, "wait", false, "Wait until the Features are deactivated in the cluster, and fail if their FeatureGate reports the deactivation as invalid")
+	FeatureDeactivateCmd.Flags().DurationVar(&deactivateWaitTimeout, "timeout", defaultWaitTimeout, "How long to wait for the Features with --wait")
+	FeatureDeactivateCmd.Flags().BoolVar(&deactivateBypassApproval, "bypass-approval", false, "Change the FeatureGate directly for Features that are not Stable, rather than requesting approval with \"tanzu feature request\". Clusters enforcing approval reject the change")
+}
+
+func deactivateFeature(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, featureName string) (*featuregateclient.ActivationResult, error) {
 	return fgClient.DeactivateFeature(ctx, featureName)
 }
@@ -0,0 +1,46 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+// dryRunFeatureChanges reports the FeatureGates the changes would update, the old and new activation intent of every
+// Feature, whether support guarantees would be voided and whether the cluster would accept the updates.
+func dryRunFeatureChanges(ctx context.Context, cmd *cobra.Command, fgClient *featuregateclient.FeatureGateClient, changes []featuregateclient.FeatureChange) error {
+	gateChanges, err := fgClient.DryRunFeatureChanges(ctx, changes)
+	if err != nil {
+		return fmt.Errorf("could not dry run Feature changes: %w", err)
+	}
+	printFeatureGateChanges(cmd, gateChanges)
+	return nil
+}
+
+func printFeatureGateChanges(cmd *cobra.Command, gateChanges []featuregateclient.FeatureGateChange) {
+	if len(gateChanges) == 0 {
+		cmd.Println("No FeatureGate would change.")
+		return
+	}
+
+	for _, gateChange := range gateChanges {
+		cmd.Printf("FeatureGate %s would change:\n", gateChange.FeatureGate)
+		for _, change := range gateChange.Features {
+			cmd.Printf("  Feature %s: activate %t -> %t\n", change.New.Name, change.Old.Activate, change.New.Activate)
+			if change.VoidsWarranty {
+				cmd.Printf("    Warning: all support guarantees for this environment would be permanently voided.\n")
+			}
+		}
+		if gateChange.Accepted {
+			cmd.Printf("  The change would be accepted.\n")
+		} else {
+			cmd.Printf("  The change would be rejected: %s\n", gateChange.Rejection)
+		}
+	}
+}
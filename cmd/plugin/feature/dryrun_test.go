@@ -0,0 +1,95 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+)
+
+func TestDryRunFeatureChanges(t *testing.T) {
+	tests := []struct {
+		description string
+		changes     []featuregateclient.FeatureChange
+		want        []string
+		wantErr     bool
+	}{
+		{
+			description: "report activation voiding the warranty",
+			changes: []featuregateclient.FeatureChange{
+				{Name: "cloud-event-speaker", Activate: true, WarrantyVoidAllowed: true},
+			},
+			want: []string{
+				"FeatureGate tkg-system would change:",
+				"Feature cloud-event-speaker: activate false -> true",
+				"support guarantees for this environment would be permanently voided",
+				"The change would be accepted.",
+			},
+		},
+		{
+			description: "report deactivation",
+			changes: []featuregateclient.FeatureChange{
+				{Name: "tuner", Activate: false},
+			},
+			want: []string{
+				"FeatureGate tanzu-fg would change:",
+				"Feature tuner: activate true -> false",
+			},
+		},
+		{
+			description: "report that nothing would change",
+			changes: []featuregateclient.FeatureChange{
+				{Name: "super-toaster", Activate: true},
+			},
+			want: []string{"No FeatureGate would change."},
+		},
+		{
+			description: "fail for immutable feature",
+			changes: []featuregateclient.FeatureChange{
+				{Name: "super-toaster", Activate: false},
+			},
+			wantErr: true,
+		},
+	}
+
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			objs, _, _ := fake.GetTestObjects()
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+			fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate client: %v", err)
+			}
+
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+
+			err = dryRunFeatureChanges(context.Background(), cmd, fgClient, tc.changes)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error: %v, want error: %t", err, tc.wantErr)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("got output:\n%s\nwant it to contain: %s", out.String(), want)
+				}
+			}
+		})
+	}
+}
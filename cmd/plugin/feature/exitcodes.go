@@ -0,0 +1,95 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+// Exit codes of the plugin. They are part of the interface of the plugin, so that scripts can tell why a command
+// failed: existing values must not change.
+const (
+	// exitCodeError is used for errors without a more specific exit code.
+	exitCodeError = 1
+	// exitCodeNotFound is used when a Feature or FeatureGate does not exist, or a Feature is not gated by any
+	// FeatureGate.
+	exitCodeNotFound = 3
+	// exitCodeWarrantyConsentRequired is used when changing the activation of a Feature voids the warranty, and
+	// permission to void it was not given.
+	exitCodeWarrantyConsentRequired = 4
+	// exitCodeImmutable is used when the activation of a Feature cannot be changed as its stability policy makes it
+	// immutable.
+	exitCodeImmutable = 5
+	// exitCodeMultipleFeatureGates is used when a Feature is gated by more than one FeatureGate.
+	exitCodeMultipleFeatureGates = 6
+	// exitCodeInvalidReference is used when a FeatureGate reports its reference to a Feature as invalid while
+	// waiting for the Feature.
+	exitCodeInvalidReference = 7
+	// exitCodeConflict is used when a FeatureGate kept being changed concurrently.
+	exitCodeConflict = 8
+	// exitCodeRejected is used when the cluster rejects a FeatureGate change.
+	exitCodeRejected = 9
+	// exitCodeTimeout is used when waiting for Features times out.
+	exitCodeTimeout = 10
+	// exitCodeForbidden is used when the cluster does not allow the user to make a change, such as deciding on a
+	// FeatureActivationRequest without the approval permission.
+	exitCodeForbidden = 11
+)
+
+// exitCode returns the exit code for the error returned by a command. An aggregate of errors gets the exit code of its
+// errors if they all have the same one, and exitCodeError otherwise.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var aggregate kerrors.Aggregate
+	if errors.As(err, &aggregate) && len(aggregate.Errors()) > 0 {
+		code := exitCode(aggregate.Errors()[0])
+		for _, e := range aggregate.Errors()[1:] {
+			if exitCode(e) != code {
+				return exitCodeError
+			}
+		}
+		return code
+	}
+
+	var (
+		notFound      *featuregateclient.NotFoundError
+		notGated      *featuregateclient.FeatureNotGatedError
+		consent       *featuregateclient.WarrantyConsentError
+		immutable     *featuregateclient.ImmutableFeatureError
+		multipleGates *featuregateclient.MultipleFeatureGatesError
+		invalidRef    *featuregateclient.InvalidReferenceError
+		conflict      *featuregateclient.ConflictError
+	)
+	switch {
+	case errors.As(err, &notFound), errors.As(err, &notGated):
+		return exitCodeNotFound
+	case errors.As(err, &consent):
+		return exitCodeWarrantyConsentRequired
+	case errors.As(err, &immutable):
+		return exitCodeImmutable
+	case errors.As(err, &multipleGates):
+		return exitCodeMultipleFeatureGates
+	case errors.As(err, &invalidRef):
+		return exitCodeInvalidReference
+	case errors.As(err, &conflict):
+		return exitCodeConflict
+	case apierrors.IsInvalid(err):
+		return exitCodeRejected
+	case apierrors.IsForbidden(err):
+		return exitCodeForbidden
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitCodeTimeout
+	default:
+		return exitCodeError
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+func TestExitCode(t *testing.T) {
+	wrap := func(err error) error {
+		return fmt.Errorf("could not activate Feature foo gated by FeatureGate bar: %w", err)
+	}
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "featuregates"}, "bar", errors.New("modified"))
+	rejected := apierrors.NewInvalid(schema.GroupKind{Kind: "FeatureGate"}, "bar", field.ErrorList{field.Forbidden(field.NewPath("spec"), "gated twice")})
+
+	tests := []struct {
+		description string
+		err         error
+		want        int
+	}{
+		{description: "no error", want: 0},
+		{description: "unknown error", err: errors.New("boom"), want: exitCodeError},
+		{description: "Feature not found", err: wrap(&featuregateclient.NotFoundError{Kind: "Feature", Name: "foo"}), want: exitCodeNotFound},
+		{description: "Feature not gated", err: wrap(&featuregateclient.FeatureNotGatedError{Feature: "foo"}), want: exitCodeNotFound},
+		{description: "warranty consent required", err: wrap(&featuregateclient.WarrantyConsentError{Feature: "foo"}), want: exitCodeWarrantyConsentRequired},
+		{description: "immutable Feature", err: wrap(&featuregateclient.ImmutableFeatureError{Feature: "foo"}), want: exitCodeImmutable},
+		{description: "Feature in several FeatureGates", err: wrap(&featuregateclient.MultipleFeatureGatesError{Feature: "foo"}), want: exitCodeMultipleFeatureGates},
+		{description: "invalid reference", err: wrap(&featuregateclient.InvalidReferenceError{Feature: "foo"}), want: exitCodeInvalidReference},
+		{description: "conflict", err: wrap(&featuregateclient.ConflictError{FeatureGate: "bar", Err: conflict}), want: exitCodeConflict},
+		{description: "rejected by the cluster", err: wrap(rejected), want: exitCodeRejected},
+		{description: "forbidden by the cluster", err: wrap(apierrors.NewForbidden(schema.GroupResource{Resource: "featureactivationrequests"}, "foo-x7k2p", errors.New("not an approver"))), want: exitCodeForbidden},
+		{description: "timeout", err: wrap(context.DeadlineExceeded), want: exitCodeTimeout},
+		{
+			description: "aggregate of the same kind",
+			err: wrap(kerrors.NewAggregate([]error{
+				&featuregateclient.WarrantyConsentError{Feature: "foo"},
+				&featuregateclient.WarrantyConsentError{Feature: "baz"},
+			})),
+			want: exitCodeWarrantyConsentRequired,
+		},
+		{
+			description: "aggregate of different kinds",
+			err: kerrors.NewAggregate([]error{
+				&featuregateclient.WarrantyConsentError{Feature: "foo"},
+				&featuregateclient.ImmutableFeatureError{Feature: "baz"},
+			}),
+			want: exitCodeError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := exitCode(tc.err); got != tc.want {
+				t.Errorf("got exit code: %d, want: %d", got, tc.want)
+			}
+		})
+	}
+}
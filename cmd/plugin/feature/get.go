@@ -0,0 +1,195 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+var getOutputFormat string
+
+// FeatureGetCmd is for describing a Feature.
+var FeatureGetCmd = &cobra.Command{
+	Use:   "get <feature>",
+	Short: "Describe a feature",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+	# Describe a Feature, its stability policy, the FeatureGate gating it and its activation.
+	tanzu feature get myfeature
+	tanzu feature get myfeature -o yaml`,
+	RunE: featureGet,
+}
+
+func init() {
+	FeatureGetCmd.Flags().StringVarP(&getOutputFormat, "output", "o", "", "Output format (yaml|json)")
+}
+
+// FeatureDetails holds a Feature, its stability policy and how the FeatureGate gating it sets its activation.
+type FeatureDetails struct {
+	Name        string                      `json:"name" yaml:"name"`
+	Description string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Stability   corev1alpha2.StabilityLevel `json:"stability,omitempty" yaml:"stability,omitempty"`
+	// Policy is the policy of the stability level, unknown until the Feature is in the cluster.
+	Policy *FeaturePolicy `json:"policy,omitempty" yaml:"policy,omitempty"`
+	// FeatureGate is the name of the FeatureGate gating the Feature, if any.
+	FeatureGate string `json:"featureGate,omitempty" yaml:"featureGate,omitempty"`
+	// Reference is the activation intent set in the FeatureGate.
+	Reference *FeatureReferenceDetails `json:"reference,omitempty" yaml:"reference,omitempty"`
+	// Result is the outcome of applying the reference, as reported in the FeatureGate status.
+	Result *FeatureReferenceResultDetails `json:"result,omitempty" yaml:"result,omitempty"`
+	// Activated is the effective activation of the Feature.
+	Activated bool `json:"activated" yaml:"activated"`
+	// InCluster is false when the FeatureGate references a Feature that is not in the cluster.
+	InCluster bool `json:"inCluster" yaml:"inCluster"`
+}
+
+// FeaturePolicy is the stability level policy of a Feature.
+type FeaturePolicy struct {
+	DefaultActivation bool `json:"defaultActivation" yaml:"defaultActivation"`
+	Immutable         bool `json:"immutable" yaml:"immutable"`
+	VoidsWarranty     bool `json:"voidsWarranty" yaml:"voidsWarranty"`
+	Discoverable      bool `json:"discoverable" yaml:"discoverable"`
+}
+
+// FeatureReferenceDetails is the activation intent for a Feature in a FeatureGate.
+type FeatureReferenceDetails struct {
+	Activate                            bool `json:"activate" yaml:"activate"`
+	PermanentlyVoidAllSupportGuarantees bool `json:"permanentlyVoidAllSupportGuarantees" yaml:"permanentlyVoidAllSupportGuarantees"`
+}
+
+// FeatureReferenceResultDetails is the result of applying a Feature reference.
+type FeatureReferenceResultDetails struct {
+	Status  corev1alpha2.FeatureReferenceStatus `json:"status" yaml:"status"`
+	Message string                              `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+func featureGet(cmd *cobra.Command, args []string) error {
+	featureName := args[0]
+
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	details, err := featureDetails(ctx, fgClient, featureName)
+	if err != nil {
+		return fmt.Errorf("could not get Feature %s: %w", featureName, err)
+	}
+
+	return printFeatureDetails(cmd, details, getOutputFormat)
+}
+
+// featureDetails gathers the Feature, its policy and the reference and reference result of the FeatureGate gating it.
+// A Feature that is not in the cluster is described only if a FeatureGate references it.
+func featureDetails(ctx context.Context, cl *featuregateclient.FeatureGateClient, featureName string) (*FeatureDetails, error) {
+	feature, err := cl.GetFeature(ctx, featureName)
+	if err != nil && !errors.Is(err, featuregateclient.ErrTypeNotFound) {
+		return nil, err
+	}
+
+	gates, err := cl.GetFeatureGateList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureGate List: %w", err)
+	}
+	gateName, featRef := featuregateclient.FeatureRefFromGateList(gates, featureName)
+
+	if feature == nil && gateName == "" {
+		return nil, featuregateclient.ErrTypeNotFound
+	}
+
+	details := &FeatureDetails{Name: featureName, FeatureGate: gateName}
+	if feature != nil {
+		policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability)
+		details.Description = feature.Spec.Description
+		details.Stability = feature.Spec.Stability
+		details.Policy = &FeaturePolicy{
+			DefaultActivation: policy.DefaultActivation,
+			Immutable:         policy.Immutable,
+			VoidsWarranty:     policy.VoidsWarranty,
+			Discoverable:      policy.Discoverable,
+		}
+		details.Activated = feature.Status.Activated
+		details.InCluster = true
+	}
+
+	if gateName != "" {
+		details.Reference = &FeatureReferenceDetails{
+			Activate:                            featRef.Activate,
+			PermanentlyVoidAllSupportGuarantees: featRef.PermanentlyVoidAllSupportGuarantees,
+		}
+		details.Result = featureReferenceResult(gates, gateName, featureName)
+	}
+
+	return details, nil
+}
+
+// featureReferenceResult returns the result of the Feature reference reported by the FeatureGate, or nil if the
+// FeatureGate has not reported one yet.
+func featureReferenceResult(gates *corev1alpha2.FeatureGateList, gateName, featureName string) *FeatureReferenceResultDetails {
+	for i := range gates.Items {
+		if gates.Items[i].Name != gateName {
+			continue
+		}
+		for _, result := range gates.Items[i].Status.FeatureReferenceResults {
+			if result.Name == featureName {
+				return &FeatureReferenceResultDetails{Status: result.Status, Message: result.Message}
+			}
+		}
+	}
+	return nil
+}
+
+// printFeatureDetails renders the Feature details as yaml or json, or as a list table by default.
+func printFeatureDetails(cmd *cobra.Command, details *FeatureDetails, format string) error {
+	switch component.OutputType(format) {
+	case component.YAMLOutputType, component.JSONOutputType:
+		component.NewObjectWriter(cmd.OutOrStdout(), format, details).Render()
+		return nil
+	case "", component.ListTableOutputType:
+	default:
+		return fmt.Errorf("unsupported output format %q, must be yaml or json", format)
+	}
+
+	t := component.NewOutputWriter(cmd.OutOrStdout(), string(component.ListTableOutputType),
+		"NAME", "DESCRIPTION", "STABILITY", "DEFAULT ACTIVATION", "IMMUTABLE", "VOIDS WARRANTY", "DISCOVERABLE",
+		"FEATUREGATE", "ACTIVATE", "PERMANENTLY VOID ALL SUPPORT GUARANTEES", "STATUS", "MESSAGE", "ACTIVATED")
+
+	unknown := "--"
+	row := []interface{}{details.Name, details.Description, details.Stability}
+	if details.Policy != nil {
+		row = append(row, details.Policy.DefaultActivation, details.Policy.Immutable, details.Policy.VoidsWarranty, details.Policy.Discoverable)
+	} else {
+		row = append(row, unknown, unknown, unknown, unknown)
+	}
+	if details.Reference != nil {
+		row = append(row, details.FeatureGate, details.Reference.Activate, details.Reference.PermanentlyVoidAllSupportGuarantees)
+	} else {
+		row = append(row, unknown, unknown, unknown)
+	}
+	if details.Result != nil {
+		row = append(row, details.Result.Status, details.Result.Message)
+	} else {
+		row = append(row, unknown, unknown)
+	}
+	if details.InCluster {
+		row = append(row, details.Activated)
+	} else {
+		row = append(row, "Feature not found in cluster")
+	}
+
+	t.AddRow(row...)
+	t.Render()
+	return nil
+}
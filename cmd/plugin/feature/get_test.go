@@ -0,0 +1,171 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+)
+
+func TestFeatureDetails(t *testing.T) {
+	tests := []struct {
+		description string
+		featureName string
+		want        *FeatureDetails
+		wantErr     error
+	}{
+		{
+			description: "experimental feature activated with voided warranty",
+			featureName: "cloud-event-listener",
+			want: &FeatureDetails{
+				Name:        "cloud-event-listener",
+				Description: "Open a port to listen for cloud events. Highly experimental!",
+				Stability:   corev1alpha2.Experimental,
+				Policy:      &FeaturePolicy{VoidsWarranty: true, Discoverable: true},
+				FeatureGate: "tkg-system",
+				Reference:   &FeatureReferenceDetails{Activate: true, PermanentlyVoidAllSupportGuarantees: true},
+				Result:      &FeatureReferenceResultDetails{Status: corev1alpha2.AppliedReferenceStatus},
+				Activated:   true,
+				InCluster:   true,
+			},
+		},
+		{
+			description: "feature with invalid reference",
+			featureName: "cloud-event-speaker",
+			want: &FeatureDetails{
+				Name:        "cloud-event-speaker",
+				Description: "Open a port to speak for cloud events. Highly experimental!",
+				Stability:   corev1alpha2.Experimental,
+				Policy:      &FeaturePolicy{VoidsWarranty: true, Discoverable: true},
+				FeatureGate: "tkg-system",
+				Reference:   &FeatureReferenceDetails{},
+				Result:      &FeatureReferenceResultDetails{Status: corev1alpha2.InvalidReferenceStatus, Message: "warranty void not allowed"},
+				InCluster:   true,
+			},
+		},
+		{
+			description: "feature not gated by any feature gate",
+			featureName: "specialized-toaster",
+			want: &FeatureDetails{
+				Name:        "specialized-toaster",
+				Description: "A new toaster specialized for special things",
+				Stability:   corev1alpha2.Stable,
+				Policy:      &FeaturePolicy{DefaultActivation: true, Immutable: true, Discoverable: true},
+				Activated:   true,
+				InCluster:   true,
+			},
+		},
+		{
+			description: "feature referenced by feature gate but not in cluster",
+			featureName: "hard-to-get",
+			want: &FeatureDetails{
+				Name:        "hard-to-get",
+				FeatureGate: "tkg-system",
+				Reference:   &FeatureReferenceDetails{},
+			},
+		},
+		{
+			description: "feature neither in cluster nor referenced",
+			featureName: "no-such-feature",
+			wantErr:     featuregateclient.ErrTypeNotFound,
+		},
+	}
+
+	objs, features, gates := fake.GetTestObjects()
+	objs = append(objs, features["specialized-toaster"])
+	gates["tkg-system"].Status.FeatureReferenceResults = []corev1alpha2.FeatureReferenceResult{
+		{Name: "cloud-event-listener", Status: corev1alpha2.AppliedReferenceStatus},
+		{Name: "cloud-event-speaker", Status: corev1alpha2.InvalidReferenceStatus, Message: "warranty void not allowed"},
+	}
+
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGate client: %v", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := featureDetails(context.Background(), fgClient, tc.featureName)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got: %+v, want: %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPrintFeatureDetails(t *testing.T) {
+	tests := []struct {
+		description string
+		format      string
+		want        string
+		wantErr     bool
+	}{
+		{
+			description: "list table",
+			format:      "",
+			want:        "PERMANENTLY VOID ALL SUPPORT GUARANTEES:",
+		},
+		{
+			description: "yaml",
+			format:      "yaml",
+			want:        "permanentlyVoidAllSupportGuarantees: true",
+		},
+		{
+			description: "json",
+			format:      "json",
+			want:        `"permanentlyVoidAllSupportGuarantees": true`,
+		},
+		{
+			description: "unsupported format",
+			format:      "table",
+			wantErr:     true,
+		},
+	}
+
+	details := &FeatureDetails{
+		Name:        "cloud-event-listener",
+		Stability:   corev1alpha2.Experimental,
+		Policy:      &FeaturePolicy{VoidsWarranty: true, Discoverable: true},
+		FeatureGate: "tkg-system",
+		Reference:   &FeatureReferenceDetails{Activate: true, PermanentlyVoidAllSupportGuarantees: true},
+		Activated:   true,
+		InCluster:   true,
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+
+			err := printFeatureDetails(cmd, details, tc.format)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error: %v, want error: %t", err, tc.wantErr)
+			}
+			if !strings.Contains(out.String(), tc.want) {
+				t.Errorf("got output:\n%s\nwant it to contain: %s", out.String(), tc.want)
+			}
+		})
+	}
+}
@@ -6,8 +6,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
 	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
@@ -18,8 +22,22 @@ var (
 	featuregate, outputFormat     string
 	activated, deactivated        bool
 	extended, includeExperimental bool
+	selector, sortBy              string
+	stabilities                   []string
 )
 
+// sortByFields are the fields the listed features can be sorted by.
+var sortByFields = []string{"name", "stability", "featuregate", "activation"}
+
+// stabilityOrder sorts stability levels from the least to the most mature. Unknown stability levels come last.
+var stabilityOrder = map[corev1alpha2.StabilityLevel]int{
+	corev1alpha2.WorkInProgress:   1,
+	corev1alpha2.Experimental:     2,
+	corev1alpha2.TechnicalPreview: 3,
+	corev1alpha2.Stable:           4,
+	corev1alpha2.Deprecated:       5,
+}
+
 // FeatureListCmd is for listing features in (a) featuregate(s).
 var FeatureListCmd = &cobra.Command{
 	Use:   "list",
@@ -28,7 +46,14 @@ var FeatureListCmd = &cobra.Command{
 	Example: `
 	# List feature(s) in the cluster.
 	tanzu feature list --activated
-	tanzu feature list --deactivated`,
+	tanzu feature list --deactivated
+
+	# List the features of a component, owned by a team or in a category.
+	tanzu feature list -l core.tanzu.vmware.com/feature-component=cloud-events
+	tanzu feature list -l core.tanzu.vmware.com/feature-owner-team=eventing
+
+	# List technical preview features, sorted by FeatureGate.
+	tanzu feature list --stability "Technical Preview" --sort-by featuregate`,
 	RunE: printFeatures,
 }
 
@@ -39,6 +64,9 @@ func init() {
 	FeatureListCmd.Flags().BoolVarP(&deactivated, "deactivated", "d", false, "List only deactivated features")
 	FeatureListCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
 	FeatureListCmd.Flags().BoolVarP(&includeExperimental, "include-experimental", "x", false, "Include experimental features in list")
+	FeatureListCmd.Flags().StringVarP(&selector, "selector", "l", "", "List only features matching the label selector, e.g. core.tanzu.vmware.com/feature-component=mycomponent")
+	FeatureListCmd.Flags().StringSliceVar(&stabilities, "stability", nil, "List only features of the stability levels. Experimental features are listed when requested.")
+	FeatureListCmd.Flags().StringVar(&sortBy, "sort-by", "name", "Sort features by name, stability, featuregate or activation")
 }
 
 // FeatureInfo is a struct that holds Feature information an if it can be listed by plugin.
@@ -77,7 +105,24 @@ func printFeatures(cmd *cobra.Command, _ []string) error {
 // is displayed or not depends on the flags passed in by the user (e.g., activated, deactivated),
 // as well as the features' discoverable settings and stability levels.
 func featureInfoList(ctx context.Context, cl *featuregateclient.FeatureGateClient, featuregate string) ([]FeatureInfo, error) {
-	clusterFeatures, err := cl.GetFeatureList(ctx)
+	stabilityLevels, err := parseStabilities(stabilities)
+	if err != nil {
+		return nil, err
+	}
+	if !isSortByField(sortBy) {
+		return nil, fmt.Errorf("unsupported sort-by field %q, must be one of %s", sortBy, strings.Join(sortByFields, ", "))
+	}
+
+	var listOpts []client.ListOption
+	if selector != "" {
+		labelSelector, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse label selector %q: %w", selector, err)
+		}
+		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: labelSelector})
+	}
+
+	clusterFeatures, err := cl.GetFeatureList(ctx, listOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -88,13 +133,103 @@ func featureInfoList(ctx context.Context, cl *featuregateclient.FeatureGateClien
 	}
 
 	featureInfos := collectFeaturesInfo(gateList.Items, clusterFeatures.Items)
+	if selector != "" {
+		// The labels of FeatureGate referenced Features that are not in cluster are unknown, so they cannot match.
+		delistFeaturesNotInCluster(featureInfos, clusterFeatures.Items)
+	}
 
-	setShowInList(featureInfos, includeExperimental, featuregate)
+	setShowInList(featureInfos, includeExperimental || stabilityLevels[corev1alpha2.Experimental], featuregate)
 
 	filteredList := featuresFilteredByFlags(featureInfos, activated, deactivated)
+	filteredList = featuresFilteredByStability(filteredList, stabilityLevels)
+	sortFeatureInfos(filteredList, sortBy)
 	return filteredList, nil
 }
 
+// parseStabilities returns the set of stability levels the user asked for, matched case insensitively.
+func parseStabilities(values []string) (map[corev1alpha2.StabilityLevel]bool, error) {
+	levels := map[corev1alpha2.StabilityLevel]bool{}
+	for _, value := range values {
+		found := false
+		for level := range corev1alpha2.StabilityPolicies {
+			if strings.EqualFold(value, string(level)) {
+				levels[level] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown stability level %q", value)
+		}
+	}
+	return levels, nil
+}
+
+func isSortByField(field string) bool {
+	for _, f := range sortByFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// delistFeaturesNotInCluster removes the FeatureGate referenced Features that are not in the list of cluster Features.
+func delistFeaturesNotInCluster(infos map[string]*FeatureInfo, features []corev1alpha2.Feature) {
+	inCluster := map[string]bool{}
+	for i := range features {
+		inCluster[features[i].Name] = true
+	}
+	for name := range infos {
+		if !inCluster[name] {
+			delete(infos, name)
+		}
+	}
+}
+
+// featuresFilteredByStability keeps the features of the given stability levels. All features are kept if no stability
+// level is given.
+func featuresFilteredByStability(infos []FeatureInfo, levels map[corev1alpha2.StabilityLevel]bool) []FeatureInfo {
+	if len(levels) == 0 {
+		return infos
+	}
+	var filteredList []FeatureInfo
+	for _, info := range infos {
+		if levels[info.Stability] {
+			filteredList = append(filteredList, info)
+		}
+	}
+	return filteredList
+}
+
+func stabilityRank(stability corev1alpha2.StabilityLevel) int {
+	if rank, ok := stabilityOrder[stability]; ok {
+		return rank
+	}
+	return len(stabilityOrder) + 1
+}
+
+// sortFeatureInfos sorts the features by the given field, and by name for features with the same value.
+func sortFeatureInfos(infos []FeatureInfo, field string) {
+	sort.SliceStable(infos, func(i, j int) bool {
+		a, b := infos[i], infos[j]
+		switch field {
+		case "stability":
+			if a.Stability != b.Stability {
+				return stabilityRank(a.Stability) < stabilityRank(b.Stability)
+			}
+		case "featuregate":
+			if a.FeatureGate != b.FeatureGate {
+				return a.FeatureGate < b.FeatureGate
+			}
+		case "activation":
+			if a.Activated != b.Activated {
+				return a.Activated
+			}
+		}
+		return a.Name < b.Name
+	})
+}
+
 // collectFeaturesInfo will create a map of features and their information from
 // FeatureGate references and features.
 func collectFeaturesInfo(gates []corev1alpha2.FeatureGate, features []corev1alpha2.Feature) map[string]*FeatureInfo {
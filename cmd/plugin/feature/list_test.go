@@ -6,6 +6,7 @@ package main
 import (
 	"context"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -274,6 +275,98 @@ func TestFeatureInfoList(t *testing.T) {
 	}
 }
 
+func TestFeatureInfoListSelectorStabilityAndSort(t *testing.T) {
+	tests := []struct {
+		description string
+		selector    string
+		stabilities []string
+		sortBy      string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			description: "list features matching the label selector, including experimental ones when selected",
+			selector:    "core.tanzu.vmware.com/feature-owner-team=eventing",
+			stabilities: []string{"experimental"},
+			sortBy:      "name",
+			want:        []string{"cloud-event-listener", "cloud-event-relayer", "cloud-event-speaker"},
+		},
+		{
+			description: "list features matching the label selector sorted by activation",
+			selector:    "core.tanzu.vmware.com/feature-component=tuning",
+			sortBy:      "activation",
+			want:        []string{"tuner", "tuna"},
+		},
+		{
+			description: "list features of a stability level sorted by FeatureGate",
+			stabilities: []string{"Technical Preview"},
+			sortBy:      "featuregate",
+			want:        []string{"tuna", "tuner", "bar", "barries"},
+		},
+		{
+			description: "list features sorted by stability",
+			selector:    "core.tanzu.vmware.com/feature-component in (cloud-events,tuning)",
+			stabilities: []string{"Experimental", "Technical Preview"},
+			sortBy:      "stability",
+			want:        []string{"cloud-event-listener", "cloud-event-relayer", "cloud-event-speaker", "tuna", "tuner"},
+		},
+		{
+			description: "fail for unknown stability level",
+			stabilities: []string{"Beta"},
+			sortBy:      "name",
+			wantErr:     true,
+		},
+		{
+			description: "fail for unsupported sort-by field",
+			sortBy:      "owner",
+			wantErr:     true,
+		},
+		{
+			description: "fail for invalid label selector",
+			selector:    "core.tanzu.vmware.com/feature-component in",
+			sortBy:      "name",
+			wantErr:     true,
+		},
+	}
+
+	objs, _, _ := fake.GetTestObjects()
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("add config scheme: (%v)", err)
+	}
+
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+	if err != nil {
+		t.Fatalf("get FeatureGate client: (%v)", err)
+	}
+
+	defer func() {
+		selector, stabilities, sortBy = "", nil, "name"
+	}()
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			// Set global variables used by Cobra.
+			featuregate, activated, deactivated, includeExperimental = "", false, false, false
+			selector, stabilities, sortBy = tc.selector, tc.stabilities, tc.sortBy
+
+			got, err := featureInfoList(context.Background(), fgClient, featuregate)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error: %v, want error: %t", err, tc.wantErr)
+			}
+
+			var names []string
+			for _, info := range got {
+				names = append(names, info.Name)
+			}
+			if !reflect.DeepEqual(names, tc.want) {
+				t.Errorf("got Features: %v, want: %v", names, tc.want)
+			}
+		})
+	}
+}
+
 func TestListExtended(t *testing.T) {
 	tests := []struct {
 		description string
@@ -33,11 +33,18 @@ func main() {
 
 	p.AddCommands(
 		FeatureListCmd,
+		FeatureGetCmd,
 		FeatureActivateCmd,
 		FeatureDeactivateCmd,
+		FeatureResetCmd,
+		FeatureWatchCmd,
+		FeatureSupportStatusCmd,
+		FeatureRequestCmd,
+		FeatureApproveCmd,
+		FeatureRejectCmd,
 	)
 
 	if err := p.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
@@ -0,0 +1,47 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+var rejectReason string
+
+// FeatureRejectCmd is for rejecting a request to change the activation of a Feature
+var FeatureRejectCmd = &cobra.Command{
+	Use:   "reject <request>",
+	Short: "Reject a feature activation request",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+	# Reject a FeatureActivationRequest, so that it is closed without changing the FeatureGate gating the Feature
+	tanzu feature reject myfeature-x7k2p --reason "Not supported in production"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		requestName := args[0]
+
+		fgClient, err := featuregateclient.NewFeatureGateClient()
+		if err != nil {
+			return fmt.Errorf("could not get FeatureGateClient: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+		defer cancel()
+
+		request, err := fgClient.RejectFeatureActivationRequest(ctx, requestName, rejectReason)
+		if err != nil {
+			return fmt.Errorf("could not reject FeatureActivationRequest %s: %w", requestName, err)
+		}
+		printDecision(cmd, request)
+		return nil
+	},
+}
+
+func init() {
+	FeatureRejectCmd.Flags().StringVar(&rejectReason, "reason", "", "Why the request is rejected")
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+)
+
+func TestPrintRejectedDecision(t *testing.T) {
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	objs, _, _ := fake.GetTestObjects()
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGate client: %v", err)
+	}
+	request, err := fgClient.CreateFeatureActivationRequest(context.Background(), "cloud-event-speaker", true, "needed")
+	if err != nil {
+		t.Fatalf("unable to create FeatureActivationRequest: %v", err)
+	}
+	got, err := fgClient.RejectFeatureActivationRequest(context.Background(), request.Name, "not now")
+	if err != nil {
+		t.Fatalf("unable to reject FeatureActivationRequest: %v", err)
+	}
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	printDecision(cmd, got)
+	want := "for Feature cloud-event-speaker to be activated is rejected."
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("got output: %q, want it to contain: %q", out.String(), want)
+	}
+	if strings.Contains(out.String(), "voided") {
+		t.Errorf("got output: %q, want it not to report voided support guarantees", out.String())
+	}
+}
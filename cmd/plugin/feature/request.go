@@ -0,0 +1,107 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+var (
+	requestDeactivate    bool
+	requestJustification string
+)
+
+// FeatureRequestCmd is for requesting the activation of a Feature to be changed once an approver approves it
+var FeatureRequestCmd = &cobra.Command{
+	Use:   "request <feature>",
+	Short: "Request a feature to be activated or deactivated once approved",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+	# Request a cluster Feature to be activated
+	tanzu feature request myfeature --justification "Needed to evaluate the new scheduler"
+
+	# Request a cluster Feature to be deactivated
+	tanzu feature request myfeature --deactivate --justification "Causes errors in production"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		featureName := args[0]
+
+		fgClient, err := featuregateclient.NewFeatureGateClient()
+		if err != nil {
+			return fmt.Errorf("could not get FeatureGateClient: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+		defer cancel()
+
+		return requestFeatureActivation(ctx, cmd, fgClient, featureName, !requestDeactivate, requestJustification)
+	},
+}
+
+func init() {
+	FeatureRequestCmd.Flags().BoolVar(&requestDeactivate, "deactivate", false, "Request the Feature to be deactivated rather than activated")
+	FeatureRequestCmd.Flags().StringVar(&requestJustification, "justification", "", "Why the change is needed, for the approver")
+	_ = FeatureRequestCmd.MarkFlagRequired("justification")
+}
+
+func requestFeatureActivation(ctx context.Context, cmd *cobra.Command, fgClient *featuregateclient.FeatureGateClient, featureName string, activate bool, justification string) error {
+	state := "deactivated"
+	if activate {
+		state = "activated"
+	}
+
+	request, err := fgClient.CreateFeatureActivationRequest(ctx, featureName, activate, justification)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("FeatureActivationRequest %s for Feature %s to be %s is waiting for approval.\n", request.Name, featureName, state)
+	return nil
+}
+
+// requireApprovalBypass returns an error when changing the activation of any of the Features requires approval, i.e.
+// when any of them is not Stable, unless bypassApproval is set. The activate and deactivate commands change the
+// FeatureGate directly, bypassing FeatureActivationRequests, so they must be asked to. Features that cannot be
+// retrieved are reported when changing the FeatureGate.
+func requireApprovalBypass(ctx context.Context, fgClient featuregateclient.FeatureGateInterface, featureNames []string, activate, bypassApproval bool) error {
+	if bypassApproval {
+		return nil
+	}
+
+	gates, err := fgClient.GetFeatureGateList(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGate List: %w", err)
+	}
+
+	var requiringApproval []string
+	for _, featureName := range featureNames {
+		feature, err := fgClient.GetFeature(ctx, featureName)
+		if err != nil {
+			continue
+		}
+		if _, featRef := featuregateclient.FeatureRefFromGateList(gates, featureName); featRef.Activate == activate {
+			continue
+		}
+		request := &corev1alpha2.FeatureActivationRequest{Spec: corev1alpha2.FeatureActivationRequestSpec{Feature: featureName, Activate: activate}}
+		if request.RequiresApproval(feature) {
+			requiringApproval = append(requiringApproval, featureName)
+		}
+	}
+	if len(requiringApproval) == 0 {
+		return nil
+	}
+
+	state := "deactivated"
+	if activate {
+		state = "activated"
+	}
+	return fmt.Errorf("changing the activation of Features %s requires approval: request them to be %s with \"tanzu feature request\", "+
+		"or give --bypass-approval to change the FeatureGate directly, which clusters enforcing approval reject", strings.Join(requiringApproval, ", "), state)
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+)
+
+func TestRequestFeatureActivation(t *testing.T) {
+	tests := []struct {
+		description string
+		featureName string
+		activate    bool
+		wantErr     error
+		wantOutput  string
+	}{
+		{
+			description: "request a technical preview feature to be activated",
+			featureName: "tuna",
+			activate:    true,
+			wantOutput:  "for Feature tuna to be activated is waiting for approval.",
+		},
+		{
+			description: "request an experimental feature to be deactivated",
+			featureName: "cloud-event-speaker",
+			wantOutput:  "for Feature cloud-event-speaker to be deactivated is waiting for approval.",
+		},
+		{
+			description: "cannot request a feature that was not found in cluster",
+			featureName: "hard-to-get",
+			activate:    true,
+			wantErr:     featuregateclient.ErrTypeNotFound,
+		},
+		{
+			description: "cannot request a feature that is gated by more than one feature gate",
+			featureName: "baz",
+			activate:    true,
+			wantErr:     featuregateclient.ErrTypeTooMany,
+		},
+	}
+
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			objs, _, _ := fake.GetTestObjects()
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+			fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate client: %v", err)
+			}
+
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			err = requestFeatureActivation(context.Background(), cmd, fgClient, tc.featureName, tc.activate, "needed")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if !strings.Contains(out.String(), tc.wantOutput) {
+				t.Errorf("got output: %q, want it to contain: %q", out.String(), tc.wantOutput)
+			}
+
+			gates, err := fgClient.GetFeatureGateList(context.Background())
+			if err != nil {
+				t.Fatalf("get FeatureGate List: %v", err)
+			}
+			_, ref := featuregateclient.FeatureRefFromGateList(gates, tc.featureName)
+			if ref.Activate {
+				t.Errorf("got Feature %s activated before the request is approved", tc.featureName)
+			}
+		})
+	}
+}
+
+func TestRequireApprovalBypass(t *testing.T) {
+	tests := []struct {
+		description    string
+		featureNames   []string
+		activate       bool
+		bypassApproval bool
+		wantErr        string
+	}{
+		{
+			description:  "activating a technical preview feature requires approval",
+			featureNames: []string{"bar", "super-toaster"},
+			activate:     true,
+			wantErr:      "changing the activation of Features bar requires approval",
+		},
+		{
+			description:    "activating a technical preview feature bypassing approval",
+			featureNames:   []string{"bar"},
+			activate:       true,
+			bypassApproval: true,
+		},
+		{
+			description:  "deactivating a feature that is already deactivated does not require approval",
+			featureNames: []string{"bar", "foo"},
+		},
+		{
+			description:  "changing a stable feature does not require approval",
+			featureNames: []string{"super-toaster"},
+		},
+		{
+			description:  "features that do not exist are left to the change to report",
+			featureNames: []string{"hard-to-get"},
+			activate:     true,
+		},
+	}
+
+	objs, _, _ := fake.GetTestObjects()
+	fgClient := fake.NewFeatureGateClient(objs...)
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := requireApprovalBypass(context.Background(), fgClient, tc.featureNames, tc.activate, tc.bypassApproval)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("got error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("got error: %v, want it to contain: %s", err, tc.wantErr)
+			}
+		})
+	}
+}
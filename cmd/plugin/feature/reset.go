@@ -0,0 +1,58 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+// FeatureResetCmd is for returning Features to the default activation of their stability level
+var FeatureResetCmd = &cobra.Command{
+	Use:   "reset <feature>",
+	Short: "Reset a feature to its default activation",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+	# Return a cluster Feature to the default activation of its stability level
+	tanzu feature reset myfeature`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		featureName := args[0]
+
+		fgClient, err := featuregateclient.NewFeatureGateClient()
+		if err != nil {
+			return fmt.Errorf("could not get FeatureGateClient: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+		defer cancel()
+
+		result, err := resetFeature(ctx, fgClient, featureName)
+		if err != nil {
+			return fmt.Errorf("could not reset Feature %s: %w", featureName, err)
+		}
+		printResetResult(cmd, result)
+		return nil
+	},
+}
+
+// printResetResult reports the default activation a Feature was reset to.
+func printResetResult(cmd *cobra.Command, result *featuregateclient.ActivationResult) {
+	state := "deactivated"
+	if result.Activate {
+		state = "activated"
+	}
+	if result.NoOp {
+		cmd.Printf("Feature %s gated by FeatureGate %s is already at its default activation: %s.\n", result.Feature, result.FeatureGate, state)
+		return
+	}
+	cmd.Printf("Feature %s gated by FeatureGate %s is reset to its default activation: %s.\n", result.Feature, result.FeatureGate, state)
+}
+
+func resetFeature(ctx context.Context, fgClient *featuregateclient.FeatureGateClient, featureName string) (*featuregateclient.ActivationResult, error) {
+	return fgClient.ResetFeature(ctx, featureName)
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+)
+
+func TestResetFeature(t *testing.T) {
+	tests := []struct {
+		description    string
+		featureName    string
+		wantErr        error
+		wantReferenced bool
+	}{
+		{
+			description: "reset a deprecated feature",
+			featureName: "biz",
+		},
+		{
+			description: "reset a technical preview feature",
+			featureName: "tuner",
+		},
+		{
+			description:    "reset an experimental feature that voided the warranty",
+			featureName:    "cloud-event-listener",
+			wantReferenced: true,
+		},
+		{
+			description:    "cannot reset a feature that is not in cluster",
+			featureName:    "hard-to-get",
+			wantErr:        featuregateclient.ErrTypeNotFound,
+			wantReferenced: true,
+		},
+		{
+			description:    "cannot reset a feature that is gated by more than one feature gate",
+			featureName:    "bazzies",
+			wantErr:        featuregateclient.ErrTypeTooMany,
+			wantReferenced: true,
+		},
+	}
+
+	objs, _, _ := fake.GetTestObjects()
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGate client: %v", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := resetFeature(context.Background(), fgClient, tc.featureName)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+
+			gates, err := fgClient.GetFeatureGateList(context.Background())
+			if err != nil {
+				t.Fatalf("get FeatureGate List: %v", err)
+			}
+			gateName, _ := featuregateclient.FeatureRefFromGateList(gates, tc.featureName)
+			if (gateName != "") != tc.wantReferenced {
+				t.Errorf("got Feature referenced by FeatureGate %q, want referenced: %t", gateName, tc.wantReferenced)
+			}
+		})
+	}
+}
+
+func TestPrintResetResult(t *testing.T) {
+	tests := []struct {
+		description string
+		result      *featuregateclient.ActivationResult
+		want        string
+	}{
+		{
+			description: "should report a Feature reset to its default activation",
+			result:      &featuregateclient.ActivationResult{FeatureGate: "tanzu-fg", Feature: "tuner", PreviousActivate: true},
+			want:        "Feature tuner gated by FeatureGate tanzu-fg is reset to its default activation: deactivated.",
+		},
+		{
+			description: "should report a Feature already at its default activation",
+			result:      &featuregateclient.ActivationResult{FeatureGate: "tkg-system", Feature: "cloud-event-listener", NoOp: true},
+			want:        "Feature cloud-event-listener gated by FeatureGate tkg-system is already at its default activation: deactivated.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			printResetResult(cmd, tc.result)
+			if !strings.Contains(out.String(), tc.want) {
+				t.Errorf("got output: %q, want it to contain: %q", out.String(), tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+var supportStatusOutputFormat string
+
+// FeatureSupportStatusCmd is for reporting the support status of the environment.
+var FeatureSupportStatusCmd = &cobra.Command{
+	Use:   "support-status",
+	Short: "Report the support status of the environment",
+	Args:  cobra.NoArgs,
+	Example: `
+	# Report whether support guarantees were permanently voided and which activated features are unsupported
+	tanzu feature support-status
+
+	# Report the support status for tooling. The verdict is Supported, UnsupportedFeaturesActivated or WarrantyVoided.
+	tanzu feature support-status -o json`,
+	RunE: featureSupportStatus,
+}
+
+func init() {
+	FeatureSupportStatusCmd.Flags().StringVarP(&supportStatusOutputFormat, "output", "o", "", "Output format (yaml|json)")
+}
+
+func featureSupportStatus(cmd *cobra.Command, _ []string) error {
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	status, err := fgClient.GetSupportStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get support status: %w", err)
+	}
+	return printSupportStatus(cmd, status, supportStatusOutputFormat)
+}
+
+// printSupportStatus renders the support status as yaml or json, or by default as a report ending with the verdict.
+func printSupportStatus(cmd *cobra.Command, status *featuregateclient.SupportStatus, format string) error {
+	switch component.OutputType(format) {
+	case component.YAMLOutputType, component.JSONOutputType:
+		component.NewObjectWriter(cmd.OutOrStdout(), format, status).Render()
+		return nil
+	case "", component.TableOutputType:
+	default:
+		return fmt.Errorf("unsupported output format %q, must be yaml or json", format)
+	}
+
+	if status.WarrantyVoided {
+		cmd.Println("All support guarantees for this environment are permanently voided by these FeatureGate references:")
+		t := component.NewOutputWriter(cmd.OutOrStdout(), string(component.TableOutputType), "FEATUREGATE", "FEATURE", "ACTIVATE")
+		for _, ref := range status.VoidingReferences {
+			t.AddRow(ref.FeatureGate, ref.Feature, ref.Activate)
+		}
+		t.Render()
+	} else {
+		cmd.Println("Support guarantees for this environment have not been voided.")
+	}
+	cmd.Println()
+
+	if len(status.UnsupportedFeatures) > 0 {
+		cmd.Println("These activated features are not supported:")
+		t := component.NewOutputWriter(cmd.OutOrStdout(), string(component.TableOutputType), "FEATURE", "STABILITY", "FEATUREGATE")
+		for _, feature := range status.UnsupportedFeatures {
+			featureGate := feature.FeatureGate
+			if featureGate == "" {
+				featureGate = "--"
+			}
+			t.AddRow(feature.Name, feature.Stability, featureGate)
+		}
+		t.Render()
+	} else {
+		cmd.Println("No unsupported feature is activated.")
+	}
+	cmd.Println()
+
+	cmd.Printf("Verdict: %s\n", status.Verdict)
+	return nil
+}
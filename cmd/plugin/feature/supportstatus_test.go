@@ -0,0 +1,92 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+func TestPrintSupportStatus(t *testing.T) {
+	voided := &featuregateclient.SupportStatus{
+		Verdict:        featuregateclient.SupportVerdictWarrantyVoided,
+		WarrantyVoided: true,
+		VoidingReferences: []featuregateclient.VoidingReference{
+			{FeatureGate: "tkg-system", Feature: "cloud-event-listener", Activate: true},
+		},
+		UnsupportedFeatures: []featuregateclient.UnsupportedFeature{
+			{Name: "tuner", Stability: corev1alpha2.TechnicalPreview, FeatureGate: "tanzu-fg"},
+		},
+	}
+	supported := &featuregateclient.SupportStatus{
+		Verdict:             featuregateclient.SupportVerdictSupported,
+		VoidingReferences:   []featuregateclient.VoidingReference{},
+		UnsupportedFeatures: []featuregateclient.UnsupportedFeature{},
+	}
+
+	tests := []struct {
+		description string
+		status      *featuregateclient.SupportStatus
+		format      string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			description: "report voided warranty and unsupported features",
+			status:      voided,
+			want: []string{
+				"permanently voided by these FeatureGate references",
+				"cloud-event-listener",
+				"tuner",
+				"Technical Preview",
+				"Verdict: WarrantyVoided",
+			},
+		},
+		{
+			description: "report supported environment",
+			status:      supported,
+			want: []string{
+				"have not been voided",
+				"No unsupported feature is activated.",
+				"Verdict: Supported",
+			},
+		},
+		{
+			description: "report verdict as json",
+			status:      voided,
+			format:      "json",
+			want:        []string{`"verdict": "WarrantyVoided"`, `"warrantyVoided": true`},
+		},
+		{
+			description: "fail for unsupported format",
+			status:      voided,
+			format:      "xml",
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+
+			err := printSupportStatus(cmd, tc.status, tc.format)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error: %v, want error: %t", err, tc.wantErr)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("got output:\n%s\nwant it to contain: %s", out.String(), want)
+				}
+			}
+		})
+	}
+}
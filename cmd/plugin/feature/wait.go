@@ -0,0 +1,34 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+// defaultWaitTimeout is how long activate and deactivate wait for Features with --wait, unless --timeout is set.
+const defaultWaitTimeout = 5 * time.Minute
+
+// waitForFeatures waits until every Feature is in the activation state in the cluster, for at most timeout in total.
+func waitForFeatures(cmd *cobra.Command, fgClient *featuregateclient.FeatureGateClient, featureNames []string, activated bool, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	state := "deactivated"
+	if activated {
+		state = "activated"
+	}
+	for _, featureName := range featureNames {
+		if err := fgClient.WaitForFeatureState(ctx, featureName, activated); err != nil {
+			return err
+		}
+		cmd.Printf("Feature %s is %s in the cluster.\n", featureName, state)
+	}
+	return nil
+}
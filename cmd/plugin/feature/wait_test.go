@@ -0,0 +1,83 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+)
+
+func TestWaitForFeatures(t *testing.T) {
+	tests := []struct {
+		description  string
+		featureNames []string
+		wantErr      error
+		wantOut      string
+	}{
+		{
+			description:  "should report Features activated in the cluster",
+			featureNames: []string{"tuner", "bazzies"},
+			wantOut:      "Feature tuner is activated in the cluster.\nFeature bazzies is activated in the cluster.\n",
+		},
+		{
+			description:  "should time out waiting for a Feature that is not reconciled",
+			featureNames: []string{"tuner", "barries"},
+			wantErr:      context.DeadlineExceeded,
+			wantOut:      "Feature tuner is activated in the cluster.\n",
+		},
+	}
+
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			objs, _, _ := fake.GetTestObjects()
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+			fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate client: %v", err)
+			}
+
+			// Reconcile tuner and bazzies in tanzu-fg, as the Feature reconciler does.
+			gate := &corev1alpha2.FeatureGate{}
+			if err := cl.Get(context.Background(), client.ObjectKey{Name: "tanzu-fg"}, gate); err != nil {
+				t.Fatalf("unable to get FeatureGate: %v", err)
+			}
+			gate.Status.FeatureReferenceResults = []corev1alpha2.FeatureReferenceResult{
+				{Name: "tuner", Status: corev1alpha2.AppliedReferenceStatus},
+				{Name: "bazzies", Status: corev1alpha2.AppliedReferenceStatus},
+			}
+			if err := cl.Update(context.Background(), gate); err != nil {
+				t.Fatalf("unable to update FeatureGate: %v", err)
+			}
+
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			err = waitForFeatures(cmd, fgClient, tc.featureNames, true, 200*time.Millisecond)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if !strings.Contains(out.String(), tc.wantOut) {
+				t.Errorf("got output: %q, want: %q", out.String(), tc.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+var watchOutputFormat string
+
+// featureEventRowFormat has fixed column widths, so that rows printed as events arrive line up.
+const featureEventRowFormat = "%-20s  %-14s  %-32s  %-20s  %s\n"
+
+// FeatureWatchCmd is for streaming changes to the state of Features
+var FeatureWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch features",
+	Args:  cobra.NoArgs,
+	Example: `
+	# Stream activation changes, invalid FeatureGate references, new features and voided warranties.
+	tanzu feature watch
+
+	# Stream the changes as JSON lines.
+	tanzu feature watch -o json`,
+	RunE: featureWatch,
+}
+
+func init() {
+	FeatureWatchCmd.Flags().StringVarP(&watchOutputFormat, "output", "o", "", "Output format (table|json)")
+}
+
+func featureWatch(cmd *cobra.Command, _ []string) error {
+	if watchOutputFormat != "" && watchOutputFormat != "table" && watchOutputFormat != "json" {
+		return fmt.Errorf("unsupported output format %q, must be table or json", watchOutputFormat)
+	}
+
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	events, err := fgClient.WatchFeatureEvents(ctx)
+	if err != nil {
+		return fmt.Errorf("could not watch Features: %w", err)
+	}
+
+	if err := printFeatureEvents(cmd.OutOrStdout(), events, watchOutputFormat); err != nil {
+		return err
+	}
+	if ctx.Err() == nil {
+		return fmt.Errorf("could not watch Features: the watch was closed by the cluster")
+	}
+	return nil
+}
+
+// printFeatureEvents writes every event as it is received, either as a table row or as a JSON line, until the
+// channel is closed.
+func printFeatureEvents(out io.Writer, events <-chan featuregateclient.FeatureEvent, format string) error {
+	if format == "json" {
+		encoder := json.NewEncoder(out)
+		for event := range events {
+			if err := encoder.Encode(event); err != nil {
+				return fmt.Errorf("could not write Feature event: %w", err)
+			}
+		}
+		return nil
+	}
+
+	fmt.Fprintf(out, featureEventRowFormat, "TIME", "EVENT", "FEATURE", "FEATUREGATE", "MESSAGE")
+	for event := range events {
+		feature := event.Feature
+		if feature == "" {
+			feature = "--"
+		}
+		featureGate := event.FeatureGate
+		if featureGate == "" {
+			featureGate = "--"
+		}
+		if _, err := fmt.Fprintf(out, featureEventRowFormat, event.Time.Format(time.RFC3339), event.Type, feature,
+			featureGate, event.Message); err != nil {
+			return fmt.Errorf("could not write Feature event: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+func TestPrintFeatureEvents(t *testing.T) {
+	eventTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		description string
+		format      string
+		want        []string
+	}{
+		{
+			description: "print events as table rows",
+			want: []string{
+				"TIME",
+				"2023-01-02T03:04:05Z  Activated       tuna ",
+				"tkg-system            Feature bar not found",
+				"Error           --",
+			},
+		},
+		{
+			description: "print events as JSON lines",
+			format:      "json",
+			want: []string{
+				`{"time":"2023-01-02T03:04:05Z","type":"Activated","feature":"tuna"}`,
+				`{"time":"2023-01-02T03:04:05Z","type":"Invalid","feature":"bar","featureGate":"tkg-system","message":"Feature bar not found"}`,
+				`{"time":"2023-01-02T03:04:05Z","type":"Error","feature":"","message":"etcd unavailable"}`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			events := make(chan featuregateclient.FeatureEvent, 3)
+			events <- featuregateclient.FeatureEvent{Time: eventTime, Type: featuregateclient.FeatureActivated, Feature: "tuna"}
+			events <- featuregateclient.FeatureEvent{Time: eventTime, Type: featuregateclient.FeatureReferenceInvalid,
+				Feature: "bar", FeatureGate: "tkg-system", Message: "Feature bar not found"}
+			events <- featuregateclient.FeatureEvent{Time: eventTime, Type: featuregateclient.FeatureWatchError, Message: "etcd unavailable"}
+			close(events)
+
+			var out bytes.Buffer
+			if err := printFeatureEvents(&out, events, tc.format); err != nil {
+				t.Fatalf("error not expected, but got error: %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("got output:\n%s\nwant it to contain: %s", out.String(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+var (
+	addActivated              bool
+	userAllowsVoidingWarranty bool
+)
+
+// FeatureGateAddFeatureCmd is for adding Feature references to FeatureGates.
+var FeatureGateAddFeatureCmd = &cobra.Command{
+	Use:   "add-feature <featuregate> <feature>",
+	Short: "Gate a feature by a featuregate",
+	Args:  cobra.ExactArgs(2),
+	Example: `
+	# Gate a Feature by a FeatureGate, keeping the default activation of its stability level
+	tanzu featuregate add-feature myfeaturegate myfeature
+
+	# Gate a Feature by a FeatureGate and set it to be activated
+	tanzu featuregate add-feature myfeaturegate myfeature --activate`,
+	RunE: featureGateAddFeature,
+}
+
+func init() {
+	FeatureGateAddFeatureCmd.Flags().BoolVar(&addActivated, "activate", false, "Set the Feature to be activated. By default, the Feature keeps the default activation of its stability level.")
+	FeatureGateAddFeatureCmd.Flags().BoolVar(&userAllowsVoidingWarranty, "permanentlyVoidAllSupportGuarantees", false, "Allow for the permanent voiding of all support guarantees for this environment. For some features, e.g. experimental features, if a user sets the activation status to one that does not match the default activation, all support guarantees for this environment will be permanently voided.")
+}
+
+func featureGateAddFeature(cmd *cobra.Command, args []string) error {
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	var userAllows *bool
+	if cmd.Flags().Changed("permanentlyVoidAllSupportGuarantees") {
+		userAllows = &userAllowsVoidingWarranty
+	}
+
+	gateName, featureName := args[0], args[1]
+	if err := addFeature(ctx, cmd, fgClient, gateName, featureName, cmd.Flags().Changed("activate"), userAllows); err != nil {
+		return fmt.Errorf("could not add Feature %s to FeatureGate %s: %w", featureName, gateName, err)
+	}
+
+	cmd.Printf("Feature %s is gated by FeatureGate %s.\n", featureName, gateName)
+	return nil
+}
+
+// addFeature adds the Feature to the FeatureGate. Unless the activation is set by flag, the Feature keeps the default
+// activation of its stability level. The user is asked for permission if the activation voids the warranty.
+func addFeature(ctx context.Context, cmd *cobra.Command, fgClient *featuregateclient.FeatureGateClient, gateName, featureName string, activationSet bool, userAllows *bool) error {
+	feature, err := fgClient.GetFeature(ctx, featureName)
+	if err != nil {
+		return fmt.Errorf("could not get Feature %s: %w", featureName, err)
+	}
+
+	policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability)
+	activate := policy.DefaultActivation
+	if activationSet {
+		activate = addActivated
+	}
+
+	var proceedWithVoidingWarranty bool
+	if policy.VoidsWarranty && activate != policy.DefaultActivation {
+		proceedWithVoidingWarranty, err = featuregateclient.UserGivesPermissionToVoidWarranty(os.Stdin, cmd.OutOrStdout(), feature, userAllows)
+		if err != nil {
+			return fmt.Errorf("could not get user permission to void warranty for Feature %s: %w", featureName, err)
+		}
+	}
+
+	return fgClient.AddFeatureReference(ctx, gateName, featureName, activate, proceedWithVoidingWarranty)
+}
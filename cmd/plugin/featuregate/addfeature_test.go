@@ -0,0 +1,102 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+)
+
+func TestAddFeature(t *testing.T) {
+	allow, disallow := true, false
+	tests := []struct {
+		description   string
+		feature       string
+		activationSet bool
+		activate      bool
+		userAllows    *bool
+		wantErr       error
+		wantRef       corev1alpha2.FeatureReference
+	}{
+		{
+			description: "keep the default activation",
+			feature:     "specialized-toaster",
+			wantRef:     corev1alpha2.FeatureReference{Name: "specialized-toaster", Activate: true},
+		},
+		{
+			description:   "void the warranty when the user allows it",
+			feature:       "ungated-experiment",
+			activationSet: true,
+			activate:      true,
+			userAllows:    &allow,
+			wantRef:       corev1alpha2.FeatureReference{Name: "ungated-experiment", Activate: true, PermanentlyVoidAllSupportGuarantees: true},
+		},
+		{
+			description:   "fail when the user does not allow voiding the warranty",
+			feature:       "ungated-experiment",
+			activationSet: true,
+			activate:      true,
+			userAllows:    &disallow,
+			wantErr:       featuregateclient.ErrTypeForbidden,
+		},
+		{
+			description: "fail for Feature gated by another FeatureGate",
+			feature:     "tuna",
+			wantErr:     featuregateclient.ErrTypeTooMany,
+		},
+	}
+
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			objs, features, _ := fake.GetTestObjects()
+			objs = append(objs, features["specialized-toaster"], &corev1alpha2.Feature{
+				ObjectMeta: metav1.ObjectMeta{Name: "ungated-experiment"},
+				Spec:       corev1alpha2.FeatureSpec{Stability: corev1alpha2.Experimental},
+			})
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+			fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate client: %v", err)
+			}
+
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			addActivated = tc.activate
+
+			err = addFeature(ctx, cmd, fgClient, "empty-fg", tc.feature, tc.activationSet, tc.userAllows)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			gate, err := fgClient.GetFeatureGate(ctx, "empty-fg")
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate: %v", err)
+			}
+			if len(gate.Spec.Features) != 1 || gate.Spec.Features[0] != tc.wantRef {
+				t.Errorf("got references: %+v, want: %+v", gate.Spec.Features, tc.wantRef)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+// FeatureGateCreateCmd is for creating FeatureGates.
+var FeatureGateCreateCmd = &cobra.Command{
+	Use:   "create <featuregate>",
+	Short: "Create a featuregate",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+	# Create a FeatureGate that does not gate any Feature yet
+	tanzu featuregate create myfeaturegate`,
+	RunE: featureGateCreate,
+}
+
+func featureGateCreate(cmd *cobra.Command, args []string) error {
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	gateName := args[0]
+	if err := fgClient.CreateFeatureGate(ctx, gateName); err != nil {
+		return err
+	}
+
+	cmd.Printf("FeatureGate %s is created.\n", gateName)
+	return nil
+}
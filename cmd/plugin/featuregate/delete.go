@@ -0,0 +1,42 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+// FeatureGateDeleteCmd is for deleting FeatureGates.
+var FeatureGateDeleteCmd = &cobra.Command{
+	Use:   "delete <featuregate>",
+	Short: "Delete a featuregate",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+	# Delete a FeatureGate. The Features it gates return to the default activation of their stability level.
+	tanzu featuregate delete myfeaturegate`,
+	RunE: featureGateDelete,
+}
+
+func featureGateDelete(cmd *cobra.Command, args []string) error {
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	gateName := args[0]
+	if err := fgClient.DeleteFeatureGate(ctx, gateName); err != nil {
+		return err
+	}
+
+	cmd.Printf("FeatureGate %s is deleted.\n", gateName)
+	return nil
+}
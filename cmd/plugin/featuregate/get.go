@@ -0,0 +1,125 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+var getOutputFormat string
+
+// FeatureGateGetCmd is for describing a FeatureGate.
+var FeatureGateGetCmd = &cobra.Command{
+	Use:   "get <featuregate>",
+	Short: "Describe a featuregate",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+	# Describe the Feature references of a FeatureGate and the results of applying them.
+	tanzu featuregate get tkg-system
+	tanzu featuregate get tkg-system -o yaml`,
+	RunE: featureGateGet,
+}
+
+func init() {
+	FeatureGateGetCmd.Flags().StringVarP(&getOutputFormat, "output", "o", "", "Output format (yaml|json)")
+}
+
+func featureGateGet(cmd *cobra.Command, args []string) error {
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	gateName := args[0]
+	gate, err := fgClient.GetFeatureGate(ctx, gateName)
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGate %s: %w", gateName, err)
+	}
+	return printFeatureGate(cmd, gate, getOutputFormat)
+}
+
+// FeatureGateDetails holds the Feature references of a FeatureGate and the results of applying them.
+type FeatureGateDetails struct {
+	Name     string                    `json:"name" yaml:"name"`
+	Features []FeatureReferenceDetails `json:"features" yaml:"features"`
+}
+
+// FeatureReferenceDetails is the activation intent for a Feature in a FeatureGate and the result of applying it.
+type FeatureReferenceDetails struct {
+	Name                                string `json:"name" yaml:"name"`
+	Activate                            bool   `json:"activate" yaml:"activate"`
+	PermanentlyVoidAllSupportGuarantees bool   `json:"permanentlyVoidAllSupportGuarantees" yaml:"permanentlyVoidAllSupportGuarantees"`
+	// InSpec is false when only the FeatureGate status reports a result for the Feature, e.g. after its reference
+	// was removed.
+	InSpec  bool                                `json:"inSpec" yaml:"inSpec"`
+	Status  corev1alpha2.FeatureReferenceStatus `json:"status,omitempty" yaml:"status,omitempty"`
+	Message string                              `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// featureGateDetails matches the Feature references of the FeatureGate with the results of applying them.
+func featureGateDetails(gate *corev1alpha2.FeatureGate) *FeatureGateDetails {
+	details := &FeatureGateDetails{Name: gate.Name, Features: []FeatureReferenceDetails{}}
+	refIndex := map[string]int{}
+	for _, ref := range gate.Spec.Features {
+		refIndex[ref.Name] = len(details.Features)
+		details.Features = append(details.Features, FeatureReferenceDetails{
+			Name:                                ref.Name,
+			Activate:                            ref.Activate,
+			PermanentlyVoidAllSupportGuarantees: ref.PermanentlyVoidAllSupportGuarantees,
+			InSpec:                              true,
+		})
+	}
+	for _, result := range gate.Status.FeatureReferenceResults {
+		i, ok := refIndex[result.Name]
+		if !ok {
+			i = len(details.Features)
+			details.Features = append(details.Features, FeatureReferenceDetails{Name: result.Name})
+		}
+		details.Features[i].Status = result.Status
+		details.Features[i].Message = result.Message
+	}
+	return details
+}
+
+// printFeatureGate renders the FeatureGate details as yaml or json, or by default as a table with a row for every
+// Feature reference and the result of applying it.
+func printFeatureGate(cmd *cobra.Command, gate *corev1alpha2.FeatureGate, format string) error {
+	details := featureGateDetails(gate)
+	switch component.OutputType(format) {
+	case component.YAMLOutputType, component.JSONOutputType:
+		component.NewObjectWriter(cmd.OutOrStdout(), format, details).Render()
+		return nil
+	case "", component.TableOutputType:
+	default:
+		return fmt.Errorf("unsupported output format %q, must be yaml or json", format)
+	}
+
+	unknown := "--"
+	t := component.NewOutputWriter(cmd.OutOrStdout(), string(component.TableOutputType),
+		"FEATURE", "ACTIVATE", "PERMANENTLY VOID ALL SUPPORT GUARANTEES", "STATUS", "MESSAGE")
+	for _, ref := range details.Features {
+		row := []interface{}{ref.Name, unknown, unknown, unknown, unknown}
+		if ref.InSpec {
+			row[1], row[2] = ref.Activate, ref.PermanentlyVoidAllSupportGuarantees
+		}
+		if ref.Status != "" {
+			row[3], row[4] = ref.Status, ref.Message
+		}
+		t.AddRow(row...)
+	}
+
+	cmd.Printf("FeatureGate %s gates %d Features.\n\n", gate.Name, len(gate.Spec.Features))
+	t.Render()
+	return nil
+}
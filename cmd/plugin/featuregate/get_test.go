@@ -0,0 +1,72 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+)
+
+func TestPrintFeatureGate(t *testing.T) {
+	_, _, gates := fake.GetTestObjects()
+	gate := gates["tanzu-fg"].DeepCopy()
+	gate.Status.FeatureReferenceResults = []corev1alpha2.FeatureReferenceResult{
+		{Name: "tuna", Status: corev1alpha2.AppliedReferenceStatus},
+		{Name: "baz", Status: corev1alpha2.InvalidReferenceStatus, Message: "gated by more than one FeatureGate"},
+		{Name: "removed-feature", Status: corev1alpha2.AppliedReferenceStatus},
+	}
+
+	tests := []struct {
+		description string
+		format      string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			description: "render references and results as a table",
+			want: []string{
+				"FeatureGate tanzu-fg gates 4 Features.",
+				"PERMANENTLY VOID ALL SUPPORT GUARANTEES",
+				"gated by more than one FeatureGate",
+				"removed-feature",
+				"Applied",
+				"Invalid",
+			},
+		},
+		{
+			description: "render the FeatureGate as yaml",
+			format:      "yaml",
+			want:        []string{"name: tanzu-fg", "inSpec: false", "message: gated by more than one FeatureGate"},
+		},
+		{
+			description: "fail for unsupported format",
+			format:      "xml",
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+
+			err := printFeatureGate(cmd, gate, tc.format)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error: %v, want error: %t", err, tc.wantErr)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("got output:\n%s\nwant it to contain: %s", out.String(), want)
+				}
+			}
+		})
+	}
+}
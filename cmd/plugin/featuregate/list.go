@@ -0,0 +1,82 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+)
+
+var listOutputFormat string
+
+// FeatureGateListCmd is for listing FeatureGates.
+var FeatureGateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List featuregates",
+	Args:  cobra.NoArgs,
+	Example: `
+	# List the FeatureGates in the cluster and how many Features they gate.
+	tanzu featuregate list`,
+	RunE: featureGateList,
+}
+
+func init() {
+	FeatureGateListCmd.Flags().StringVarP(&listOutputFormat, "output", "o", "", "Output format (yaml|json|table)")
+}
+
+// FeatureGateInfo summarizes the Feature references of a FeatureGate.
+type FeatureGateInfo struct {
+	Name      string `json:"name" yaml:"name"`
+	Features  int    `json:"features" yaml:"features"`
+	Activated int    `json:"activated" yaml:"activated"`
+	Invalid   int    `json:"invalid" yaml:"invalid"`
+}
+
+func featureGateList(cmd *cobra.Command, _ []string) error {
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	gates, err := fgClient.GetFeatureGateList(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGates: %w", err)
+	}
+
+	t := component.NewOutputWriter(cmd.OutOrStdout(), listOutputFormat, "NAME", "FEATURES", "ACTIVATED", "INVALID")
+	for _, info := range featureGateInfos(gates) {
+		t.AddRow(info.Name, info.Features, info.Activated, info.Invalid)
+	}
+	t.Render()
+	return nil
+}
+
+// featureGateInfos summarizes every FeatureGate in the list.
+func featureGateInfos(gates *corev1alpha2.FeatureGateList) []FeatureGateInfo {
+	infos := make([]FeatureGateInfo, 0, len(gates.Items))
+	for i := range gates.Items {
+		info := FeatureGateInfo{Name: gates.Items[i].Name, Features: len(gates.Items[i].Spec.Features)}
+		for _, ref := range gates.Items[i].Spec.Features {
+			if ref.Activate {
+				info.Activated++
+			}
+		}
+		for _, result := range gates.Items[i].Status.FeatureReferenceResults {
+			if result.Status == corev1alpha2.InvalidReferenceStatus {
+				info.Invalid++
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
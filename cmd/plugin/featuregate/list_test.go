@@ -0,0 +1,30 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+)
+
+func TestFeatureGateInfos(t *testing.T) {
+	_, _, gates := fake.GetTestObjects()
+	tanzuFG := gates["tanzu-fg"].DeepCopy()
+	tanzuFG.Status.FeatureReferenceResults = []corev1alpha2.FeatureReferenceResult{
+		{Name: "tuna", Status: corev1alpha2.AppliedReferenceStatus},
+		{Name: "baz", Status: corev1alpha2.InvalidReferenceStatus, Message: "Feature baz is gated by more than one FeatureGate"},
+	}
+	list := &corev1alpha2.FeatureGateList{Items: []corev1alpha2.FeatureGate{*gates["empty-fg"], *tanzuFG}}
+
+	want := []FeatureGateInfo{
+		{Name: "empty-fg"},
+		{Name: "tanzu-fg", Features: 4, Activated: 2, Invalid: 1},
+	}
+	if got := featureGateInfos(list); !reflect.DeepEqual(got, want) {
+		t.Errorf("got FeatureGate infos: %+v, want: %+v", got, want)
+	}
+}
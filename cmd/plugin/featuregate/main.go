@@ -0,0 +1,46 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/aunum/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	plugintypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+)
+
+var descriptor = plugin.PluginDescriptor{
+	Name:        "featuregate",
+	Description: "Operate on featuregates",
+	Target:      plugintypes.TargetK8s,
+	Version:     buildinfo.Version,
+	Group:       plugin.RunCmdGroup,
+	BuildSHA:    buildinfo.SHA,
+}
+
+const contextTimeout = 300 * time.Second
+
+func main() {
+	p, err := plugin.NewPlugin(&descriptor)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p.AddCommands(
+		FeatureGateListCmd,
+		FeatureGateGetCmd,
+		FeatureGateCreateCmd,
+		FeatureGateDeleteCmd,
+		FeatureGateAddFeatureCmd,
+		FeatureGateRemoveFeatureCmd,
+	)
+
+	if err := p.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+// FeatureGateRemoveFeatureCmd is for removing Feature references from FeatureGates.
+var FeatureGateRemoveFeatureCmd = &cobra.Command{
+	Use:   "remove-feature <featuregate> <feature>",
+	Short: "Stop gating a feature by a featuregate",
+	Args:  cobra.ExactArgs(2),
+	Example: `
+	# Stop gating a Feature. It returns to the default activation of its stability level.
+	tanzu featuregate remove-feature myfeaturegate myfeature`,
+	RunE: featureGateRemoveFeature,
+}
+
+func featureGateRemoveFeature(cmd *cobra.Command, args []string) error {
+	fgClient, err := featuregateclient.NewFeatureGateClient()
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	gateName, featureName := args[0], args[1]
+	if err := fgClient.RemoveFeatureReference(ctx, gateName, featureName); err != nil {
+		return err
+	}
+
+	cmd.Printf("Feature %s is no longer gated by FeatureGate %s.\n", featureName, gateName)
+	return nil
+}
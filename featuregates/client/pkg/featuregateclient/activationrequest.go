@@ -0,0 +1,129 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// CreateFeatureActivationRequest requests the activation of a Feature to be set, with a justification. The FeatureGate
+// gating the Feature is only changed once an approver approves the request. The Feature is validated like for
+// ActivateFeature and DeactivateFeature, so that a change that cannot be made is not requested.
+func (f *FeatureGateClient) CreateFeatureActivationRequest(ctx context.Context, featureName string, activate bool, justification string) (*corev1alpha2.FeatureActivationRequest, error) {
+	feature, err := f.GetFeature(ctx, featureName)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Feature %s: %w", featureName, err)
+	}
+
+	gates, err := f.GetFeatureGateList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureGateList: %w", err)
+	}
+
+	if err := validateFeatureActivationToggle(gates, feature); err != nil {
+		return nil, fmt.Errorf("could not request Feature %s to be %s: %w", featureName, activationState(activate), err)
+	}
+
+	request := &corev1alpha2.FeatureActivationRequest{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: featureName + "-"},
+		Spec: corev1alpha2.FeatureActivationRequestSpec{
+			Feature:       featureName,
+			Activate:      activate,
+			Justification: justification,
+		},
+	}
+	if err := f.crClient.Create(ctx, request); err != nil {
+		return nil, fmt.Errorf("could not create FeatureActivationRequest for Feature %s: %w", featureName, err)
+	}
+	return request, nil
+}
+
+// GetFeatureActivationRequest fetches the specified FeatureActivationRequest resource.
+func (f *FeatureGateClient) GetFeatureActivationRequest(ctx context.Context, requestName string) (*corev1alpha2.FeatureActivationRequest, error) {
+	request := &corev1alpha2.FeatureActivationRequest{}
+	err := f.crClient.Get(ctx, client.ObjectKey{Name: requestName}, request)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &NotFoundError{Kind: "FeatureActivationRequest", Name: requestName}
+		}
+		return nil, err
+	}
+	return request, nil
+}
+
+// ApproveFeatureActivationRequest approves a FeatureActivationRequest, so that the FeatureGate gating the Feature is
+// changed as requested.
+// Warning: Before sending `true` via the warrantyVoidAllowed function argument, ensure explicit approver awareness
+// and approval if the requested change will cause the support warranty to be void. Once warranty is void, it is
+// permanent for the environment.
+func (f *FeatureGateClient) ApproveFeatureActivationRequest(ctx context.Context, requestName, reason string, warrantyVoidAllowed bool) (*corev1alpha2.FeatureActivationRequest, error) {
+	return f.decideFeatureActivationRequest(ctx, requestName, func(request *corev1alpha2.FeatureActivationRequest) (*corev1alpha2.FeatureActivationDecision, error) {
+		feature, err := f.GetFeature(ctx, request.Spec.Feature)
+		if err != nil {
+			return nil, fmt.Errorf("could not get Feature %s: %w", request.Spec.Feature, err)
+		}
+
+		voidsWarranty := request.VoidsWarranty(feature)
+		if voidsWarranty && !warrantyVoidAllowed {
+			gates, err := f.GetFeatureGateList(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("could not get FeatureGateList: %w", err)
+			}
+			gateName, _ := FeatureRefFromGateList(gates, feature.Name)
+			return nil, &WarrantyConsentError{
+				Feature:     feature.Name,
+				FeatureGate: gateName,
+				Stability:   feature.Spec.Stability,
+				Policy:      corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability),
+			}
+		}
+
+		return &corev1alpha2.FeatureActivationDecision{
+			Type:                                corev1alpha2.FeatureActivationApproved,
+			Reason:                              reason,
+			PermanentlyVoidAllSupportGuarantees: voidsWarranty,
+		}, nil
+	})
+}
+
+// RejectFeatureActivationRequest rejects a FeatureActivationRequest, so that it is closed without changing the
+// FeatureGate gating the Feature.
+func (f *FeatureGateClient) RejectFeatureActivationRequest(ctx context.Context, requestName, reason string) (*corev1alpha2.FeatureActivationRequest, error) {
+	return f.decideFeatureActivationRequest(ctx, requestName, func(*corev1alpha2.FeatureActivationRequest) (*corev1alpha2.FeatureActivationDecision, error) {
+		return &corev1alpha2.FeatureActivationDecision{Type: corev1alpha2.FeatureActivationRejected, Reason: reason}, nil
+	})
+}
+
+// decideFeatureActivationRequest sets the decision returned by decide on a FeatureActivationRequest that has not been
+// decided on yet. The decision is sent as a merge patch carrying the resourceVersion that was read, so that it fails
+// if another approver decides concurrently.
+func (f *FeatureGateClient) decideFeatureActivationRequest(ctx context.Context, requestName string, decide func(*corev1alpha2.FeatureActivationRequest) (*corev1alpha2.FeatureActivationDecision, error)) (*corev1alpha2.FeatureActivationRequest, error) {
+	request, err := f.GetFeatureActivationRequest(ctx, requestName)
+	if err != nil {
+		return nil, err
+	}
+	if request.Spec.Decision != nil {
+		return nil, fmt.Errorf("could not decide on FeatureActivationRequest %s as it is already %s by %s: %w",
+			requestName, request.Spec.Decision.Type, request.Spec.Decision.Approver, ErrTypeForbidden)
+	}
+
+	decision, err := decide(request)
+	if err != nil {
+		return nil, err
+	}
+
+	original := request.DeepCopy()
+	request.Spec.Decision = decision
+	if err := f.crClient.Patch(ctx, request, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		return nil, fmt.Errorf("could not set decision on FeatureActivationRequest %s: %w", requestName, err)
+	}
+	return request, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/internal/testobjects"
+)
+
+func TestCreateFeatureActivationRequest(t *testing.T) {
+	tests := []struct {
+		description string
+		featureName string
+		activate    bool
+		wantErr     error
+	}{
+		{
+			description: "should request to activate a Feature",
+			featureName: "experiment",
+			activate:    true,
+		},
+		{
+			description: "should not request to deactivate an immutable Feature",
+			featureName: "stable",
+			wantErr:     ErrTypeForbidden,
+		},
+		{
+			description: "should not request to activate a Feature that is not gated",
+			featureName: "ungated",
+			activate:    true,
+			wantErr:     ErrTypeNotFound,
+		},
+		{
+			description: "should not request to activate a Feature that does not exist",
+			featureName: "missing",
+			activate:    true,
+			wantErr:     ErrTypeNotFound,
+		},
+	}
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+			defer cancel()
+
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(testobjects.Policies()...).Build()
+			featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+			}
+
+			request, err := featureGateClient.CreateFeatureActivationRequest(ctx, tc.featureName, tc.activate, "needed")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+
+			got, err := featureGateClient.GetFeatureActivationRequest(ctx, request.Name)
+			if err != nil {
+				t.Fatalf("unable to get FeatureActivationRequest: %v", err)
+			}
+			if !strings.HasPrefix(got.Name, tc.featureName+"-") || got.Spec.Feature != tc.featureName ||
+				got.Spec.Activate != tc.activate || got.Spec.Justification != "needed" || got.Spec.Decision != nil {
+				t.Errorf("got FeatureActivationRequest: %+v", got)
+			}
+		})
+	}
+}
+
+func TestDecideFeatureActivationRequest(t *testing.T) {
+	tests := []struct {
+		description        string
+		featureName        string
+		decide             func(context.Context, *FeatureGateClient, string) (*corev1alpha2.FeatureActivationRequest, error)
+		wantConsentErr     bool
+		wantDecision       corev1alpha2.FeatureActivationDecisionType
+		wantWarrantyVoided bool
+	}{
+		{
+			description: "should approve a request",
+			featureName: "preview",
+			decide: func(ctx context.Context, c *FeatureGateClient, name string) (*corev1alpha2.FeatureActivationRequest, error) {
+				return c.ApproveFeatureActivationRequest(ctx, name, "looks good", false)
+			},
+			wantDecision: corev1alpha2.FeatureActivationApproved,
+		},
+		{
+			description: "should approve a request voiding the warranty with the acknowledgement of the approver",
+			featureName: "experiment",
+			decide: func(ctx context.Context, c *FeatureGateClient, name string) (*corev1alpha2.FeatureActivationRequest, error) {
+				return c.ApproveFeatureActivationRequest(ctx, name, "", true)
+			},
+			wantDecision:       corev1alpha2.FeatureActivationApproved,
+			wantWarrantyVoided: true,
+		},
+		{
+			description: "should not approve a request voiding the warranty without the acknowledgement of the approver",
+			featureName: "experiment",
+			decide: func(ctx context.Context, c *FeatureGateClient, name string) (*corev1alpha2.FeatureActivationRequest, error) {
+				return c.ApproveFeatureActivationRequest(ctx, name, "", false)
+			},
+			wantConsentErr: true,
+		},
+		{
+			description: "should not void the warranty approving a request that does not void it",
+			featureName: "preview",
+			decide: func(ctx context.Context, c *FeatureGateClient, name string) (*corev1alpha2.FeatureActivationRequest, error) {
+				return c.ApproveFeatureActivationRequest(ctx, name, "", true)
+			},
+			wantDecision: corev1alpha2.FeatureActivationApproved,
+		},
+		{
+			description: "should reject a request",
+			featureName: "experiment",
+			decide: func(ctx context.Context, c *FeatureGateClient, name string) (*corev1alpha2.FeatureActivationRequest, error) {
+				return c.RejectFeatureActivationRequest(ctx, name, "not now")
+			},
+			wantDecision: corev1alpha2.FeatureActivationRejected,
+		},
+	}
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+			defer cancel()
+
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(testobjects.Policies()...).Build()
+			featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+			}
+			request, err := featureGateClient.CreateFeatureActivationRequest(ctx, tc.featureName, true, "needed")
+			if err != nil {
+				t.Fatalf("unable to create FeatureActivationRequest: %v", err)
+			}
+
+			_, err = tc.decide(ctx, featureGateClient, request.Name)
+			if tc.wantConsentErr {
+				var consentErr *WarrantyConsentError
+				if !errors.As(err, &consentErr) || consentErr.Feature != tc.featureName || consentErr.FeatureGate != "policies" {
+					t.Fatalf("got error: %v, want a WarrantyConsentError for Feature %s", err, tc.featureName)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+
+			got, err := featureGateClient.GetFeatureActivationRequest(ctx, request.Name)
+			if err != nil {
+				t.Fatalf("unable to get FeatureActivationRequest: %v", err)
+			}
+			if got.Spec.Decision == nil || got.Spec.Decision.Type != tc.wantDecision ||
+				got.Spec.Decision.PermanentlyVoidAllSupportGuarantees != tc.wantWarrantyVoided {
+				t.Errorf("got decision: %+v, want: %s with warranty voided: %t", got.Spec.Decision, tc.wantDecision, tc.wantWarrantyVoided)
+			}
+
+			if _, err := featureGateClient.RejectFeatureActivationRequest(ctx, request.Name, ""); !errors.Is(err, ErrTypeForbidden) {
+				t.Errorf("got error deciding again: %v, want: %v", err, ErrTypeForbidden)
+			}
+		})
+	}
+}
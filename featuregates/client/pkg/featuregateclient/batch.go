@@ -0,0 +1,210 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// FeatureChange is a requested activation setting of a Feature.
+type FeatureChange struct {
+	// Name of the Feature.
+	Name string
+	// Activate is the requested activation setting of the Feature.
+	Activate bool
+	// WarrantyVoidAllowed is the explicit approval of the user to permanently void all support guarantees of the
+	// environment, when the requested activation setting requires it.
+	WarrantyVoidAllowed bool
+}
+
+// gateUpdate holds a FeatureGate as it is in the cluster and as it is after applying the requested changes.
+type gateUpdate struct {
+	original *corev1alpha2.FeatureGate
+	updated  *corev1alpha2.FeatureGate
+}
+
+// ActivateFeatures activates Features. See ApplyFeatureChanges.
+func (f *FeatureGateClient) ActivateFeatures(ctx context.Context, featureNames []string, warrantyVoidAllowed bool) ([]string, error) {
+	changes := make([]FeatureChange, 0, len(featureNames))
+	for _, name := range featureNames {
+		changes = append(changes, FeatureChange{Name: name, Activate: true, WarrantyVoidAllowed: warrantyVoidAllowed})
+	}
+	return f.ApplyFeatureChanges(ctx, changes)
+}
+
+// DeactivateFeatures deactivates Features. See ApplyFeatureChanges.
+func (f *FeatureGateClient) DeactivateFeatures(ctx context.Context, featureNames []string) ([]string, error) {
+	changes := make([]FeatureChange, 0, len(featureNames))
+	for _, name := range featureNames {
+		changes = append(changes, FeatureChange{Name: name, Activate: false})
+	}
+	return f.ApplyFeatureChanges(ctx, changes)
+}
+
+// ApplyFeatureChanges changes the activation setting of several Features at once. All changes are validated against
+// the stability policies and warranty rules before any FeatureGate is updated, and every invalid change is reported.
+// The changes to a FeatureGate are applied in a single patch, which only touches the changed Feature references and is
+// retried when the FeatureGate is updated concurrently. If updating a FeatureGate fails, the FeatureGates that were
+// already updated are rolled back, except for the permanent voiding of support guarantees which cannot be undone. It
+// returns the names of the updated FeatureGates.
+func (f *FeatureGateClient) ApplyFeatureChanges(ctx context.Context, changes []FeatureChange) ([]string, error) {
+	updates, err := f.planFeatureChanges(ctx, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []gateUpdate
+	for _, update := range updates {
+		if err := f.patchFeatureReferences(ctx, update.original.Name, newFeatureReferences(update)); err != nil {
+			updateErr := fmt.Errorf("could not update FeatureGate %s: %w", update.original.Name, err)
+			if rollbackErr := f.rollback(ctx, applied); rollbackErr != nil {
+				return nil, kerrors.NewAggregate([]error{updateErr, rollbackErr})
+			}
+			return nil, updateErr
+		}
+		applied = append(applied, update)
+	}
+
+	names := make([]string, 0, len(applied))
+	for _, update := range applied {
+		names = append(names, update.original.Name)
+	}
+	return names, nil
+}
+
+// planFeatureChanges validates the changes and returns the FeatureGates to update, sorted by name. Changes that do not
+// alter the activation setting of a Feature are skipped.
+func (f *FeatureGateClient) planFeatureChanges(ctx context.Context, changes []FeatureChange) ([]gateUpdate, error) {
+	gates, err := f.GetFeatureGateList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureGateList: %w", err)
+	}
+
+	updates := map[string]*gateUpdate{}
+	requested := map[string]bool{}
+	var errs []error
+	for _, change := range changes {
+		if activate, found := requested[change.Name]; found {
+			if activate != change.Activate {
+				errs = append(errs, fmt.Errorf("the Feature %s is requested to be both activated and deactivated: %w", change.Name, ErrTypeForbidden))
+			}
+			continue
+		}
+		requested[change.Name] = change.Activate
+
+		if err := f.planFeatureChange(ctx, gates, change, updates); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, kerrors.NewAggregate(errs)
+	}
+
+	names := make([]string, 0, len(updates))
+	for name := range updates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	planned := make([]gateUpdate, 0, len(names))
+	for _, name := range names {
+		planned = append(planned, *updates[name])
+	}
+	return planned, nil
+}
+
+// planFeatureChange validates a change and records it in the update of the FeatureGate gating the Feature.
+func (f *FeatureGateClient) planFeatureChange(ctx context.Context, gates *corev1alpha2.FeatureGateList, change FeatureChange, updates map[string]*gateUpdate) error {
+	// A Feature must exist in the cluster to change its activation setting.
+	feature, err := f.GetFeature(ctx, change.Name)
+	if err != nil {
+		return fmt.Errorf("could not get Feature %s: %w", change.Name, err)
+	}
+
+	// The Feature must be gated by exactly one FeatureGate, even when it already has the requested activation setting.
+	if err := featureExistsInOneAndOnlyOneFeaturegate(gates, change.Name); err != nil {
+		return fmt.Errorf("could not validate Feature changing activation set point: %w", err)
+	}
+
+	gateName, featRef := FeatureRefFromGateList(gates, change.Name)
+	if featRef.Activate == change.Activate {
+		return nil
+	}
+
+	if err := validateFeatureActivationToggle(gates, feature); err != nil {
+		return err
+	}
+
+	voidWarranty := false
+	if change.Activate {
+		voidWarranty, err = setVoidWarrantyChecksPass(gateName, featRef, feature, change.WarrantyVoidAllowed)
+		if err != nil {
+			return fmt.Errorf("could not activate Feature %s: %w", change.Name, err)
+		}
+	}
+
+	update, ok := updates[gateName]
+	if !ok {
+		gate := gateFromList(gates, gateName)
+		update = &gateUpdate{original: gate, updated: gate.DeepCopy()}
+		updates[gateName] = update
+	}
+	for i := range update.updated.Spec.Features {
+		if update.updated.Spec.Features[i].Name == change.Name {
+			update.updated.Spec.Features[i].Activate = change.Activate
+			if voidWarranty {
+				update.updated.Spec.Features[i].PermanentlyVoidAllSupportGuarantees = true
+			}
+		}
+	}
+	return nil
+}
+
+// rollback restores the activation intents the updates changed to their original settings. Support guarantees voided
+// by the updates remain void.
+func (f *FeatureGateClient) rollback(ctx context.Context, applied []gateUpdate) error {
+	var errs []error
+	for i := len(applied) - 1; i >= 0; i-- {
+		var refs []corev1alpha2.FeatureReference
+		for _, refChange := range featureReferenceChanges(applied[i]) {
+			refs = append(refs, corev1alpha2.FeatureReference{Name: refChange.Old.Name, Activate: refChange.Old.Activate})
+		}
+		if err := f.patchFeatureReferences(ctx, applied[i].original.Name, refs); err != nil {
+			errs = append(errs, fmt.Errorf("could not roll back FeatureGate %s: %w", applied[i].original.Name, err))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// patchFeatureReferences patches the FeatureGate with the activation intents and voided warranties of the references.
+func (f *FeatureGateClient) patchFeatureReferences(ctx context.Context, featureGateName string, refs []corev1alpha2.FeatureReference) error {
+	return f.patchFeatureGate(ctx, featureGateName, func(gate *corev1alpha2.FeatureGate) error {
+		return setFeatureReferences(gate, refs)
+	})
+}
+
+// newFeatureReferences returns the Feature references changed by the update, as they are after the update.
+func newFeatureReferences(update gateUpdate) []corev1alpha2.FeatureReference {
+	var refs []corev1alpha2.FeatureReference
+	for _, refChange := range featureReferenceChanges(update) {
+		refs = append(refs, refChange.New)
+	}
+	return refs
+}
+
+// gateFromList returns a copy of the named FeatureGate from the list.
+func gateFromList(gates *corev1alpha2.FeatureGateList, gateName string) *corev1alpha2.FeatureGate {
+	for i := range gates.Items {
+		if gates.Items[i].Name == gateName {
+			return gates.Items[i].DeepCopy()
+		}
+	}
+	return nil
+}
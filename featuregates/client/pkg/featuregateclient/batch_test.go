@@ -0,0 +1,179 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/internal/testobjects"
+)
+
+// failingPatchClient fails the patches of a FeatureGate.
+type failingPatchClient struct {
+	client.Client
+	gateName string
+}
+
+func (c *failingPatchClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if _, ok := obj.(*corev1alpha2.FeatureGate); ok && obj.GetName() == c.gateName {
+		return errors.New("injected patch failure")
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func TestApplyFeatureChanges(t *testing.T) {
+	tests := []struct {
+		description    string
+		changes        []FeatureChange
+		failGate       string
+		wantErr        error
+		wantAnyErr     bool
+		wantGates      []string
+		wantActivation map[string]bool
+		wantVoided     map[string]bool
+	}{
+		{
+			description: "should update each FeatureGate once",
+			changes: []FeatureChange{
+				{Name: "bar", Activate: true},
+				{Name: "barries", Activate: false},
+				{Name: "tuna", Activate: true},
+			},
+			wantGates:      []string{"tanzu-fg", "tkg-system"},
+			wantActivation: map[string]bool{"bar": true, "barries": false, "tuna": true},
+		},
+		{
+			description: "should skip Features that already have the requested activation setting",
+			changes: []FeatureChange{
+				{Name: "super-toaster", Activate: true},
+				{Name: "tuna", Activate: true},
+			},
+			wantGates:      []string{"tanzu-fg"},
+			wantActivation: map[string]bool{"super-toaster": true, "tuna": true},
+		},
+		{
+			description: "should void the warranty when allowed",
+			changes: []FeatureChange{
+				{Name: "cloud-event-speaker", Activate: true, WarrantyVoidAllowed: true},
+			},
+			wantGates:      []string{"tkg-system"},
+			wantActivation: map[string]bool{"cloud-event-speaker": true},
+			wantVoided:     map[string]bool{"cloud-event-speaker": true},
+		},
+		{
+			description: "should not apply any change when one is forbidden",
+			changes: []FeatureChange{
+				{Name: "bar", Activate: true},
+				{Name: "cloud-event-speaker", Activate: true},
+			},
+			wantErr:        ErrTypeForbidden,
+			wantActivation: map[string]bool{"bar": false, "cloud-event-speaker": false},
+		},
+		{
+			description: "should not apply any change when a Feature is gated by more than one FeatureGate",
+			changes: []FeatureChange{
+				{Name: "tuna", Activate: true},
+				{Name: "baz", Activate: true},
+			},
+			wantErr:        ErrTypeTooMany,
+			wantActivation: map[string]bool{"tuna": false, "baz": false},
+		},
+		{
+			description: "should reject a Feature gated by more than one FeatureGate that already has the requested setting",
+			changes: []FeatureChange{
+				{Name: "tuna", Activate: true},
+				{Name: "baz", Activate: false},
+			},
+			wantErr:        ErrTypeTooMany,
+			wantActivation: map[string]bool{"tuna": false, "baz": false},
+		},
+		{
+			description: "should not apply any change when a Feature does not exist",
+			changes: []FeatureChange{
+				{Name: "tuna", Activate: true},
+				{Name: "hard-to-get", Activate: true},
+			},
+			wantErr:        ErrTypeNotFound,
+			wantActivation: map[string]bool{"tuna": false},
+		},
+		{
+			description: "should reject conflicting changes of the same Feature",
+			changes: []FeatureChange{
+				{Name: "tuna", Activate: true},
+				{Name: "tuna", Activate: false},
+			},
+			wantErr:        ErrTypeForbidden,
+			wantActivation: map[string]bool{"tuna": false},
+		},
+		{
+			description: "should roll back updated FeatureGates when a later update fails",
+			changes: []FeatureChange{
+				{Name: "bar", Activate: true},
+				{Name: "tuna", Activate: true},
+			},
+			failGate:       "tkg-system",
+			wantAnyErr:     true,
+			wantActivation: map[string]bool{"bar": false, "tuna": false},
+		},
+	}
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+			defer cancel()
+
+			objs, _, _ := testobjects.Get()
+			var cl client.Client = crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+			if tc.failGate != "" {
+				cl = &failingPatchClient{Client: cl, gateName: tc.failGate}
+			}
+			featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+			}
+
+			gates, err := featureGateClient.ApplyFeatureChanges(ctx, tc.changes)
+			if tc.wantAnyErr {
+				if err == nil {
+					t.Fatal("error expected, but got nothing")
+				}
+			} else if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(gates, tc.wantGates) {
+				t.Errorf("got updated FeatureGates: %v, want: %v", gates, tc.wantGates)
+			}
+
+			gateList, err := featureGateClient.GetFeatureGateList(ctx)
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate list: %v", err)
+			}
+			for name, want := range tc.wantActivation {
+				_, ref := FeatureRefFromGateList(gateList, name)
+				if ref.Activate != want {
+					t.Errorf("got Feature %s activate: %t, want: %t", name, ref.Activate, want)
+				}
+			}
+			for name, want := range tc.wantVoided {
+				_, ref := FeatureRefFromGateList(gateList, name)
+				if ref.PermanentlyVoidAllSupportGuarantees != want {
+					t.Errorf("got Feature %s warranty voided: %t, want: %t", name, ref.PermanentlyVoidAllSupportGuarantees, want)
+				}
+			}
+		})
+	}
+}
@@ -5,11 +5,14 @@ package featuregateclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,20 +22,43 @@ import (
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 )
 
+// FeatureGateInterface defines the methods to read and toggle Features that consumers depend on. It is implemented by
+// FeatureGateClient, and by fake.FeatureGateClient for unit tests.
+type FeatureGateInterface interface {
+	GetFeature(ctx context.Context, featureName string) (*corev1alpha2.Feature, error)
+	GetFeatureList(ctx context.Context, opts ...client.ListOption) (*corev1alpha2.FeatureList, error)
+	GetFeatureGate(ctx context.Context, featureGateName string) (*corev1alpha2.FeatureGate, error)
+	GetFeatureGateList(ctx context.Context) (*corev1alpha2.FeatureGateList, error)
+	ActivateFeature(ctx context.Context, featureName string, warrantyVoidAllowed bool) (*ActivationResult, error)
+	DeactivateFeature(ctx context.Context, featureName string) (*ActivationResult, error)
+}
+
+var _ FeatureGateInterface = &FeatureGateClient{}
+
 // FeatureGateClient defines methods to interact with FeatureGate resources
 type FeatureGateClient struct {
 	crClient client.Client
+	logger   logr.Logger
+	// restConfig returns the config of the cluster to create the cluster client for, unless one is set with WithClient.
+	restConfig func() (*rest.Config, error)
 }
 
-// NewFeatureGateClient returns an instance of FeatureGateClient.
+// NewFeatureGateClient returns an instance of FeatureGateClient. Unless a client is set with WithClient, a cluster client
+// is created for the cluster set with WithKubeconfig, WithRestConfig or WithInClusterConfig. Without any of them, the
+// cluster is detected: that of the current tanzu CLI context, else that of the pod the client runs in, else that of the
+// current context of the kubeconfig loaded the way kubectl does.
 func NewFeatureGateClient(options ...Option) (*FeatureGateClient, error) {
-	featureGateClient := &FeatureGateClient{}
+	featureGateClient := &FeatureGateClient{logger: logr.Discard(), restConfig: detectRestConfig}
 	// Apply options
 	for _, option := range options {
 		featureGateClient = option(featureGateClient)
 	}
 	if featureGateClient.crClient == nil {
-		c, err := getFeatureGateClient()
+		restConfig, err := featureGateClient.restConfig()
+		if err != nil {
+			return nil, err
+		}
+		c, err := getFeatureGateClient(restConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -52,10 +78,51 @@ func WithClient(cl client.Client) Option {
 	}
 }
 
-// getFeatureGateClient returns a new FeatureGate client
-func getFeatureGateClient() (client.Client, error) {
-	var err error
+// WithLogger function is for setting the logger the FeatureGateClient reports the changes it makes to. Nothing is
+// logged by default.
+func WithLogger(logger logr.Logger) Option {
+	return func(featureGateClient *FeatureGateClient) *FeatureGateClient {
+		featureGateClient.logger = logger
+		return featureGateClient
+	}
+}
+
+// WithKubeconfig function is for creating the cluster client for a context of a kubeconfig file. An empty path loads the
+// kubeconfig the way kubectl does, and an empty context is the current context of the kubeconfig.
+func WithKubeconfig(path, contextName string) Option {
+	return func(featureGateClient *FeatureGateClient) *FeatureGateClient {
+		featureGateClient.restConfig = func() (*rest.Config, error) {
+			restConfig, err := getRestConfigWithContext(contextName, path)
+			if err != nil {
+				return nil, fmt.Errorf("could not get rest config from kubeconfig: %w", err)
+			}
+			return restConfig, nil
+		}
+		return featureGateClient
+	}
+}
+
+// WithRestConfig function is for creating the cluster client with the passed in rest config.
+func WithRestConfig(restConfig *rest.Config) Option {
+	return func(featureGateClient *FeatureGateClient) *FeatureGateClient {
+		featureGateClient.restConfig = func() (*rest.Config, error) {
+			return restConfig, nil
+		}
+		return featureGateClient
+	}
+}
+
+// WithInClusterConfig function is for creating the cluster client with the service account of the pod the
+// FeatureGateClient runs in.
+func WithInClusterConfig() Option {
+	return func(featureGateClient *FeatureGateClient) *FeatureGateClient {
+		featureGateClient.restConfig = getInClusterConfig
+		return featureGateClient
+	}
+}
 
+// getFeatureGateClient returns a new FeatureGate client
+func getFeatureGateClient(restConfig *rest.Config) (client.Client, error) {
 	scheme := runtime.NewScheme()
 	if err := corev1alpha2.AddToScheme(scheme); err != nil {
 		return nil, err
@@ -64,11 +131,7 @@ func getFeatureGateClient() (client.Client, error) {
 		return nil, err
 	}
 
-	restConfig, err := getCurrentClusterConfig()
-	if err != nil {
-		return nil, err
-	}
-	crClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	crClient, err := client.NewWithWatch(restConfig, client.Options{Scheme: scheme})
 	if err != nil {
 		return nil, fmt.Errorf("could not create cluster client: %w", err)
 	}
@@ -81,7 +144,7 @@ func (f *FeatureGateClient) GetFeatureGate(ctx context.Context, featureGateName
 	err := f.crClient.Get(ctx, client.ObjectKey{Name: featureGateName}, gate)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil, ErrTypeNotFound
+			return nil, &NotFoundError{Kind: "FeatureGate", Name: featureGateName}
 		}
 		return nil, fmt.Errorf("could not get featuregate %s: %w", featureGateName, err)
 	}
@@ -104,66 +167,85 @@ func (f *FeatureGateClient) GetFeature(ctx context.Context, featureName string)
 	err := f.crClient.Get(ctx, client.ObjectKey{Name: featureName}, feature)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil, ErrTypeNotFound
+			return nil, &NotFoundError{Kind: "Feature", Name: featureName}
 		}
 		return nil, err
 	}
 	return feature, nil
 }
 
-// GetFeatureList fetches all features on the cluster.
-func (f *FeatureGateClient) GetFeatureList(ctx context.Context) (*corev1alpha2.FeatureList, error) {
+// GetFeatureList fetches all features on the cluster. List options, e.g. client.MatchingLabelsSelector, narrow down the
+// features fetched.
+func (f *FeatureGateClient) GetFeatureList(ctx context.Context, opts ...client.ListOption) (*corev1alpha2.FeatureList, error) {
 	features := &corev1alpha2.FeatureList{}
-	err := f.crClient.List(ctx, features)
+	err := f.crClient.List(ctx, features, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("could not get features on cluster: %w", err)
 	}
 	return features, nil
 }
 
-// ActivateFeature activates a Feature if it passes validation and warranty checks.
+// ActivationResult describes the outcome of setting the activation intent of a Feature.
+type ActivationResult struct {
+	// FeatureGate is the name of the FeatureGate gating the Feature.
+	FeatureGate string
+	// Feature is the name of the Feature.
+	Feature string
+	// PreviousActivate is the activation intent of the Feature before the change.
+	PreviousActivate bool
+	// Activate is the activation intent of the Feature after the change.
+	Activate bool
+	// WarrantyVoided is true if the change permanently voided all support guarantees for the environment.
+	WarrantyVoided bool
+	// NoOp is true if the Feature already had the requested activation intent, and nothing was changed.
+	NoOp bool
+}
+
+// ActivateFeature activates a Feature if it passes validation and warranty checks, and returns what was changed.
 // Warning: Before sending `true` via the warrantyVoidAllowed function argument, ensure
 // explicit user awareness and approval if activating a Feature will cause the support
 // warranty to be void. Once warranty is void, it is is permanent for the environment.
-func (f *FeatureGateClient) ActivateFeature(ctx context.Context, featureName string, warrantyVoidAllowed bool) error {
+func (f *FeatureGateClient) ActivateFeature(ctx context.Context, featureName string, warrantyVoidAllowed bool) (*ActivationResult, error) {
 	// A Feature must exist in the cluster to be activated.
 	feature, err := f.GetFeature(ctx, featureName)
 	if err != nil {
-		return fmt.Errorf("could not get Feature %s: %w", featureName, err)
+		return nil, fmt.Errorf("could not get Feature %s: %w", featureName, err)
 	}
 
 	gates, err := f.GetFeatureGateList(ctx)
 	if err != nil {
-		return fmt.Errorf("could not get FeatureGateList: %w", err)
+		return nil, fmt.Errorf("could not get FeatureGateList: %w", err)
 	}
 
 	gateName, featRef := FeatureRefFromGateList(gates, featureName)
+	result := &ActivationResult{FeatureGate: gateName, Feature: featureName, PreviousActivate: featRef.Activate, Activate: true}
 
 	if featRef.Activate {
-		fmt.Printf("The Feature Reference %s is already set to be activated in FeatureGate %s.\n", featureName, gateName)
-		return nil
+		f.logger.Info("Feature is already set to be activated", "feature", featureName, "featureGate", gateName)
+		result.NoOp = true
+		return result, nil
 	}
 
 	if err := validateFeatureActivationToggle(gates, feature); err != nil {
-		return err
+		return nil, err
 	}
 
-	gate, err := f.GetFeatureGate(ctx, gateName)
+	voidWarranty, err := setVoidWarrantyChecksPass(gateName, featRef, feature, warrantyVoidAllowed)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	ok, err := setVoidWarrantyChecksPass(featRef, feature, warrantyVoidAllowed)
-	if err != nil {
-		return err
+	// Voiding the warranty and activating the Feature are a single patch, so that a failed activation never leaves
+	// the support guarantees voided.
+	if err := f.setActivated(ctx, gateName, featureName, voidWarranty); err != nil {
+		return nil, err
 	}
-	if ok {
-		if err := f.setVoidWarranty(ctx, gate, feature.Name); err != nil {
-			return err
-		}
+	if voidWarranty {
+		f.logger.Info("Permanently voided all support guarantees", "feature", featureName, "featureGate", gateName)
+		result.WarrantyVoided = true
 	}
-
-	return f.setActivated(ctx, gate, featureName)
+	f.logger.Info("Activated Feature", "feature", featureName, "featureGate", gateName)
+	return result, nil
 }
 
 // FeatureRefFromGateList finds the requested Feature from a list of featuregates. If found,
@@ -193,7 +275,7 @@ func FeatureRefFromGateList(gates *corev1alpha2.FeatureGateList, featureName str
 //   - Warranty will be voided, but user does not give permission to do so.
 //   - The new activation setting is the same as the default. Another way to say this is that the old
 //     activation setting is different than the default (policy.DefaultActivation != ref.Activate)
-func setVoidWarrantyChecksPass(ref corev1alpha2.FeatureReference, feature *corev1alpha2.Feature, warrantyVoidAllowed bool) (bool, error) {
+func setVoidWarrantyChecksPass(featureGateName string, ref corev1alpha2.FeatureReference, feature *corev1alpha2.Feature, warrantyVoidAllowed bool) (bool, error) {
 	stability := feature.Spec.Stability
 	policy := corev1alpha2.GetPolicyForStabilityLevel(stability)
 
@@ -203,7 +285,7 @@ func setVoidWarrantyChecksPass(ref corev1alpha2.FeatureReference, feature *corev
 		if warrantyVoidAllowed {
 			return true, nil
 		}
-		return false, fmt.Errorf("warranty will be voided with new activation set point, but user has not given express permission to void the warranty: %w", ErrTypeForbidden)
+		return false, &WarrantyConsentError{Feature: feature.Name, FeatureGate: featureGateName, Stability: stability, Policy: policy}
 	}
 
 	// The requested Feature activation set point is already the same as default and will not void warranty,
@@ -211,69 +293,126 @@ func setVoidWarrantyChecksPass(ref corev1alpha2.FeatureReference, feature *corev
 	return false, nil
 }
 
-func (f *FeatureGateClient) setVoidWarranty(ctx context.Context, gate *corev1alpha2.FeatureGate, featureName string) error {
-	for i, featureRef := range gate.Spec.Features {
-		if featureRef.Name == featureName {
-			gate.Spec.Features[i].PermanentlyVoidAllSupportGuarantees = true
-			return f.crClient.Update(ctx, gate)
-		}
-	}
-	return fmt.Errorf("could not void warranty for Feature %s as it was not found in any FeatureGate", ErrTypeNotFound)
-}
-
-// setActivated sets the Feature to activate in FeatureGate
-func (f *FeatureGateClient) setActivated(ctx context.Context, gate *corev1alpha2.FeatureGate, featureName string) error {
-	for i := range gate.Spec.Features {
-		if gate.Spec.Features[i].Name == featureName {
-			gate.Spec.Features[i].Activate = true
-			return f.crClient.Update(ctx, gate)
+// setActivated sets the Feature to activate in FeatureGate, permanently voiding its support guarantees in the same
+// patch when voidWarranty is set.
+func (f *FeatureGateClient) setActivated(ctx context.Context, featureGateName, featureName string, voidWarranty bool) error {
+	return f.patchFeatureGate(ctx, featureGateName, func(gate *corev1alpha2.FeatureGate) error {
+		for i := range gate.Spec.Features {
+			if gate.Spec.Features[i].Name == featureName {
+				gate.Spec.Features[i].Activate = true
+				if voidWarranty {
+					gate.Spec.Features[i].PermanentlyVoidAllSupportGuarantees = true
+				}
+				return nil
+			}
 		}
-	}
-	return fmt.Errorf("could not activate Feature %s as it was not found in FeatureGate %s: %w", featureName, gate.Name, ErrTypeNotFound)
+		return fmt.Errorf("could not activate Feature %s as it was not found in FeatureGate %s: %w", featureName, gate.Name, ErrTypeNotFound)
+	})
 }
 
-// DeactivateFeature deactivates a Feature. Along with the error, it returns the name of the FeatureGate
-// that gates the Feature.
-func (f *FeatureGateClient) DeactivateFeature(ctx context.Context, featureName string) (string, error) {
+// DeactivateFeature deactivates a Feature, and returns what was changed.
+func (f *FeatureGateClient) DeactivateFeature(ctx context.Context, featureName string) (*ActivationResult, error) {
 	// A Feature must exist in the cluster to be deactivated.
 	feature, err := f.GetFeature(ctx, featureName)
 	if err != nil {
-		return "", fmt.Errorf("could not get Feature %s: %w", featureName, err)
+		return nil, fmt.Errorf("could not get Feature %s: %w", featureName, err)
 	}
 
 	gates, err := f.GetFeatureGateList(ctx)
 	if err != nil {
-		return "", fmt.Errorf("could not get FeatureGateList: %w", err)
+		return nil, fmt.Errorf("could not get FeatureGateList: %w", err)
 	}
 
 	gateName, featRef := FeatureRefFromGateList(gates, featureName)
+	result := &ActivationResult{FeatureGate: gateName, Feature: featureName, PreviousActivate: featRef.Activate, Activate: false}
 
 	if gateName != "" && !featRef.Activate {
-		fmt.Printf("The Feature Reference %s is already set to be deactivated in FeatureGate %s.\n", featureName, gateName)
-		return gateName, nil
+		f.logger.Info("Feature is already set to be deactivated", "feature", featureName, "featureGate", gateName)
+		result.NoOp = true
+		return result, nil
 	}
 
 	if err := validateFeatureActivationToggle(gates, feature); err != nil {
-		return gateName, err
+		return nil, err
 	}
 
-	gate, err := f.GetFeatureGate(ctx, gateName)
-	if err != nil {
-		return gateName, err
+	if err := f.setDeactivated(ctx, gateName, featureName); err != nil {
+		return nil, err
 	}
-
-	return gateName, f.setDeactivated(ctx, gate, featureName)
+	f.logger.Info("Deactivated Feature", "feature", featureName, "featureGate", gateName)
+	return result, nil
 }
 
 // setDeactivated sets the Feature to 'deactivate' in the FeatureGate resource.
-func (f *FeatureGateClient) setDeactivated(ctx context.Context, gate *corev1alpha2.FeatureGate, featureName string) error {
-	for i, featureRef := range gate.Spec.Features {
-		if featureRef.Name == featureName {
-			gate.Spec.Features[i].Activate = false
-			return f.crClient.Update(ctx, gate)
+func (f *FeatureGateClient) setDeactivated(ctx context.Context, featureGateName, featureName string) error {
+	return f.patchFeatureGate(ctx, featureGateName, func(gate *corev1alpha2.FeatureGate) error {
+		for i := range gate.Spec.Features {
+			if gate.Spec.Features[i].Name == featureName {
+				gate.Spec.Features[i].Activate = false
+				return nil
+			}
 		}
+		return fmt.Errorf("could not deactivate Feature %s as it was not found in FeatureGate %s: %w", featureName, gate.Name, ErrTypeNotFound)
+	})
+}
+
+// ResetFeature returns a Feature to the default activation of its stability level by removing its reference from the
+// FeatureGate gating it, and returns what was changed. The controller then sets the Feature to its default activation.
+// As voided support guarantees cannot be restored, the reference of a Feature that voided them is kept, with its
+// activation set to the default instead.
+func (f *FeatureGateClient) ResetFeature(ctx context.Context, featureName string) (*ActivationResult, error) {
+	// A Feature must exist in the cluster for its default activation to be known.
+	feature, err := f.GetFeature(ctx, featureName)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Feature %s: %w", featureName, err)
 	}
-	return nil
+
+	gates, err := f.GetFeatureGateList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get FeatureGateList: %w", err)
+	}
+
+	if err := featureExistsInOneAndOnlyOneFeaturegate(gates, featureName); err != nil {
+		return nil, fmt.Errorf("could not reset Feature %s: %w", featureName, err)
+	}
+	gateName, featRef := FeatureRefFromGateList(gates, featureName)
+
+	policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability)
+	result := &ActivationResult{FeatureGate: gateName, Feature: featureName, PreviousActivate: featRef.Activate, Activate: policy.DefaultActivation}
+
+	// The reference of a Feature that voided the warranty is kept, so there is nothing to change if it already has the
+	// default activation.
+	if featRef.PermanentlyVoidAllSupportGuarantees && featRef.Activate == policy.DefaultActivation {
+		f.logger.Info("Feature is already set to its default activation", "feature", featureName, "featureGate", gateName)
+		result.NoOp = true
+		return result, nil
+	}
+
+	if err := f.removeReference(ctx, gateName, featureName, policy.DefaultActivation); err != nil {
+		return nil, err
+	}
+	f.logger.Info("Reset Feature", "feature", featureName, "featureGate", gateName)
+	return result, nil
+}
+
+// removeReference removes the Feature reference from the FeatureGate resource, or sets it to the default activation if
+// it voided the support guarantees.
+func (f *FeatureGateClient) removeReference(ctx context.Context, featureGateName, featureName string, defaultActivation bool) error {
+	return f.patchFeatureGate(ctx, featureGateName, func(gate *corev1alpha2.FeatureGate) error {
+		for i, featureRef := range gate.Spec.Features {
+			if featureRef.Name != featureName {
+				continue
+			}
+			if featureRef.PermanentlyVoidAllSupportGuarantees {
+				gate.Spec.Features[i].Activate = defaultActivation
+			} else {
+				gate.Spec.Features = append(gate.Spec.Features[:i], gate.Spec.Features[i+1:]...)
+			}
+			return nil
+		}
+		// The reference was removed concurrently, and the Feature is reset already.
+		return nil
+	})
 }
 
 // getCurrentClusterConfig gets the config of current logged in cluster
@@ -292,11 +431,47 @@ func getCurrentClusterConfig() (*rest.Config, error) {
 	return restConfig, nil
 }
 
-// getRestConfigWithContext returns config using the passed context.
+// getRestConfigWithContext returns config using the passed context. An empty kubeconfig path loads the kubeconfig from
+// the KUBECONFIG environment variable or the home directory, as kubectl does.
 func getRestConfigWithContext(ctx, kubeconfigPath string) (*rest.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfigPath
 	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath},
+		loadingRules,
 		&clientcmd.ConfigOverrides{
 			CurrentContext: ctx,
 		}).ClientConfig()
 }
+
+// getInClusterConfig returns the config of the service account of the pod the client runs in.
+func getInClusterConfig() (*rest.Config, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not get in-cluster config: %w", err)
+	}
+	return restConfig, nil
+}
+
+// detectRestConfig returns the config of the cluster of the current tanzu CLI context. Without a tanzu CLI context, it
+// returns the in-cluster config when running in a pod, and otherwise the config of the current context of the
+// kubeconfig loaded the way kubectl does.
+func detectRestConfig() (*rest.Config, error) {
+	restConfig, tanzuErr := getCurrentClusterConfig()
+	if tanzuErr == nil {
+		return restConfig, nil
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if err == nil {
+		return restConfig, nil
+	}
+	if !errors.Is(err, rest.ErrNotInCluster) {
+		return nil, fmt.Errorf("could not get in-cluster config: %w", err)
+	}
+
+	restConfig, err = getRestConfigWithContext("", "")
+	if err != nil {
+		return nil, fmt.Errorf("could not get rest config from the tanzu CLI context or a kubeconfig: %w", kerrors.NewAggregate([]error{tanzuErr, err}))
+	}
+	return restConfig, nil
+}
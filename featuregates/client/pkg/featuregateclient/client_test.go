@@ -6,14 +6,21 @@ package featuregateclient
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr/funcr"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
-	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/fake"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/internal/testobjects"
 )
 
 const contextTimeout = 30 * time.Second
@@ -22,7 +29,7 @@ func TestGetFeature(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 	defer cancel()
 
-	objs, features, _ := fake.GetTestObjects()
+	objs, features, _ := testobjects.Get()
 	s := scheme.Scheme
 	if err := corev1alpha2.AddToScheme(s); err != nil {
 		t.Fatalf("unable to add config scheme: (%v)", err)
@@ -75,7 +82,7 @@ func TestGetFeatureList(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 	defer cancel()
 
-	objs, _, _ := fake.GetTestObjects()
+	objs, _, _ := testobjects.Get()
 	s := scheme.Scheme
 	if err := corev1alpha2.AddToScheme(s); err != nil {
 		t.Fatalf("unable to add config scheme: (%v)", err)
@@ -122,13 +129,34 @@ func TestGetFeatureList(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("should return features matching the label selector", func(t *testing.T) {
+		selector, err := labels.Parse(corev1alpha2.FeatureComponentLabel + "=cloud-events")
+		if err != nil {
+			t.Fatalf("unable to parse label selector: %v", err)
+		}
+		features, err := featureGateClient.GetFeatureList(ctx, client.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			t.Fatalf("unable to get FeatureList: %v", err)
+		}
+
+		want := []string{"cloud-event-listener", "cloud-event-relayer", "cloud-event-speaker"}
+		if len(features.Items) != len(want) {
+			t.Errorf("got: %d features, want: %v", len(features.Items), want)
+		}
+		for _, feature := range want {
+			if !featureListContainsFeature(features, feature) {
+				t.Errorf("got: %#v, want: %s feature in list", features.Items, feature)
+			}
+		}
+	})
 }
 
 func TestGetFeaturegate(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 	defer cancel()
 
-	objs, _, _ := fake.GetTestObjects()
+	objs, _, _ := testobjects.Get()
 	s := scheme.Scheme
 	if err := corev1alpha2.AddToScheme(s); err != nil {
 		t.Fatalf("unable to add config scheme: (%v)", err)
@@ -185,7 +213,7 @@ func TestGetFeaturegateList(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 	defer cancel()
 
-	objs, _, _ := fake.GetTestObjects()
+	objs, _, _ := testobjects.Get()
 	s := scheme.Scheme
 	if err := corev1alpha2.AddToScheme(s); err != nil {
 		t.Fatalf("unable to add config scheme: (%v)", err)
@@ -229,7 +257,7 @@ func TestActivateFeature(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 	defer cancel()
 
-	objs, _, _ := fake.GetTestObjects()
+	objs, _, _ := testobjects.Get()
 	testScheme := scheme.Scheme
 	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
 		t.Fatalf("unable to add config scheme: (%v)", err)
@@ -320,7 +348,7 @@ func TestActivateFeature(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.description, func(t *testing.T) {
-			err := featureGateClient.ActivateFeature(ctx, tc.featureName, tc.allowWarrantyVoid)
+			_, err := featureGateClient.ActivateFeature(ctx, tc.featureName, tc.allowWarrantyVoid)
 
 			// Error is expected for ActivateFeature.
 			if tc.wantErr != nil {
@@ -365,11 +393,58 @@ func TestActivateFeature(t *testing.T) {
 	}
 }
 
+func TestActivateFeatureVoidsWarrantyInOnePatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	t.Run("should void the warranty and activate the Feature in a single patch", func(t *testing.T) {
+		cl := newConcurrentEditClient(0)
+		featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+		if err != nil {
+			t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+		}
+
+		result, err := featureGateClient.ActivateFeature(ctx, "dodgy-experimental-periscope", true)
+		if err != nil {
+			t.Fatalf("ActivateFeature() error: %v", err)
+		}
+		if !result.WarrantyVoided {
+			t.Errorf("WarrantyVoided = false, want true")
+		}
+		if got := cl.patches["tkg-system"]; got != 1 {
+			t.Errorf("patches of FeatureGate tkg-system = %d, want 1", got)
+		}
+	})
+
+	t.Run("should not void the warranty when the activation fails", func(t *testing.T) {
+		objs, _, _ := testobjects.Get()
+		cl := &failingPatchClient{Client: crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build(), gateName: "tkg-system"}
+		featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+		if err != nil {
+			t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+		}
+
+		if _, err := featureGateClient.ActivateFeature(ctx, "dodgy-experimental-periscope", true); err == nil {
+			t.Fatal("ActivateFeature() error = nil, want the patch failure")
+		}
+
+		gate, err := featureGateClient.GetFeatureGate(ctx, "tkg-system")
+		if err != nil {
+			t.Fatalf("GetFeatureGate() error: %v", err)
+		}
+		for _, ref := range gate.Spec.Features {
+			if ref.Name == "dodgy-experimental-periscope" && (ref.Activate || ref.PermanentlyVoidAllSupportGuarantees) {
+				t.Errorf("reference = %+v, want neither activated nor warranty voided", ref)
+			}
+		}
+	})
+}
+
 func TestDeactivateFeature(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 	defer cancel()
 
-	objs, _, _ := fake.GetTestObjects()
+	objs, _, _ := testobjects.Get()
 	s := scheme.Scheme
 	if err := corev1alpha2.AddToScheme(s); err != nil {
 		t.Fatalf("unable to add config scheme: (%v)", err)
@@ -484,6 +559,90 @@ func TestDeactivateFeature(t *testing.T) {
 	}
 }
 
+func TestActivationResult(t *testing.T) {
+	tests := []struct {
+		description string
+		featureName string
+		activate    bool
+		allowVoid   bool
+		want        ActivationResult
+		wantLog     string
+	}{
+		{
+			description: "should report the activation of a Feature",
+			featureName: "bar",
+			activate:    true,
+			want:        ActivationResult{FeatureGate: "tkg-system", Feature: "bar", Activate: true},
+			wantLog:     `"msg"="Activated Feature" "feature"="bar" "featureGate"="tkg-system"`,
+		},
+		{
+			description: "should report the activation of a Feature that voids the warranty",
+			featureName: "cloud-event-speaker",
+			activate:    true,
+			allowVoid:   true,
+			want:        ActivationResult{FeatureGate: "tkg-system", Feature: "cloud-event-speaker", Activate: true, WarrantyVoided: true},
+			wantLog:     `"msg"="Permanently voided all support guarantees" "feature"="cloud-event-speaker"`,
+		},
+		{
+			description: "should report that activating an activated Feature changes nothing",
+			featureName: "cloud-event-listener",
+			activate:    true,
+			want:        ActivationResult{FeatureGate: "tkg-system", Feature: "cloud-event-listener", PreviousActivate: true, Activate: true, NoOp: true},
+			wantLog:     `"msg"="Feature is already set to be activated" "feature"="cloud-event-listener"`,
+		},
+		{
+			description: "should report the deactivation of a Feature",
+			featureName: "barries",
+			want:        ActivationResult{FeatureGate: "tkg-system", Feature: "barries", PreviousActivate: true},
+			wantLog:     `"msg"="Deactivated Feature" "feature"="barries" "featureGate"="tkg-system"`,
+		},
+		{
+			description: "should report that deactivating a deactivated Feature changes nothing",
+			featureName: "tuna",
+			want:        ActivationResult{FeatureGate: "tanzu-fg", Feature: "tuna", NoOp: true},
+			wantLog:     `"msg"="Feature is already set to be deactivated" "feature"="tuna"`,
+		},
+	}
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+			defer cancel()
+
+			var logs []string
+			logger := funcr.New(func(prefix, args string) { logs = append(logs, args) }, funcr.Options{})
+
+			objs, _, _ := testobjects.Get()
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+			featureGateClient, err := NewFeatureGateClient(WithClient(cl), WithLogger(logger))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+			}
+
+			var result *ActivationResult
+			if tc.activate {
+				result, err = featureGateClient.ActivateFeature(ctx, tc.featureName, tc.allowVoid)
+			} else {
+				result, err = featureGateClient.DeactivateFeature(ctx, tc.featureName)
+			}
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if *result != tc.want {
+				t.Errorf("got result: %+v, want: %+v", *result, tc.want)
+			}
+			if !strings.Contains(strings.Join(logs, "\n"), tc.wantLog) {
+				t.Errorf("got logs: %v, want a log containing: %s", logs, tc.wantLog)
+			}
+		})
+	}
+}
+
 func featureGateContainsFeature(gate *corev1alpha2.FeatureGate, feature string) bool {
 	for _, feat := range gate.Spec.Features {
 		if feature == feat.Name {
@@ -510,3 +669,230 @@ func featureListContainsFeature(features *corev1alpha2.FeatureList, feature stri
 	}
 	return false
 }
+
+func TestResetFeature(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	objs, _, _ := testobjects.Get()
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	tests := []struct {
+		description      string
+		featureName      string
+		wantErr          error
+		wantGateName     string
+		wantPrevious     bool
+		wantDefault      bool
+		wantNoOp         bool
+		wantReferenced   bool
+		wantActivate     bool
+		wantVoidWarranty bool
+	}{
+		{
+			description:  "should remove the reference of a Feature",
+			featureName:  "barries",
+			wantGateName: "tkg-system",
+			wantPrevious: true,
+		},
+		{
+			description:  "should remove the reference of a deprecated Feature",
+			featureName:  "biz",
+			wantGateName: "tkg-system",
+			wantDefault:  true,
+		},
+		{
+			description:      "should keep the reference of a Feature that voided the warranty and set it to the default activation",
+			featureName:      "cloud-event-listener",
+			wantGateName:     "tkg-system",
+			wantPrevious:     true,
+			wantReferenced:   true,
+			wantActivate:     false,
+			wantVoidWarranty: true,
+		},
+		{
+			description:      "should not change the reference of a Feature that voided the warranty and has the default activation",
+			featureName:      "cloud-event-listener",
+			wantGateName:     "tkg-system",
+			wantNoOp:         true,
+			wantReferenced:   true,
+			wantActivate:     false,
+			wantVoidWarranty: true,
+		},
+		{
+			description: "should throw an error when the feature doesn't exist",
+			featureName: "bax",
+			wantErr:     ErrTypeNotFound,
+		},
+		{
+			description: "should throw an error when the Feature is not referenced in a FeatureGate",
+			featureName: "specialized-toaster",
+			wantErr:     ErrTypeNotFound,
+		},
+		{
+			description: "should throw an error when the Feature is referenced in more than one FeatureGate",
+			featureName: "bazzies",
+			wantErr:     ErrTypeTooMany,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			result, err := featureGateClient.ResetFeature(ctx, tc.featureName)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if result.FeatureGate != tc.wantGateName || result.Feature != tc.featureName || result.PreviousActivate != tc.wantPrevious ||
+				result.Activate != tc.wantDefault || result.NoOp != tc.wantNoOp {
+				t.Errorf("got result: %+v, want FeatureGate: %s, previous activate: %t, activate: %t and no-op: %t",
+					result, tc.wantGateName, tc.wantPrevious, tc.wantDefault, tc.wantNoOp)
+			}
+
+			gates, err := featureGateClient.GetFeatureGateList(ctx)
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate list: %v", err)
+			}
+			gotGate, ref := FeatureRefFromGateList(gates, tc.featureName)
+			if (gotGate != "") != tc.wantReferenced {
+				t.Fatalf("got Feature referenced by FeatureGate %q, want referenced: %t", gotGate, tc.wantReferenced)
+			}
+			if ref.Activate != tc.wantActivate || ref.PermanentlyVoidAllSupportGuarantees != tc.wantVoidWarranty {
+				t.Errorf("got Feature reference: %+v, want activate: %t and warranty voided: %t", ref, tc.wantActivate, tc.wantVoidWarranty)
+			}
+		})
+	}
+}
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: one
+  cluster:
+    server: https://one.example.com
+- name: two
+  cluster:
+    server: https://two.example.com
+contexts:
+- name: one
+  context:
+    cluster: one
+    user: user
+- name: two
+  context:
+    cluster: two
+    user: user
+current-context: one
+users:
+- name: user
+  user:
+    token: token
+`
+
+func TestRestConfigOptions(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfigPath := filepath.Join(dir, "kubeconfig")
+	if err := os.WriteFile(kubeconfigPath, []byte(testKubeconfig), 0o600); err != nil {
+		t.Fatalf("unable to write kubeconfig: %v", err)
+	}
+	// Without a tanzu CLI context, and outside a pod, the cluster is that of the kubeconfig.
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+	t.Setenv("TANZU_CONFIG_METADATA", filepath.Join(dir, ".config-metadata.yaml"))
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBECONFIG", kubeconfigPath)
+
+	tests := []struct {
+		description string
+		options     []Option
+		wantHost    string
+		wantErr     error
+		wantErrMsg  string
+	}{
+		{
+			description: "should use the current context of a kubeconfig",
+			options:     []Option{WithKubeconfig(kubeconfigPath, "")},
+			wantHost:    "https://one.example.com",
+		},
+		{
+			description: "should use a context of a kubeconfig",
+			options:     []Option{WithKubeconfig(kubeconfigPath, "two")},
+			wantHost:    "https://two.example.com",
+		},
+		{
+			description: "should load the kubeconfig the way kubectl does without a path",
+			options:     []Option{WithKubeconfig("", "two")},
+			wantHost:    "https://two.example.com",
+		},
+		{
+			description: "should throw an error when the kubeconfig context does not exist",
+			options:     []Option{WithKubeconfig(kubeconfigPath, "three")},
+			wantErrMsg:  "could not get rest config from kubeconfig",
+		},
+		{
+			description: "should use a rest config",
+			options:     []Option{WithRestConfig(&rest.Config{Host: "https://rest.example.com"})},
+			wantHost:    "https://rest.example.com",
+		},
+		{
+			description: "should throw an error when asked for the in-cluster config outside a pod",
+			options:     []Option{WithInClusterConfig()},
+			wantErr:     rest.ErrNotInCluster,
+		},
+		{
+			description: "should use the last option setting the cluster",
+			options:     []Option{WithInClusterConfig(), WithKubeconfig(kubeconfigPath, "two")},
+			wantHost:    "https://two.example.com",
+		},
+		{
+			description: "should detect the kubeconfig without a tanzu CLI context outside a pod",
+			wantHost:    "https://one.example.com",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			featureGateClient := &FeatureGateClient{restConfig: detectRestConfig}
+			for _, option := range tc.options {
+				featureGateClient = option(featureGateClient)
+			}
+			restConfig, err := featureGateClient.restConfig()
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if tc.wantErrMsg != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErrMsg)) {
+				t.Fatalf("got error: %v, want it to contain: %q", err, tc.wantErrMsg)
+			}
+			if tc.wantErr != nil || tc.wantErrMsg != "" {
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if restConfig.Host != tc.wantHost {
+				t.Errorf("got host: %s, want: %s", restConfig.Host, tc.wantHost)
+			}
+		})
+	}
+}
+
+func TestWithClientTakesPrecedence(t *testing.T) {
+	cl := crclient.NewClientBuilder().Build()
+	featureGateClient, err := NewFeatureGateClient(WithInClusterConfig(), WithClient(cl))
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if featureGateClient.crClient != cl {
+		t.Error("got a cluster client other than the one set with WithClient")
+	}
+}
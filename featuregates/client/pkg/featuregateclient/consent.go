@@ -0,0 +1,47 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// UserGivesPermissionToVoidWarranty returns the permission to void the warranty the user gave by flag. If the flag was
+// not set, the user is warned on out that changing the activation of the Feature voids all support guarantees, and
+// asked for permission on in.
+func UserGivesPermissionToVoidWarranty(in io.Reader, out io.Writer, feature *corev1alpha2.Feature, userAllowsByFlag *bool) (bool, error) {
+	if userAllowsByFlag != nil {
+		// If the user did pass a value to the flag, use the user's input. No interactive user input is needed.
+		return *userAllowsByFlag, nil
+	}
+	// Request user permission interactively to void the warranty if not already set by flag.
+	fmt.Fprintf(out, "Warning: setting the activation of %q, a %s Feature, away from its default will irrevocably void all support guarantees for this environment. You will need to recreate the environment to return to a supported state.\nWould you like to continue [y/N]?", feature.Name, feature.Spec.Stability)
+	return UserAllowsByInteractiveCLI(in, out)
+}
+
+// UserAllowsByInteractiveCLI reads a yes or no answer from in, and asks again on out until the answer is understood.
+// No answer is a no.
+func UserAllowsByInteractiveCLI(in io.Reader, out io.Writer) (bool, error) {
+	var userAnswer string
+	if n, err := fmt.Fscanln(in, &userAnswer); err != nil {
+		if n == 0 {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not read user input: %w", err)
+	}
+
+	switch strings.TrimSpace(strings.ToLower(userAnswer)) {
+	case "yes", "y":
+		return true, nil
+	case "", "no", "n":
+		return false, nil
+	default:
+		fmt.Fprintf(out, "Please respond with %q or %q\n", "y", "n")
+		return UserAllowsByInteractiveCLI(in, out)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+func TestUserGivesPermissionToVoidWarranty(t *testing.T) {
+	allowed, disallowed := true, false
+	feature := &corev1alpha2.Feature{ObjectMeta: metav1.ObjectMeta{Name: "foo"}, Spec: corev1alpha2.FeatureSpec{Stability: corev1alpha2.Experimental}}
+
+	tests := []struct {
+		description      string
+		userAllowsByFlag *bool
+		userInput        string
+		want             bool
+		wantPrompt       bool
+	}{
+		{
+			description:      "use the permission given by flag",
+			userAllowsByFlag: &allowed,
+			want:             true,
+		},
+		{
+			description:      "use the refusal given by flag",
+			userAllowsByFlag: &disallowed,
+			userInput:        "y\n",
+			want:             false,
+		},
+		{
+			description: "ask the user when the flag is not set",
+			userInput:   "y\n",
+			want:        true,
+			wantPrompt:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := UserGivesPermissionToVoidWarranty(strings.NewReader(tc.userInput), &out, feature, tc.userAllowsByFlag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got: %t, want: %t", got, tc.want)
+			}
+			if prompted := strings.Contains(out.String(), `"foo", a Experimental Feature`); prompted != tc.wantPrompt {
+				t.Errorf("got output: %q, want prompt: %t", out.String(), tc.wantPrompt)
+			}
+		})
+	}
+}
+
+func TestUserAllowsByInteractiveCLI(t *testing.T) {
+	tests := []struct {
+		description string
+		userInput   string
+		want        bool
+		wantAsked   bool
+	}{
+		{
+			description: "return true for user input yes",
+			userInput:   "yes",
+			want:        true,
+		},
+		{
+			description: "return true for user input Y",
+			userInput:   "Y",
+			want:        true,
+		},
+		{
+			description: "return false for user input no",
+			userInput:   "no",
+			want:        false,
+		},
+		{
+			description: "return false for user input N",
+			userInput:   "N",
+			want:        false,
+		},
+		{
+			description: "return false for no user input",
+			userInput:   "",
+			want:        false,
+		},
+		{
+			description: "return false for bad user input on first try and no user input on second",
+			userInput:   "bad-input",
+			want:        false,
+			wantAsked:   true,
+		},
+		{
+			description: "ask again for bad user input",
+			userInput:   "maybe\ny\n",
+			want:        true,
+			wantAsked:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := UserAllowsByInteractiveCLI(strings.NewReader(tc.userInput), &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got: %t, want: %t", got, tc.want)
+			}
+			if asked := strings.Contains(out.String(), "Please respond with"); asked != tc.wantAsked {
+				t.Errorf("got output: %q, want the user asked again: %t", out.String(), tc.wantAsked)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// FeatureGateChange describes how a FeatureGate would change, and whether the change would be accepted.
+type FeatureGateChange struct {
+	// FeatureGate is the name of the FeatureGate.
+	FeatureGate string
+	// Features are the changed Feature references.
+	Features []FeatureReferenceChange
+	// Accepted is true if the cluster, including the FeatureGate webhook, accepts the updated FeatureGate.
+	Accepted bool
+	// Rejection is the reason the cluster rejects the updated FeatureGate.
+	Rejection string
+}
+
+// FeatureReferenceChange describes how the activation intent of a Feature would change.
+type FeatureReferenceChange struct {
+	// Old is the Feature reference in the FeatureGate.
+	Old corev1alpha2.FeatureReference
+	// New is the Feature reference after the change.
+	New corev1alpha2.FeatureReference
+	// VoidsWarranty is true if the change would permanently void all support guarantees of the environment.
+	VoidsWarranty bool
+}
+
+// DryRunFeatureChanges validates the changes like ApplyFeatureChanges does, and reports how every FeatureGate would
+// change without changing it. The changed references are sent to the cluster as the same merge patches
+// ApplyFeatureChanges sends, with a server-side dry run, so that the FeatureGate webhook validates them as well. Like
+// those patches, a dry run is retried when a FeatureGate is changed concurrently.
+func (f *FeatureGateClient) DryRunFeatureChanges(ctx context.Context, changes []FeatureChange) ([]FeatureGateChange, error) {
+	updates, err := f.planFeatureChanges(ctx, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	gateChanges := make([]FeatureGateChange, 0, len(updates))
+	for _, update := range updates {
+		gateChange := FeatureGateChange{
+			FeatureGate: update.original.Name,
+			Features:    featureReferenceChanges(update),
+			Accepted:    true,
+		}
+
+		refs := newFeatureReferences(update)
+		err := f.patchFeatureGate(ctx, update.original.Name, func(gate *corev1alpha2.FeatureGate) error {
+			return setFeatureReferences(gate, refs)
+		}, client.DryRunAll)
+		if err != nil {
+			if !apierrors.IsInvalid(err) && !apierrors.IsForbidden(err) && !apierrors.IsBadRequest(err) {
+				return nil, fmt.Errorf("could not dry run update of FeatureGate %s: %w", update.original.Name, err)
+			}
+			gateChange.Accepted = false
+			gateChange.Rejection = err.Error()
+		}
+		gateChanges = append(gateChanges, gateChange)
+	}
+	return gateChanges, nil
+}
+
+// featureReferenceChanges returns the Feature references that differ between the original and updated FeatureGate.
+func featureReferenceChanges(update gateUpdate) []FeatureReferenceChange {
+	var refChanges []FeatureReferenceChange
+	for i, ref := range update.updated.Spec.Features {
+		old := update.original.Spec.Features[i]
+		if old == ref {
+			continue
+		}
+		refChanges = append(refChanges, FeatureReferenceChange{
+			Old:           old,
+			New:           ref,
+			VoidsWarranty: !old.PermanentlyVoidAllSupportGuarantees && ref.PermanentlyVoidAllSupportGuarantees,
+		})
+	}
+	return refChanges
+}
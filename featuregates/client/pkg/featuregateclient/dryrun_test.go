@@ -0,0 +1,153 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/internal/testobjects"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/util"
+)
+
+// rejectingClient rejects the patches of FeatureGates like the FeatureGate webhook does.
+type rejectingClient struct {
+	client.Client
+}
+
+func (c *rejectingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if _, ok := obj.(*corev1alpha2.FeatureGate); ok {
+		return apierrors.NewInvalid(corev1alpha2.GroupVersion.WithKind("FeatureGate").GroupKind(), obj.GetName(),
+			field.ErrorList{field.Invalid(field.NewPath("spec").Child("features"), nil, "rejected")})
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func TestDryRunFeatureChanges(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	changes := []FeatureChange{
+		{Name: "bar", Activate: true},
+		{Name: "cloud-event-speaker", Activate: true, WarrantyVoidAllowed: true},
+		{Name: "tuner", Activate: false},
+	}
+
+	t.Run("should report the changes without applying them", func(t *testing.T) {
+		objs, _, _ := testobjects.Get()
+		cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+		featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+		if err != nil {
+			t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+		}
+
+		gateChanges, err := featureGateClient.DryRunFeatureChanges(ctx, changes)
+		if err != nil {
+			t.Fatalf("error not expected, but got error: %v", err)
+		}
+		if len(gateChanges) != 2 || gateChanges[0].FeatureGate != "tanzu-fg" || gateChanges[1].FeatureGate != "tkg-system" {
+			t.Fatalf("got FeatureGate changes: %+v, want changes to tanzu-fg and tkg-system", gateChanges)
+		}
+
+		tuner := gateChanges[0].Features
+		if len(tuner) != 1 || tuner[0].Old.Name != "tuner" || !tuner[0].Old.Activate || tuner[0].New.Activate || tuner[0].VoidsWarranty {
+			t.Errorf("got tanzu-fg changes: %+v, want tuner to be deactivated", tuner)
+		}
+
+		voids := map[string]bool{}
+		for _, change := range gateChanges[1].Features {
+			if change.Old.Activate || !change.New.Activate {
+				t.Errorf("got change: %+v, want Feature to be activated", change)
+			}
+			voids[change.New.Name] = change.VoidsWarranty
+		}
+		if len(voids) != 2 || voids["bar"] || !voids["cloud-event-speaker"] {
+			t.Errorf("got voided warranties: %v, want only cloud-event-speaker to void the warranty", voids)
+		}
+
+		for _, gateChange := range gateChanges {
+			if !gateChange.Accepted {
+				t.Errorf("got FeatureGate %s rejected: %s", gateChange.FeatureGate, gateChange.Rejection)
+			}
+		}
+
+		gates, err := featureGateClient.GetFeatureGateList(ctx)
+		if err != nil {
+			t.Fatalf("unable to get FeatureGate list: %v", err)
+		}
+		for _, name := range []string{"bar", "cloud-event-speaker"} {
+			if _, ref := FeatureRefFromGateList(gates, name); ref.Activate || ref.PermanentlyVoidAllSupportGuarantees {
+				t.Errorf("got Feature reference %+v changed by dry run", ref)
+			}
+		}
+	})
+
+	t.Run("should report FeatureGates the cluster rejects", func(t *testing.T) {
+		objs, _, _ := testobjects.Get()
+		cl := &rejectingClient{Client: crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()}
+		featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+		if err != nil {
+			t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+		}
+
+		gateChanges, err := featureGateClient.DryRunFeatureChanges(ctx, changes)
+		if err != nil {
+			t.Fatalf("error not expected, but got error: %v", err)
+		}
+		for _, gateChange := range gateChanges {
+			if gateChange.Accepted || gateChange.Rejection == "" {
+				t.Errorf("got FeatureGate change %+v accepted, want it rejected", gateChange)
+			}
+		}
+	})
+
+	t.Run("should report the changes when FeatureGates are changed concurrently", func(t *testing.T) {
+		cl := newConcurrentEditClient(1)
+		featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+		if err != nil {
+			t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+		}
+
+		gateChanges, err := featureGateClient.DryRunFeatureChanges(ctx, changes)
+		if err != nil {
+			t.Fatalf("error not expected, but got error: %v", err)
+		}
+		for _, gateChange := range gateChanges {
+			if !gateChange.Accepted {
+				t.Errorf("got FeatureGate %s rejected: %s", gateChange.FeatureGate, gateChange.Rejection)
+			}
+		}
+
+		gates, err := featureGateClient.GetFeatureGateList(ctx)
+		if err != nil {
+			t.Fatalf("unable to get FeatureGate list: %v", err)
+		}
+		for _, name := range []string{"bar", "cloud-event-speaker"} {
+			if _, ref := FeatureRefFromGateList(gates, name); ref.Activate || ref.PermanentlyVoidAllSupportGuarantees {
+				t.Errorf("got Feature reference %+v changed by dry run", ref)
+			}
+		}
+		for _, gateChange := range gateChanges {
+			gate, err := featureGateClient.GetFeatureGate(ctx, gateChange.FeatureGate)
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate %s: %v", gateChange.FeatureGate, err)
+			}
+			if _, found := util.GetFeatureReferenceFromFeatureGate(gate, concurrentReference); !found {
+				t.Errorf("got FeatureGate %s without the concurrently added reference", gate.Name)
+			}
+		}
+	})
+}
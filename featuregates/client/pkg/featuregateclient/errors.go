@@ -3,7 +3,12 @@
 
 package featuregateclient
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
 
 // ErrType is a machine readable value created for facilitating error-matching
 // in tests.
@@ -16,6 +21,10 @@ const (
 	ErrTypeForbidden ErrType = "Forbidden"
 	// ErrTypeTooMany indicates there are too many of a resource.
 	ErrTypeTooMany ErrType = "TooMany"
+	// ErrTypeInvalid indicates a FeatureGate reported its reference to a Feature as invalid.
+	ErrTypeInvalid ErrType = "Invalid"
+	// ErrTypeConflict indicates a FeatureGate kept being changed concurrently.
+	ErrTypeConflict ErrType = "Conflict"
 )
 
 // Error converts a ErrorType into its corresponding canonical error message.
@@ -27,7 +36,134 @@ func (t ErrType) Error() string {
 		return "Forbidden"
 	case ErrTypeTooMany:
 		return "Too many"
+	case ErrTypeInvalid:
+		return "Invalid"
+	case ErrTypeConflict:
+		return "Conflict"
 	default:
 		return fmt.Sprintf("unrecognized validation error: %q", string(t))
 	}
 }
+
+// The errors below carry the details of what went wrong, for errors.As. Each of them also matches the ErrType of its
+// kind with errors.Is.
+
+// NotFoundError indicates a Feature or a FeatureGate does not exist in the cluster. It matches ErrTypeNotFound.
+type NotFoundError struct {
+	// Kind is Feature or FeatureGate.
+	Kind string
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Kind, e.Name)
+}
+
+// Is makes the error match ErrTypeNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrTypeNotFound
+}
+
+// FeatureNotGatedError indicates a Feature is not gated by any FeatureGate, so its activation cannot be set. It matches
+// ErrTypeNotFound.
+type FeatureNotGatedError struct {
+	Feature string
+}
+
+func (e *FeatureNotGatedError) Error() string {
+	return fmt.Sprintf("the Feature %s must exist in one FeatureGate", e.Feature)
+}
+
+// Is makes the error match ErrTypeNotFound.
+func (e *FeatureNotGatedError) Is(target error) bool {
+	return target == ErrTypeNotFound
+}
+
+// MultipleFeatureGatesError indicates a Feature is gated by more than one FeatureGate, so which one sets its activation
+// is ambiguous. It matches ErrTypeTooMany.
+type MultipleFeatureGatesError struct {
+	Feature      string
+	FeatureGates []string
+}
+
+func (e *MultipleFeatureGatesError) Error() string {
+	return fmt.Sprintf("the Feature %s was found in more than one FeatureGate: %s", e.Feature, strings.Join(e.FeatureGates, ", "))
+}
+
+// Is makes the error match ErrTypeTooMany.
+func (e *MultipleFeatureGatesError) Is(target error) bool {
+	return target == ErrTypeTooMany
+}
+
+// ImmutableFeatureError indicates the activation of a Feature cannot be changed, as its stability policy makes it
+// immutable. It matches ErrTypeForbidden.
+type ImmutableFeatureError struct {
+	Feature   string
+	Stability corev1alpha2.StabilityLevel
+	Policy    corev1alpha2.Policy
+}
+
+func (e *ImmutableFeatureError) Error() string {
+	return fmt.Sprintf("activation setting for Feature %s cannot be toggled as its stability level is %s", e.Feature, e.Stability)
+}
+
+// Is makes the error match ErrTypeForbidden.
+func (e *ImmutableFeatureError) Is(target error) bool {
+	return target == ErrTypeForbidden
+}
+
+// WarrantyConsentError indicates changing the activation of a Feature permanently voids all support guarantees for the
+// environment, as its stability policy dictates, and the user has not consented to it. It matches ErrTypeForbidden.
+type WarrantyConsentError struct {
+	Feature     string
+	FeatureGate string
+	Stability   corev1alpha2.StabilityLevel
+	Policy      corev1alpha2.Policy
+}
+
+func (e *WarrantyConsentError) Error() string {
+	return fmt.Sprintf("warranty will be voided with new activation set point of %s Feature %s, but user has not given express permission to void the warranty", e.Stability, e.Feature)
+}
+
+// Is makes the error match ErrTypeForbidden.
+func (e *WarrantyConsentError) Is(target error) bool {
+	return target == ErrTypeForbidden
+}
+
+// InvalidReferenceError indicates a FeatureGate reported its reference to a Feature as invalid. It matches
+// ErrTypeInvalid.
+type InvalidReferenceError struct {
+	Feature     string
+	FeatureGate string
+	// Message is the reason the FeatureGate gives.
+	Message string
+}
+
+func (e *InvalidReferenceError) Error() string {
+	return fmt.Sprintf("FeatureGate %s reports its reference to Feature %s as invalid: %s", e.FeatureGate, e.Feature, e.Message)
+}
+
+// Is makes the error match ErrTypeInvalid.
+func (e *InvalidReferenceError) Is(target error) bool {
+	return target == ErrTypeInvalid
+}
+
+// ConflictError indicates a FeatureGate could not be changed, as it kept being changed concurrently. It matches
+// ErrTypeConflict, and unwraps to the conflict error of the API server.
+type ConflictError struct {
+	FeatureGate string
+	Err         error
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("FeatureGate %s kept being changed concurrently: %v", e.FeatureGate, e.Err)
+}
+
+// Is makes the error match ErrTypeConflict.
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrTypeConflict
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
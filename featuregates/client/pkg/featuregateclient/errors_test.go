@@ -0,0 +1,145 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/internal/testobjects"
+)
+
+func TestStructuredErrors(t *testing.T) {
+	tests := []struct {
+		description string
+		toggle      func(context.Context, *FeatureGateClient) error
+		wantTarget  interface{}
+		want        interface{}
+		wantErrType ErrType
+	}{
+		{
+			description: "should report a Feature that does not exist",
+			toggle: func(ctx context.Context, c *FeatureGateClient) error {
+				_, err := c.ActivateFeature(ctx, "missing", false)
+				return err
+			},
+			wantTarget:  new(*NotFoundError),
+			want:        &NotFoundError{Kind: "Feature", Name: "missing"},
+			wantErrType: ErrTypeNotFound,
+		},
+		{
+			description: "should report a Feature that is not gated",
+			toggle: func(ctx context.Context, c *FeatureGateClient) error {
+				_, err := c.ActivateFeature(ctx, "ungated", false)
+				return err
+			},
+			wantTarget:  new(*FeatureNotGatedError),
+			want:        &FeatureNotGatedError{Feature: "ungated"},
+			wantErrType: ErrTypeNotFound,
+		},
+		{
+			description: "should report the consent needed to void the warranty",
+			toggle: func(ctx context.Context, c *FeatureGateClient) error {
+				_, err := c.ActivateFeature(ctx, "experiment", false)
+				return err
+			},
+			wantTarget: new(*WarrantyConsentError),
+			want: &WarrantyConsentError{
+				Feature:     "experiment",
+				FeatureGate: "policies",
+				Stability:   corev1alpha2.Experimental,
+				Policy:      corev1alpha2.GetPolicyForStabilityLevel(corev1alpha2.Experimental),
+			},
+			wantErrType: ErrTypeForbidden,
+		},
+		{
+			description: "should report an immutable Feature",
+			toggle: func(ctx context.Context, c *FeatureGateClient) error {
+				_, err := c.DeactivateFeature(ctx, "stable")
+				return err
+			},
+			wantTarget: new(*ImmutableFeatureError),
+			want: &ImmutableFeatureError{
+				Feature:   "stable",
+				Stability: corev1alpha2.Stable,
+				Policy:    corev1alpha2.GetPolicyForStabilityLevel(corev1alpha2.Stable),
+			},
+			wantErrType: ErrTypeForbidden,
+		},
+		{
+			description: "should report the FeatureGate name of a missing FeatureGate",
+			toggle: func(ctx context.Context, c *FeatureGateClient) error {
+				_, err := c.GetFeatureGate(ctx, "missing")
+				return err
+			},
+			wantTarget:  new(*NotFoundError),
+			want:        &NotFoundError{Kind: "FeatureGate", Name: "missing"},
+			wantErrType: ErrTypeNotFound,
+		},
+	}
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+			defer cancel()
+
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(testobjects.Policies()...).Build()
+			featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+			}
+
+			err = tc.toggle(ctx, featureGateClient)
+			if !errors.Is(err, tc.wantErrType) {
+				t.Errorf("got error: %v, want: %v", err, tc.wantErrType)
+			}
+			if !errors.As(err, tc.wantTarget) {
+				t.Fatalf("got error: %v, want it to be a %T", err, tc.want)
+			}
+			if got := reflect.ValueOf(tc.wantTarget).Elem().Interface(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got error details: %+v, want: %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMultipleFeatureGatesError(t *testing.T) {
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	objs, _, _ := testobjects.Get()
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	_, err = featureGateClient.ActivateFeature(ctx, "baz", false)
+	if !errors.Is(err, ErrTypeTooMany) {
+		t.Errorf("got error: %v, want: %v", err, ErrTypeTooMany)
+	}
+	var tooMany *MultipleFeatureGatesError
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("got error: %v, want a MultipleFeatureGatesError", err)
+	}
+	if tooMany.Feature != "baz" || len(tooMany.FeatureGates) != 2 {
+		t.Errorf("got error details: %+v, want Feature baz gated by two FeatureGates", tooMany)
+	}
+}
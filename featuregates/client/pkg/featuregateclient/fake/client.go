@@ -0,0 +1,384 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/util"
+)
+
+// FeatureGateClient is an in-memory implementation of featuregateclient.FeatureGateInterface for unit tests. It behaves
+// like a cluster running the FeatureGate webhook and the Feature controller: FeatureGate changes that violate the
+// stability policies of their Features are rejected, and after every change the Feature statuses and the FeatureGate
+// results are set from the stability policies, as the controller does.
+type FeatureGateClient struct {
+	mu       sync.Mutex
+	features map[string]*corev1alpha2.Feature
+	gates    map[string]*corev1alpha2.FeatureGate
+}
+
+var _ featuregateclient.FeatureGateInterface = &FeatureGateClient{}
+
+// NewFeatureGateClient returns a FeatureGateClient holding the Features and FeatureGates among the objects,
+// e.g. those of GetTestObjects. Other objects are ignored. The objects are copied, and reconciled like a cluster
+// would reconcile them.
+func NewFeatureGateClient(objs ...runtime.Object) *FeatureGateClient {
+	f := &FeatureGateClient{
+		features: map[string]*corev1alpha2.Feature{},
+		gates:    map[string]*corev1alpha2.FeatureGate{},
+	}
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *corev1alpha2.Feature:
+			f.features[o.Name] = o.DeepCopy()
+		case *corev1alpha2.FeatureGate:
+			f.gates[o.Name] = o.DeepCopy()
+		}
+	}
+	f.reconcile()
+	return f
+}
+
+// GetFeature returns the Feature.
+func (f *FeatureGateClient) GetFeature(_ context.Context, featureName string) (*corev1alpha2.Feature, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	feature, ok := f.features[featureName]
+	if !ok {
+		return nil, &featuregateclient.NotFoundError{Kind: "Feature", Name: featureName}
+	}
+	return feature.DeepCopy(), nil
+}
+
+// GetFeatureList returns the Features, sorted by name. Label selectors narrow down the Features returned; other list
+// options are not supported.
+func (f *FeatureGateClient) GetFeatureList(_ context.Context, opts ...client.ListOption) (*corev1alpha2.FeatureList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if listOpts.FieldSelector != nil || listOpts.Namespace != "" || listOpts.Limit != 0 || listOpts.Continue != "" {
+		return nil, fmt.Errorf("could not get features: only label selectors are supported by the fake client")
+	}
+
+	features := &corev1alpha2.FeatureList{}
+	for _, name := range sets.StringKeySet(f.features).List() {
+		feature := f.features[name]
+		if listOpts.LabelSelector != nil && !listOpts.LabelSelector.Matches(labels.Set(feature.Labels)) {
+			continue
+		}
+		features.Items = append(features.Items, *feature.DeepCopy())
+	}
+	return features, nil
+}
+
+// GetFeatureGate returns the FeatureGate.
+func (f *FeatureGateClient) GetFeatureGate(_ context.Context, featureGateName string) (*corev1alpha2.FeatureGate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	gate, ok := f.gates[featureGateName]
+	if !ok {
+		return nil, &featuregateclient.NotFoundError{Kind: "FeatureGate", Name: featureGateName}
+	}
+	return gate.DeepCopy(), nil
+}
+
+// GetFeatureGateList returns the FeatureGates, sorted by name.
+func (f *FeatureGateClient) GetFeatureGateList(_ context.Context) (*corev1alpha2.FeatureGateList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.featureGateList(), nil
+}
+
+// ActivateFeature activates a Feature with the same checks as featuregateclient.FeatureGateClient.ActivateFeature.
+func (f *FeatureGateClient) ActivateFeature(_ context.Context, featureName string, warrantyVoidAllowed bool) (*featuregateclient.ActivationResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	feature, ok := f.features[featureName]
+	if !ok {
+		return nil, fmt.Errorf("could not get Feature %s: %w", featureName, &featuregateclient.NotFoundError{Kind: "Feature", Name: featureName})
+	}
+
+	gates := f.featureGateList()
+	gateName, featRef := featuregateclient.FeatureRefFromGateList(gates, featureName)
+	result := &featuregateclient.ActivationResult{FeatureGate: gateName, Feature: featureName, PreviousActivate: featRef.Activate, Activate: true}
+
+	if featRef.Activate {
+		result.NoOp = true
+		return result, nil
+	}
+
+	if err := validateFeatureActivationToggle(gates, feature); err != nil {
+		return nil, err
+	}
+
+	voidWarranty, err := setVoidWarrantyChecksPass(gateName, featRef, feature, warrantyVoidAllowed)
+	if err != nil {
+		return nil, err
+	}
+	result.WarrantyVoided = voidWarranty
+
+	ref := corev1alpha2.FeatureReference{Name: featureName, Activate: true, PermanentlyVoidAllSupportGuarantees: voidWarranty}
+	if err := f.updateFeatureGate(gateName, ref); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeactivateFeature deactivates a Feature with the same checks as featuregateclient.FeatureGateClient.DeactivateFeature.
+func (f *FeatureGateClient) DeactivateFeature(_ context.Context, featureName string) (*featuregateclient.ActivationResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	feature, ok := f.features[featureName]
+	if !ok {
+		return nil, fmt.Errorf("could not get Feature %s: %w", featureName, &featuregateclient.NotFoundError{Kind: "Feature", Name: featureName})
+	}
+
+	gates := f.featureGateList()
+	gateName, featRef := featuregateclient.FeatureRefFromGateList(gates, featureName)
+	result := &featuregateclient.ActivationResult{FeatureGate: gateName, Feature: featureName, PreviousActivate: featRef.Activate, Activate: false}
+
+	if gateName != "" && !featRef.Activate {
+		result.NoOp = true
+		return result, nil
+	}
+
+	if err := validateFeatureActivationToggle(gates, feature); err != nil {
+		return nil, err
+	}
+
+	if err := f.updateFeatureGate(gateName, corev1alpha2.FeatureReference{Name: featureName, Activate: false}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// featureGateList returns copies of the FeatureGates, sorted by name.
+func (f *FeatureGateClient) featureGateList() *corev1alpha2.FeatureGateList {
+	gates := &corev1alpha2.FeatureGateList{}
+	for _, name := range sets.StringKeySet(f.gates).List() {
+		gates.Items = append(gates.Items, *f.gates[name].DeepCopy())
+	}
+	return gates
+}
+
+// updateFeatureGate sets the reference in the FeatureGate if the webhook admits the change, and reconciles the
+// Features.
+func (f *FeatureGateClient) updateFeatureGate(featureGateName string, ref corev1alpha2.FeatureReference) error {
+	old, ok := f.gates[featureGateName]
+	if !ok {
+		return &featuregateclient.NotFoundError{Kind: "FeatureGate", Name: featureGateName}
+	}
+	gate := old.DeepCopy()
+	found := false
+	for i := range gate.Spec.Features {
+		if gate.Spec.Features[i].Name == ref.Name {
+			gate.Spec.Features[i].Activate = ref.Activate
+			if ref.PermanentlyVoidAllSupportGuarantees {
+				gate.Spec.Features[i].PermanentlyVoidAllSupportGuarantees = true
+			}
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("could not set Feature %s as it was not found in FeatureGate %s: %w", ref.Name, gate.Name, featuregateclient.ErrTypeNotFound)
+	}
+	if err := f.validateFeatureGateUpdate(old, gate); err != nil {
+		return err
+	}
+	f.gates[featureGateName] = gate
+	f.reconcile()
+	return nil
+}
+
+// validateFeatureGateUpdate rejects the FeatureGate update the way the FeatureGate webhook does.
+func (f *FeatureGateClient) validateFeatureGateUpdate(old, gate *corev1alpha2.FeatureGate) error {
+	var missing, conflicting, voidOverridden, voidingWarranty, immutable []string
+	for _, ref := range gate.Spec.Features {
+		for name, other := range f.gates {
+			if _, found := util.GetFeatureReferenceFromFeatureGate(other, ref.Name); found && name != gate.Name {
+				conflicting = append(conflicting, ref.Name)
+				break
+			}
+		}
+		if oldRef, found := util.GetFeatureReferenceFromFeatureGate(old, ref.Name); found &&
+			oldRef.PermanentlyVoidAllSupportGuarantees && !ref.PermanentlyVoidAllSupportGuarantees {
+			voidOverridden = append(voidOverridden, ref.Name)
+		}
+		feature, ok := f.features[ref.Name]
+		if !ok {
+			missing = append(missing, ref.Name)
+			continue
+		}
+		policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability)
+		if policy.VoidsWarranty && !ref.PermanentlyVoidAllSupportGuarantees && policy.DefaultActivation != ref.Activate {
+			voidingWarranty = append(voidingWarranty, ref.Name)
+		}
+		if policy.Immutable && policy.DefaultActivation != ref.Activate {
+			immutable = append(immutable, ref.Name)
+		}
+	}
+
+	featuresPath := field.NewPath("spec").Child("features")
+	var allErrors field.ErrorList
+	if len(missing) > 0 {
+		allErrors = append(allErrors, field.Invalid(featuresPath, gate.Spec.Features,
+			fmt.Sprintf("some features in the FeatureGate spec do not exist in cluster: %v", sets.NewString(missing...).List())))
+	}
+	if len(conflicting) > 0 {
+		allErrors = append(allErrors, field.Invalid(featuresPath, gate.Spec.Features,
+			fmt.Sprintf("features %v cannot be gated by multiple featuregates", sets.NewString(conflicting...).List())))
+	}
+	if len(voidOverridden) > 0 {
+		allErrors = append(allErrors, field.Invalid(featuresPath, gate.Spec.Features,
+			fmt.Sprintf("cannot toggle features due to policy violation: %v", sets.NewString(voidOverridden...).List())))
+	}
+	if len(voidingWarranty) > 0 {
+		allErrors = append(allErrors, field.Invalid(featuresPath, gate.Spec.Features,
+			fmt.Sprintf("cannot toggle features %v as the stability level of the features indicate that it should not be "+
+				"activated in production environments", sets.NewString(voidingWarranty...).List())))
+	}
+	if len(immutable) > 0 {
+		allErrors = append(allErrors, field.Invalid(featuresPath, gate.Spec.Features,
+			fmt.Sprintf("cannot toggle immutable features: %v", sets.NewString(immutable...).List())))
+	}
+	if len(allErrors) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(corev1alpha2.GroupVersion.WithKind("FeatureGate").GroupKind(), gate.Name, allErrors)
+}
+
+// reconcile sets the Feature statuses and the FeatureGate results the way the Feature controller does. A Feature
+// referenced by a FeatureGate takes the activation of the reference if its stability policy allows it, and its default
+// activation otherwise. A Feature that is not referenced takes its default activation.
+func (f *FeatureGateClient) reconcile() {
+	gateNames := sets.StringKeySet(f.gates).List()
+	for _, featureName := range sets.StringKeySet(f.features).List() {
+		feature := f.features[featureName]
+		policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability)
+		feature.Status.Activated = policy.DefaultActivation
+
+		var gated bool
+		for _, gateName := range gateNames {
+			gate := f.gates[gateName]
+			ref, found := util.GetFeatureReferenceFromFeatureGate(gate, featureName)
+			if !found {
+				removeFeatureReferenceResult(gate, featureName)
+				continue
+			}
+			if gated {
+				// The controller only reports the result in the first FeatureGate gating the Feature.
+				continue
+			}
+			gated = true
+			result := corev1alpha2.FeatureReferenceResult{
+				Name:    featureName,
+				Status:  corev1alpha2.AppliedReferenceStatus,
+				Message: "Feature has been successfully toggled",
+			}
+			switch {
+			case policy.Immutable && policy.DefaultActivation != ref.Activate:
+				result.Status = corev1alpha2.InvalidReferenceStatus
+				result.Message = "Feature could not be toggled because it is immutable"
+			case policy.VoidsWarranty && !ref.PermanentlyVoidAllSupportGuarantees && policy.DefaultActivation != ref.Activate:
+				result.Status = corev1alpha2.InvalidReferenceStatus
+				result.Message = "The stability level of this feature indicates that it should not be activated in " +
+					"production environments. To activate the feature, you must agree to permanently void all support " +
+					"guarantees for this environment by setting featureRef.permanentlyVoidAllSupportGuarantees to true."
+			default:
+				feature.Status.Activated = ref.Activate
+			}
+			setFeatureReferenceResult(gate, result)
+		}
+	}
+
+	for _, gateName := range gateNames {
+		gate := f.gates[gateName]
+		for _, ref := range gate.Spec.Features {
+			if _, ok := f.features[ref.Name]; !ok {
+				setFeatureReferenceResult(gate, corev1alpha2.FeatureReferenceResult{
+					Name:    ref.Name,
+					Status:  corev1alpha2.InvalidReferenceStatus,
+					Message: "Feature does not exist in cluster",
+				})
+			}
+		}
+	}
+}
+
+// validateFeatureActivationToggle ensures the Feature is gated by exactly one FeatureGate and its stability policy allows
+// changing its activation, as FeatureGateClient does.
+func validateFeatureActivationToggle(gates *corev1alpha2.FeatureGateList, feature *corev1alpha2.Feature) error {
+	var gateNames []string
+	for i := range gates.Items {
+		if _, found := util.GetFeatureReferenceFromFeatureGate(&gates.Items[i], feature.Name); found {
+			gateNames = append(gateNames, gates.Items[i].Name)
+		}
+	}
+	if len(gateNames) > 1 {
+		return fmt.Errorf("could not validate Feature changing activation set point: %w",
+			&featuregateclient.MultipleFeatureGatesError{Feature: feature.Name, FeatureGates: gateNames})
+	}
+	if len(gateNames) == 0 {
+		return fmt.Errorf("could not validate Feature changing activation set point: %w",
+			&featuregateclient.FeatureNotGatedError{Feature: feature.Name})
+	}
+
+	policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability)
+	if policy.Immutable {
+		return fmt.Errorf("could not validate Feature changing activation set point: %w",
+			&featuregateclient.ImmutableFeatureError{Feature: feature.Name, Stability: feature.Spec.Stability, Policy: policy})
+	}
+	return nil
+}
+
+// setVoidWarrantyChecksPass returns whether activating the Feature voids its warranty, and an error if it does but
+// voiding the warranty is not allowed, as FeatureGateClient does.
+func setVoidWarrantyChecksPass(featureGateName string, ref corev1alpha2.FeatureReference, feature *corev1alpha2.Feature, warrantyVoidAllowed bool) (bool, error) {
+	policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability)
+	if !policy.VoidsWarranty || ref.PermanentlyVoidAllSupportGuarantees || policy.DefaultActivation != ref.Activate {
+		return false, nil
+	}
+	if !warrantyVoidAllowed {
+		return false, &featuregateclient.WarrantyConsentError{Feature: feature.Name, FeatureGate: featureGateName, Stability: feature.Spec.Stability, Policy: policy}
+	}
+	return true, nil
+}
+
+func setFeatureReferenceResult(gate *corev1alpha2.FeatureGate, result corev1alpha2.FeatureReferenceResult) {
+	for i := range gate.Status.FeatureReferenceResults {
+		if gate.Status.FeatureReferenceResults[i].Name == result.Name {
+			gate.Status.FeatureReferenceResults[i] = result
+			return
+		}
+	}
+	gate.Status.FeatureReferenceResults = append(gate.Status.FeatureReferenceResults, result)
+}
+
+func removeFeatureReferenceResult(gate *corev1alpha2.FeatureGate, featureName string) {
+	for i := range gate.Status.FeatureReferenceResults {
+		if gate.Status.FeatureReferenceResults[i].Name == featureName {
+			gate.Status.FeatureReferenceResults = append(gate.Status.FeatureReferenceResults[:i], gate.Status.FeatureReferenceResults[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/internal/testobjects"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/util"
+)
+
+const contextTimeout = 30 * time.Second
+
+// TestFeatureGateClientMatchesFeatureGateClient checks the fake returns what featuregateclient.FeatureGateClient
+// returns.
+func TestFeatureGateClientMatchesFeatureGateClient(t *testing.T) {
+	activate := func(name string, warrantyVoidAllowed bool) func(context.Context, featuregateclient.FeatureGateInterface) (*featuregateclient.ActivationResult, error) {
+		return func(ctx context.Context, c featuregateclient.FeatureGateInterface) (*featuregateclient.ActivationResult, error) {
+			return c.ActivateFeature(ctx, name, warrantyVoidAllowed)
+		}
+	}
+	deactivate := func(name string) func(context.Context, featuregateclient.FeatureGateInterface) (*featuregateclient.ActivationResult, error) {
+		return func(ctx context.Context, c featuregateclient.FeatureGateInterface) (*featuregateclient.ActivationResult, error) {
+			return c.DeactivateFeature(ctx, name)
+		}
+	}
+
+	tests := []struct {
+		description string
+		toggle      func(context.Context, featuregateclient.FeatureGateInterface) (*featuregateclient.ActivationResult, error)
+		want        *featuregateclient.ActivationResult
+		wantErr     error
+	}{
+		{
+			description: "should activate a technical preview Feature",
+			toggle:      activate("preview", false),
+			want:        &featuregateclient.ActivationResult{FeatureGate: "policies", Feature: "preview", Activate: true},
+		},
+		{
+			description: "should not activate an experimental Feature without voiding the warranty",
+			toggle:      activate("experiment", false),
+			wantErr:     featuregateclient.ErrTypeForbidden,
+		},
+		{
+			description: "should activate an experimental Feature voiding the warranty",
+			toggle:      activate("experiment", true),
+			want:        &featuregateclient.ActivationResult{FeatureGate: "policies", Feature: "experiment", Activate: true, WarrantyVoided: true},
+		},
+		{
+			description: "should not change an activated Feature",
+			toggle:      activate("stable", false),
+			want:        &featuregateclient.ActivationResult{FeatureGate: "policies", Feature: "stable", PreviousActivate: true, Activate: true, NoOp: true},
+		},
+		{
+			description: "should not deactivate an immutable Feature",
+			toggle:      deactivate("stable"),
+			wantErr:     featuregateclient.ErrTypeForbidden,
+		},
+		{
+			description: "should deactivate a deprecated Feature",
+			toggle:      deactivate("deprecated"),
+			want:        &featuregateclient.ActivationResult{FeatureGate: "policies", Feature: "deprecated", PreviousActivate: true},
+		},
+		{
+			description: "should not change a deactivated Feature",
+			toggle:      deactivate("wip"),
+			want:        &featuregateclient.ActivationResult{FeatureGate: "policies", Feature: "wip", NoOp: true},
+		},
+		{
+			description: "should not activate a Feature that is not gated",
+			toggle:      activate("ungated", false),
+			wantErr:     featuregateclient.ErrTypeNotFound,
+		},
+		{
+			description: "should not activate a Feature that does not exist",
+			toggle:      activate("missing", false),
+			wantErr:     featuregateclient.ErrTypeNotFound,
+		},
+	}
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	clients := map[string]func() featuregateclient.FeatureGateInterface{
+		"featuregateclient.FeatureGateClient": func() featuregateclient.FeatureGateInterface {
+			cl := crclient.NewClientBuilder().WithRuntimeObjects(testobjects.Policies()...).Build()
+			featureGateClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+			}
+			return featureGateClient
+		},
+		"fake.FeatureGateClient": func() featuregateclient.FeatureGateInterface {
+			return NewFeatureGateClient(testobjects.Policies()...)
+		},
+	}
+
+	for _, tc := range tests {
+		for clientName, newClient := range clients {
+			t.Run(clientName+" "+tc.description, func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+				defer cancel()
+
+				got, err := tc.toggle(ctx, newClient())
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+				}
+				if tc.want != nil && (got == nil || *got != *tc.want) {
+					t.Errorf("got result: %+v, want: %+v", got, tc.want)
+				}
+			})
+		}
+	}
+}
+
+func TestFeatureGateClientReconciles(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	objs := append(testobjects.Policies(), &corev1alpha2.FeatureGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+		Spec:       corev1alpha2.FeatureGateSpec{Features: []corev1alpha2.FeatureReference{{Name: "missing"}}},
+	})
+	fakeClient := NewFeatureGateClient(objs...)
+
+	wantActivated := func(featureName string, want bool) {
+		t.Helper()
+		feature, err := fakeClient.GetFeature(ctx, featureName)
+		if err != nil {
+			t.Fatalf("unable to get Feature: %v", err)
+		}
+		if feature.Status.Activated != want {
+			t.Errorf("got Feature %s activated: %t, want: %t", featureName, feature.Status.Activated, want)
+		}
+	}
+	wantResult := func(featureGateName, featureName string, want corev1alpha2.FeatureReferenceStatus) {
+		t.Helper()
+		gate, err := fakeClient.GetFeatureGate(ctx, featureGateName)
+		if err != nil {
+			t.Fatalf("unable to get FeatureGate: %v", err)
+		}
+		for _, result := range gate.Status.FeatureReferenceResults {
+			if result.Name == featureName {
+				if result.Status != want {
+					t.Errorf("got result for Feature %s: %+v, want status: %s", featureName, result, want)
+				}
+				return
+			}
+		}
+		t.Errorf("got no result for Feature %s in FeatureGate %s, want status: %s", featureName, featureGateName, want)
+	}
+
+	wantActivated("stable", true)
+	wantActivated("experiment", false)
+	wantActivated("ungated", false)
+	wantResult("policies", "stable", corev1alpha2.AppliedReferenceStatus)
+	wantResult("invalid", "missing", corev1alpha2.InvalidReferenceStatus)
+
+	if _, err := fakeClient.ActivateFeature(ctx, "experiment", true); err != nil {
+		t.Fatalf("unable to activate Feature: %v", err)
+	}
+	wantActivated("experiment", true)
+	wantResult("policies", "experiment", corev1alpha2.AppliedReferenceStatus)
+
+	if _, err := fakeClient.DeactivateFeature(ctx, "deprecated"); err != nil {
+		t.Fatalf("unable to deactivate Feature: %v", err)
+	}
+	wantActivated("deprecated", false)
+}
+
+func TestFeatureGateClientWebhook(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	// The baz and bazzies Features of the test objects are gated by two FeatureGates, so the webhook rejects any change
+	// to those FeatureGates.
+	objs, _, _ := GetTestObjects()
+	fakeClient := NewFeatureGateClient(objs...)
+
+	_, err := fakeClient.ActivateFeature(ctx, "tuna", false)
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("got error: %v, want the FeatureGate change to be rejected", err)
+	}
+	gate, err := fakeClient.GetFeatureGate(ctx, "tanzu-fg")
+	if err != nil {
+		t.Fatalf("unable to get FeatureGate: %v", err)
+	}
+	if ref, _ := util.GetFeatureReferenceFromFeatureGate(gate, "tuna"); ref.Activate {
+		t.Error("got Feature tuna activated by a rejected change")
+	}
+}
+
+func TestFeatureGateClientGetFeatureList(t *testing.T) {
+	fakeClient := NewFeatureGateClient(testobjects.Policies()...)
+
+	features, err := fakeClient.GetFeatureList(context.Background(), client.MatchingLabels{"stability": string(corev1alpha2.TechnicalPreview)})
+	if err != nil {
+		t.Fatalf("unable to get Feature list: %v", err)
+	}
+	var got []string
+	for i := range features.Items {
+		got = append(got, features.Items[i].Name)
+	}
+	if len(got) != 2 || got[0] != "preview" || got[1] != "ungated" {
+		t.Errorf("got Features: %v, want: [preview ungated]", got)
+	}
+
+	if _, err := fakeClient.GetFeatureList(context.Background(), client.MatchingFields{"metadata.name": "preview"}); err == nil {
+		t.Error("got no error for a field selector")
+	}
+}
@@ -1,5 +1,5 @@
 // Copyright 2021 VMware, Inc. All Rights Reserved.
 // SPDX-License-Identifier: Apache-2.0
 
-// Package fake provides data needed for testing
+// Package fake provides data and an in-memory FeatureGate client needed for testing
 package fake
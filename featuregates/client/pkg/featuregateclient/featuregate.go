@@ -0,0 +1,128 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// CreateFeatureGate creates a FeatureGate that does not gate any Feature yet.
+func (f *FeatureGateClient) CreateFeatureGate(ctx context.Context, featureGateName string) error {
+	gate := &corev1alpha2.FeatureGate{ObjectMeta: metav1.ObjectMeta{Name: featureGateName}}
+	if err := f.crClient.Create(ctx, gate); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("could not create FeatureGate %s as it already exists: %w", featureGateName, ErrTypeTooMany)
+		}
+		return fmt.Errorf("could not create FeatureGate %s: %w", featureGateName, err)
+	}
+	return nil
+}
+
+// DeleteFeatureGate deletes a FeatureGate. The Features it gates return to the default activation of their stability
+// level. As voided support guarantees cannot be restored, a FeatureGate with a reference that voided them cannot be
+// deleted.
+func (f *FeatureGateClient) DeleteFeatureGate(ctx context.Context, featureGateName string) error {
+	gate, err := f.GetFeatureGate(ctx, featureGateName)
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGate %s: %w", featureGateName, err)
+	}
+
+	for _, ref := range gate.Spec.Features {
+		if ref.PermanentlyVoidAllSupportGuarantees {
+			return fmt.Errorf("could not delete FeatureGate %s as its reference to Feature %s permanently voided all support guarantees: %w", featureGateName, ref.Name, ErrTypeForbidden)
+		}
+	}
+
+	if err := f.crClient.Delete(ctx, gate); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("could not delete FeatureGate %s: %w", featureGateName, ErrTypeNotFound)
+		}
+		return fmt.Errorf("could not delete FeatureGate %s: %w", featureGateName, err)
+	}
+	return nil
+}
+
+// AddFeatureReference adds a reference to a Feature to a FeatureGate, with the given activation intent. The Feature must
+// exist in the cluster and must not be gated by any FeatureGate yet. Activation intents other than the default of an
+// immutable Feature are forbidden.
+// Warning: Before sending `true` via the warrantyVoidAllowed function argument, ensure explicit user awareness and
+// approval if the activation intent will cause the support warranty to be void. Once warranty is void, it is permanent
+// for the environment.
+func (f *FeatureGateClient) AddFeatureReference(ctx context.Context, featureGateName, featureName string, activate, warrantyVoidAllowed bool) error {
+	// A Feature must exist in the cluster to be gated.
+	feature, err := f.GetFeature(ctx, featureName)
+	if err != nil {
+		return fmt.Errorf("could not get Feature %s: %w", featureName, err)
+	}
+
+	gates, err := f.GetFeatureGateList(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get FeatureGateList: %w", err)
+	}
+
+	if gateName, _ := FeatureRefFromGateList(gates, featureName); gateName != "" {
+		return fmt.Errorf("could not add Feature %s to FeatureGate %s as it is already gated by FeatureGate %s: %w", featureName, featureGateName, gateName, ErrTypeTooMany)
+	}
+
+	if gateFromList(gates, featureGateName) == nil {
+		return fmt.Errorf("could not get FeatureGate %s: %w", featureGateName, ErrTypeNotFound)
+	}
+
+	policy := corev1alpha2.GetPolicyForStabilityLevel(feature.Spec.Stability)
+	ref := corev1alpha2.FeatureReference{Name: featureName, Activate: policy.DefaultActivation}
+	if activate != policy.DefaultActivation {
+		if err := featureActivationToggleAllowed(feature); err != nil {
+			return fmt.Errorf("could not add Feature %s to FeatureGate %s: %w", featureName, featureGateName, err)
+		}
+		voidWarranty, err := setVoidWarrantyChecksPass(featureGateName, ref, feature, warrantyVoidAllowed)
+		if err != nil {
+			return fmt.Errorf("could not add Feature %s to FeatureGate %s: %w", featureName, featureGateName, err)
+		}
+		ref.Activate = activate
+		ref.PermanentlyVoidAllSupportGuarantees = voidWarranty
+	}
+
+	err = f.patchFeatureGate(ctx, featureGateName, func(gate *corev1alpha2.FeatureGate) error {
+		for i := range gate.Spec.Features {
+			if gate.Spec.Features[i].Name == featureName {
+				return fmt.Errorf("could not add Feature %s to FeatureGate %s as it is already gated by it: %w", featureName, featureGateName, ErrTypeTooMany)
+			}
+		}
+		gate.Spec.Features = append(gate.Spec.Features, ref)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not update FeatureGate %s: %w", featureGateName, err)
+	}
+	return nil
+}
+
+// RemoveFeatureReference removes the reference to a Feature from a FeatureGate, and the Feature returns to the default
+// activation of its stability level. As voided support guarantees cannot be restored, a reference that voided them
+// cannot be removed.
+func (f *FeatureGateClient) RemoveFeatureReference(ctx context.Context, featureGateName, featureName string) error {
+	err := f.patchFeatureGate(ctx, featureGateName, func(gate *corev1alpha2.FeatureGate) error {
+		for i, ref := range gate.Spec.Features {
+			if ref.Name != featureName {
+				continue
+			}
+			if ref.PermanentlyVoidAllSupportGuarantees {
+				return fmt.Errorf("could not remove Feature %s from FeatureGate %s as its reference permanently voided all support guarantees: %w", featureName, featureGateName, ErrTypeForbidden)
+			}
+			gate.Spec.Features = append(gate.Spec.Features[:i], gate.Spec.Features[i+1:]...)
+			return nil
+		}
+		return fmt.Errorf("could not remove Feature %s as it was not found in FeatureGate %s: %w", featureName, featureGateName, ErrTypeNotFound)
+	})
+	if err != nil {
+		return fmt.Errorf("could not update FeatureGate %s: %w", featureGateName, err)
+	}
+	return nil
+}
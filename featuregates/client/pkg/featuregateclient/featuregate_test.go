@@ -0,0 +1,259 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/internal/testobjects"
+)
+
+// newManagementTestClient returns a FeatureGateClient for the fake objects, with specialized-toaster and an
+// experimental Feature that are not gated by any FeatureGate.
+func newManagementTestClient(t *testing.T) *FeatureGateClient {
+	objs, features, _ := testobjects.Get()
+	objs = append(objs, features["specialized-toaster"], &corev1alpha2.Feature{
+		ObjectMeta: metav1.ObjectMeta{Name: "ungated-experiment"},
+		Spec:       corev1alpha2.FeatureSpec{Stability: corev1alpha2.Experimental},
+	})
+
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+	return featureGateClient
+}
+
+func TestCreateAndDeleteFeatureGate(t *testing.T) {
+	tests := []struct {
+		description string
+		create      string
+		delete      string
+		wantErr     error
+	}{
+		{
+			description: "should create a FeatureGate",
+			create:      "new-fg",
+		},
+		{
+			description: "should not create a FeatureGate that exists",
+			create:      "tanzu-fg",
+			wantErr:     ErrTypeTooMany,
+		},
+		{
+			description: "should delete a FeatureGate",
+			delete:      "tanzu-fg",
+		},
+		{
+			description: "should not delete a FeatureGate that does not exist",
+			delete:      "not-a-fg",
+			wantErr:     ErrTypeNotFound,
+		},
+		{
+			description: "should not delete a FeatureGate with a voided warranty",
+			delete:      "tkg-system",
+			wantErr:     ErrTypeForbidden,
+		},
+	}
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+			defer cancel()
+
+			featureGateClient := newManagementTestClient(t)
+
+			var err error
+			if tc.create != "" {
+				err = featureGateClient.CreateFeatureGate(ctx, tc.create)
+			} else {
+				err = featureGateClient.DeleteFeatureGate(ctx, tc.delete)
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			_, err = featureGateClient.GetFeatureGate(ctx, tc.create+tc.delete)
+			if tc.create != "" && err != nil {
+				t.Errorf("got error getting created FeatureGate: %v", err)
+			}
+			if tc.delete != "" && !errors.Is(err, ErrTypeNotFound) {
+				t.Errorf("got error getting deleted FeatureGate: %v, want: %v", err, ErrTypeNotFound)
+			}
+		})
+	}
+}
+
+func TestAddFeatureReference(t *testing.T) {
+	tests := []struct {
+		description         string
+		gate                string
+		feature             string
+		activate            bool
+		warrantyVoidAllowed bool
+		wantErr             error
+		wantRef             corev1alpha2.FeatureReference
+	}{
+		{
+			description: "should add a Feature with its default activation",
+			gate:        "empty-fg",
+			feature:     "specialized-toaster",
+			activate:    true,
+			wantRef:     corev1alpha2.FeatureReference{Name: "specialized-toaster", Activate: true},
+		},
+		{
+			description:         "should add an activated Feature voiding the warranty when allowed",
+			gate:                "empty-fg",
+			feature:             "ungated-experiment",
+			activate:            true,
+			warrantyVoidAllowed: true,
+			wantRef:             corev1alpha2.FeatureReference{Name: "ungated-experiment", Activate: true, PermanentlyVoidAllSupportGuarantees: true},
+		},
+		{
+			description: "should not void the warranty unless allowed",
+			gate:        "empty-fg",
+			feature:     "ungated-experiment",
+			activate:    true,
+			wantErr:     ErrTypeForbidden,
+		},
+		{
+			description: "should not add an immutable Feature with another activation than its default",
+			gate:        "empty-fg",
+			feature:     "specialized-toaster",
+			activate:    false,
+			wantErr:     ErrTypeForbidden,
+		},
+		{
+			description: "should not add a Feature gated by another FeatureGate",
+			gate:        "empty-fg",
+			feature:     "tuna",
+			wantErr:     ErrTypeTooMany,
+		},
+		{
+			description: "should not add a Feature that does not exist",
+			gate:        "empty-fg",
+			feature:     "hard-to-get",
+			wantErr:     ErrTypeNotFound,
+		},
+		{
+			description: "should not add a Feature to a FeatureGate that does not exist",
+			gate:        "not-a-fg",
+			feature:     "specialized-toaster",
+			wantErr:     ErrTypeNotFound,
+		},
+	}
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+			defer cancel()
+
+			featureGateClient := newManagementTestClient(t)
+
+			err := featureGateClient.AddFeatureReference(ctx, tc.gate, tc.feature, tc.activate, tc.warrantyVoidAllowed)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			gates, err := featureGateClient.GetFeatureGateList(ctx)
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate list: %v", err)
+			}
+			gateName, ref := FeatureRefFromGateList(gates, tc.feature)
+			if gateName != tc.gate || ref != tc.wantRef {
+				t.Errorf("got reference %+v in FeatureGate %s, want: %+v in FeatureGate %s", ref, gateName, tc.wantRef, tc.gate)
+			}
+		})
+	}
+}
+
+func TestRemoveFeatureReference(t *testing.T) {
+	tests := []struct {
+		description string
+		gate        string
+		feature     string
+		wantErr     error
+	}{
+		{
+			description: "should remove a Feature",
+			gate:        "tanzu-fg",
+			feature:     "tuna",
+		},
+		{
+			description: "should remove a reference to a Feature that does not exist",
+			gate:        "tkg-system",
+			feature:     "hard-to-get",
+		},
+		{
+			description: "should not remove a Feature that voided the warranty",
+			gate:        "tkg-system",
+			feature:     "cloud-event-listener",
+			wantErr:     ErrTypeForbidden,
+		},
+		{
+			description: "should not remove a Feature gated by another FeatureGate",
+			gate:        "empty-fg",
+			feature:     "tuna",
+			wantErr:     ErrTypeNotFound,
+		},
+		{
+			description: "should not remove a Feature from a FeatureGate that does not exist",
+			gate:        "not-a-fg",
+			feature:     "tuna",
+			wantErr:     ErrTypeNotFound,
+		},
+	}
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+			defer cancel()
+
+			featureGateClient := newManagementTestClient(t)
+
+			err := featureGateClient.RemoveFeatureReference(ctx, tc.gate, tc.feature)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tc.wantErr)
+			}
+
+			gates, err := featureGateClient.GetFeatureGateList(ctx)
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate list: %v", err)
+			}
+			gateName, _ := FeatureRefFromGateList(gates, tc.feature)
+			if tc.wantErr == nil && gateName != "" {
+				t.Errorf("got Feature %s still gated by FeatureGate %s", tc.feature, gateName)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// patchFeatureGate reads the latest version of a FeatureGate, lets mutate change it and sends the changes as a merge
+// patch. The patch carries the resourceVersion that was read, so it fails with a conflict when the FeatureGate is
+// updated concurrently. On conflict, the FeatureGate is read again and mutate applied to it, until the patch goes
+// through or the retries run out, in which case a ConflictError is returned. mutate must only change the Feature
+// references it is about, so that the changes of other editors are kept. An error returned by mutate is returned as
+// is, without retrying. opts are passed on to the patch, e.g. client.DryRunAll.
+func (f *FeatureGateClient) patchFeatureGate(ctx context.Context, featureGateName string, mutate func(*corev1alpha2.FeatureGate) error, opts ...client.PatchOption) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		gate, err := f.GetFeatureGate(ctx, featureGateName)
+		if err != nil {
+			return err
+		}
+		original := gate.DeepCopy()
+		if err := mutate(gate); err != nil {
+			return err
+		}
+		return f.crClient.Patch(ctx, gate, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}), opts...)
+	})
+	if apierrors.IsConflict(err) {
+		return &ConflictError{FeatureGate: featureGateName, Err: err}
+	}
+	return err
+}
+
+// setFeatureReferences sets the activation intent of the references to the Features in the FeatureGate to those of refs,
+// and voids the support guarantees for those voided in refs. Voided support guarantees are never restored.
+func setFeatureReferences(gate *corev1alpha2.FeatureGate, refs []corev1alpha2.FeatureReference) error {
+	for _, ref := range refs {
+		found := false
+		for i := range gate.Spec.Features {
+			if gate.Spec.Features[i].Name != ref.Name {
+				continue
+			}
+			gate.Spec.Features[i].Activate = ref.Activate
+			if ref.PermanentlyVoidAllSupportGuarantees {
+				gate.Spec.Features[i].PermanentlyVoidAllSupportGuarantees = true
+			}
+			found = true
+			break
+		}
+		if !found {
+			return fmt.Errorf("could not set Feature %s as it was not found in FeatureGate %s: %w", ref.Name, gate.Name, ErrTypeNotFound)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/internal/testobjects"
+)
+
+// concurrentReference is the Feature reference another editor adds to a FeatureGate.
+const concurrentReference = "added-concurrently"
+
+// concurrentEditClient simulates another editor of the FeatureGates. Before each of the first conflicts patches of a
+// FeatureGate, the other editor adds a reference to the FeatureGate, so that the patch is based on an outdated version.
+type concurrentEditClient struct {
+	client.Client
+	conflicts int
+	// patches counts the patches of each FeatureGate.
+	patches map[string]int
+}
+
+func newConcurrentEditClient(conflicts int) *concurrentEditClient {
+	objs, _, _ := testobjects.Get()
+	return &concurrentEditClient{
+		Client:    crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build(),
+		conflicts: conflicts,
+		patches:   map[string]int{},
+	}
+}
+
+func (c *concurrentEditClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if _, ok := obj.(*corev1alpha2.FeatureGate); ok {
+		c.patches[obj.GetName()]++
+		if c.patches[obj.GetName()] <= c.conflicts {
+			latest := &corev1alpha2.FeatureGate{}
+			if err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+				return err
+			}
+			latest.Spec.Features = append(latest.Spec.Features, corev1alpha2.FeatureReference{Name: concurrentReference})
+			if err := c.Client.Update(ctx, latest); err != nil {
+				return err
+			}
+		}
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func TestWritesRetryConcurrentEdits(t *testing.T) {
+	tests := []struct {
+		description string
+		write       func(context.Context, *FeatureGateClient) error
+		conflicts   int
+		wantGates   []string
+		wantRefs    map[string]bool
+		wantRemoved []string
+	}{
+		{
+			description: "should activate a Feature",
+			write: func(ctx context.Context, c *FeatureGateClient) error {
+				_, err := c.ActivateFeature(ctx, "tuna", false)
+				return err
+			},
+			conflicts: 1,
+			wantGates: []string{"tanzu-fg"},
+			wantRefs:  map[string]bool{"tuna": true, "tuner": true},
+		},
+		{
+			description: "should activate a Feature voiding the warranty",
+			write: func(ctx context.Context, c *FeatureGateClient) error {
+				_, err := c.ActivateFeature(ctx, "cloud-event-speaker", true)
+				return err
+			},
+			conflicts: 2,
+			wantGates: []string{"tkg-system"},
+			wantRefs:  map[string]bool{"cloud-event-speaker": true, "cloud-event-listener": true},
+		},
+		{
+			description: "should deactivate a Feature",
+			write: func(ctx context.Context, c *FeatureGateClient) error {
+				_, err := c.DeactivateFeature(ctx, "tuner")
+				return err
+			},
+			conflicts: 3,
+			wantGates: []string{"tanzu-fg"},
+			wantRefs:  map[string]bool{"tuner": false, "bazzies": true},
+		},
+		{
+			description: "should reset a Feature",
+			write: func(ctx context.Context, c *FeatureGateClient) error {
+				_, err := c.ResetFeature(ctx, "tuner")
+				return err
+			},
+			conflicts:   1,
+			wantGates:   []string{"tanzu-fg"},
+			wantRefs:    map[string]bool{"bazzies": true},
+			wantRemoved: []string{"tuner"},
+		},
+		{
+			description: "should apply Feature changes to several FeatureGates",
+			write: func(ctx context.Context, c *FeatureGateClient) error {
+				_, err := c.ApplyFeatureChanges(ctx, []FeatureChange{{Name: "bar", Activate: true}, {Name: "tuna", Activate: true}})
+				return err
+			},
+			conflicts: 2,
+			wantGates: []string{"tanzu-fg", "tkg-system"},
+			wantRefs:  map[string]bool{"bar": true, "tuna": true, "cloud-event-listener": true},
+		},
+		{
+			description: "should remove a Feature reference",
+			write: func(ctx context.Context, c *FeatureGateClient) error {
+				return c.RemoveFeatureReference(ctx, "tanzu-fg", "tuna")
+			},
+			conflicts:   1,
+			wantGates:   []string{"tanzu-fg"},
+			wantRefs:    map[string]bool{"tuner": true},
+			wantRemoved: []string{"tuna"},
+		},
+	}
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+			defer cancel()
+
+			cl := newConcurrentEditClient(tc.conflicts)
+			featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+			}
+
+			if err := tc.write(ctx, featureGateClient); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+
+			gates, err := featureGateClient.GetFeatureGateList(ctx)
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate list: %v", err)
+			}
+			for _, name := range tc.wantGates {
+				if cl.patches[name] <= tc.conflicts {
+					t.Errorf("got %d patches of FeatureGate %s, want more than the %d conflicting ones", cl.patches[name], name, tc.conflicts)
+				}
+				gate := gateFromList(gates, name)
+				found := false
+				for _, ref := range gate.Spec.Features {
+					found = found || ref.Name == concurrentReference
+				}
+				if !found {
+					t.Errorf("got FeatureGate %s without the reference added concurrently: %+v", name, gate.Spec.Features)
+				}
+			}
+			for name, want := range tc.wantRefs {
+				gateName, ref := FeatureRefFromGateList(gates, name)
+				if gateName == "" || ref.Activate != want {
+					t.Errorf("got Feature %s activate: %t in FeatureGate %q, want: %t", name, ref.Activate, gateName, want)
+				}
+			}
+			for _, name := range tc.wantRemoved {
+				if gateName, _ := FeatureRefFromGateList(gates, name); gateName != "" {
+					t.Errorf("got Feature %s still gated by FeatureGate %s", name, gateName)
+				}
+			}
+		})
+	}
+}
+
+func TestWritesGiveUpOnPersistentConflicts(t *testing.T) {
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	cl := newConcurrentEditClient(100)
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	_, err = featureGateClient.ActivateFeature(ctx, "tuna", false)
+	if !apierrors.IsConflict(err) {
+		t.Fatalf("got error: %v, want a conflict", err)
+	}
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) || conflictErr.FeatureGate != "tanzu-fg" {
+		t.Errorf("got error: %v, want a ConflictError for FeatureGate tanzu-fg", err)
+	}
+	if cl.patches["tanzu-fg"] != retry.DefaultRetry.Steps {
+		t.Errorf("got %d patches, want %d", cl.patches["tanzu-fg"], retry.DefaultRetry.Steps)
+	}
+
+	gate, err := featureGateClient.GetFeatureGate(ctx, "tanzu-fg")
+	if err != nil {
+		t.Fatalf("unable to get FeatureGate: %v", err)
+	}
+	for _, ref := range gate.Spec.Features {
+		if ref.Name == "tuna" && ref.Activate {
+			t.Error("got Feature tuna activated by a conflicting patch")
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"sort"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// SupportVerdict is a machine readable summary of the support status of an environment.
+type SupportVerdict string
+
+const (
+	// SupportVerdictSupported indicates support guarantees hold and no unsupported Feature is activated.
+	SupportVerdictSupported SupportVerdict = "Supported"
+	// SupportVerdictUnsupportedFeaturesActivated indicates support guarantees hold for the environment, but some
+	// activated Features are not supported.
+	SupportVerdictUnsupportedFeaturesActivated SupportVerdict = "UnsupportedFeaturesActivated"
+	// SupportVerdictWarrantyVoided indicates all support guarantees for the environment are permanently voided.
+	SupportVerdictWarrantyVoided SupportVerdict = "WarrantyVoided"
+)
+
+// SupportStatus reports whether the support guarantees of an environment hold.
+type SupportStatus struct {
+	// Verdict summarizes the support status.
+	Verdict SupportVerdict `json:"verdict" yaml:"verdict"`
+	// WarrantyVoided is true if any FeatureGate reference permanently voided all support guarantees.
+	WarrantyVoided bool `json:"warrantyVoided" yaml:"warrantyVoided"`
+	// VoidingReferences are the FeatureGate references that permanently voided all support guarantees.
+	VoidingReferences []VoidingReference `json:"voidingReferences" yaml:"voidingReferences"`
+	// UnsupportedFeatures are the activated Features whose stability level is not supported.
+	UnsupportedFeatures []UnsupportedFeature `json:"unsupportedFeatures" yaml:"unsupportedFeatures"`
+}
+
+// VoidingReference is a FeatureGate reference that permanently voided all support guarantees.
+type VoidingReference struct {
+	FeatureGate string `json:"featureGate" yaml:"featureGate"`
+	Feature     string `json:"feature" yaml:"feature"`
+	// Activate is the activation intent of the reference.
+	Activate bool `json:"activate" yaml:"activate"`
+}
+
+// UnsupportedFeature is an activated Feature whose stability level is not supported.
+type UnsupportedFeature struct {
+	Name      string                      `json:"name" yaml:"name"`
+	Stability corev1alpha2.StabilityLevel `json:"stability" yaml:"stability"`
+	// FeatureGate is the name of the FeatureGate gating the Feature, if any.
+	FeatureGate string `json:"featureGate,omitempty" yaml:"featureGate,omitempty"`
+}
+
+// GetSupportStatus reports whether the support guarantees of the environment were permanently voided, by which
+// FeatureGate references, and which activated Features are unsupported. Work in progress, experimental and technical
+// preview Features are unsupported.
+func (f *FeatureGateClient) GetSupportStatus(ctx context.Context) (*SupportStatus, error) {
+	features, err := f.GetFeatureList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gates, err := f.GetFeatureGateList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return supportStatus(features, gates), nil
+}
+
+func supportStatus(features *corev1alpha2.FeatureList, gates *corev1alpha2.FeatureGateList) *SupportStatus {
+	status := &SupportStatus{
+		VoidingReferences:   []VoidingReference{},
+		UnsupportedFeatures: []UnsupportedFeature{},
+	}
+
+	for i := range gates.Items {
+		for _, ref := range gates.Items[i].Spec.Features {
+			if ref.PermanentlyVoidAllSupportGuarantees {
+				status.VoidingReferences = append(status.VoidingReferences, VoidingReference{
+					FeatureGate: gates.Items[i].Name,
+					Feature:     ref.Name,
+					Activate:    ref.Activate,
+				})
+			}
+		}
+	}
+
+	for i := range features.Items {
+		feature := &features.Items[i]
+		if !feature.Status.Activated || !unsupportedStability(feature.Spec.Stability) {
+			continue
+		}
+		gateName, _ := FeatureRefFromGateList(gates, feature.Name)
+		status.UnsupportedFeatures = append(status.UnsupportedFeatures, UnsupportedFeature{
+			Name:        feature.Name,
+			Stability:   feature.Spec.Stability,
+			FeatureGate: gateName,
+		})
+	}
+
+	sort.Slice(status.VoidingReferences, func(i, j int) bool {
+		a, b := status.VoidingReferences[i], status.VoidingReferences[j]
+		return a.FeatureGate < b.FeatureGate || a.FeatureGate == b.FeatureGate && a.Feature < b.Feature
+	})
+	sort.Slice(status.UnsupportedFeatures, func(i, j int) bool {
+		return status.UnsupportedFeatures[i].Name < status.UnsupportedFeatures[j].Name
+	})
+
+	status.WarrantyVoided = len(status.VoidingReferences) > 0
+	switch {
+	case status.WarrantyVoided:
+		status.Verdict = SupportVerdictWarrantyVoided
+	case len(status.UnsupportedFeatures) > 0:
+		status.Verdict = SupportVerdictUnsupportedFeaturesActivated
+	default:
+		status.Verdict = SupportVerdictSupported
+	}
+	return status
+}
+
+// unsupportedStability returns true for the stability levels whose Features are not supported.
+func unsupportedStability(stability corev1alpha2.StabilityLevel) bool {
+	switch stability {
+	case corev1alpha2.WorkInProgress, corev1alpha2.Experimental, corev1alpha2.TechnicalPreview:
+		return true
+	}
+	return false
+}
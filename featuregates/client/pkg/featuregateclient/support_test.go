@@ -0,0 +1,101 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/internal/testobjects"
+)
+
+func TestGetSupportStatus(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	objs, _, _ := testobjects.Get()
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	got, err := featureGateClient.GetSupportStatus(ctx)
+	if err != nil {
+		t.Fatalf("error not expected, but got error: %v", err)
+	}
+
+	want := &SupportStatus{
+		Verdict:        SupportVerdictWarrantyVoided,
+		WarrantyVoided: true,
+		VoidingReferences: []VoidingReference{
+			{FeatureGate: "tkg-system", Feature: "cloud-event-listener", Activate: true},
+		},
+		UnsupportedFeatures: []UnsupportedFeature{
+			{Name: "cloud-event-listener", Stability: corev1alpha2.Experimental, FeatureGate: "tkg-system"},
+			{Name: "dodgy-experimental-periscope", Stability: corev1alpha2.WorkInProgress, FeatureGate: "tkg-system"},
+			{Name: "tuner", Stability: corev1alpha2.TechnicalPreview, FeatureGate: "tanzu-fg"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got support status: %+v, want: %+v", got, want)
+	}
+}
+
+func TestSupportVerdict(t *testing.T) {
+	_, features, gates := testobjects.Get()
+
+	tests := []struct {
+		description string
+		features    []*corev1alpha2.Feature
+		gates       []*corev1alpha2.FeatureGate
+		want        SupportVerdict
+	}{
+		{
+			description: "supported without unsupported Features activated",
+			features:    []*corev1alpha2.Feature{features["super-toaster"], features["tuna"]},
+			gates:       []*corev1alpha2.FeatureGate{gates["tanzu-fg"]},
+			want:        SupportVerdictSupported,
+		},
+		{
+			description: "unsupported Features activated",
+			features:    []*corev1alpha2.Feature{features["super-toaster"], features["tuner"]},
+			gates:       []*corev1alpha2.FeatureGate{gates["tanzu-fg"]},
+			want:        SupportVerdictUnsupportedFeaturesActivated,
+		},
+		{
+			description: "warranty voided by a reference even if the Feature is not activated",
+			features:    []*corev1alpha2.Feature{features["super-toaster"]},
+			gates:       []*corev1alpha2.FeatureGate{gates["tkg-system"]},
+			want:        SupportVerdictWarrantyVoided,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			featureList := &corev1alpha2.FeatureList{}
+			for _, feature := range tc.features {
+				featureList.Items = append(featureList.Items, *feature)
+			}
+			gateList := &corev1alpha2.FeatureGateList{}
+			for _, gate := range tc.gates {
+				gateList.Items = append(gateList.Items, *gate)
+			}
+
+			if got := supportStatus(featureList, gateList).Verdict; got != tc.want {
+				t.Errorf("got verdict: %s, want: %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -24,28 +24,28 @@ func validateFeatureActivationToggle(gates *corev1alpha2.FeatureGateList, featur
 
 // featureExistsInOneFeaturegate checks that the Feature exists in one and only one FeatureGate.
 func featureExistsInOneAndOnlyOneFeaturegate(gates *corev1alpha2.FeatureGateList, featureName string) error {
-	n := qtyFeatureGatesContainingFeature(gates, featureName)
-	if n > 1 {
-		return fmt.Errorf("the Feature %s was found in more than one FeatureGate: %w", featureName, ErrTypeTooMany)
+	gateNames := featureGatesContainingFeature(gates, featureName)
+	if len(gateNames) > 1 {
+		return &MultipleFeatureGatesError{Feature: featureName, FeatureGates: gateNames}
 	}
-	if n == 0 {
-		return fmt.Errorf("the Feature %s must exist in one FeatureGate: %w", featureName, ErrTypeNotFound)
+	if len(gateNames) == 0 {
+		return &FeatureNotGatedError{Feature: featureName}
 	}
 	return nil
 }
 
-// qtyFeatureGatesContainingFeature counts the number of FeatureGates that
-// references the provided Feature.
-func qtyFeatureGatesContainingFeature(gates *corev1alpha2.FeatureGateList, featureName string) int {
-	var n int
+// featureGatesContainingFeature returns the names of the FeatureGates that
+// reference the provided Feature.
+func featureGatesContainingFeature(gates *corev1alpha2.FeatureGateList, featureName string) []string {
+	var gateNames []string
 	for i := range gates.Items {
 		for _, ref := range gates.Items[i].Spec.Features {
 			if ref.Name == featureName {
-				n++
+				gateNames = append(gateNames, gates.Items[i].Name)
 			}
 		}
 	}
-	return n
+	return gateNames
 }
 
 // featureActivationToggleAllowed checks if a Feature is considered immutable by its stability
@@ -55,7 +55,7 @@ func featureActivationToggleAllowed(feature *corev1alpha2.Feature) error {
 	policy := corev1alpha2.GetPolicyForStabilityLevel(stability)
 
 	if policy.Immutable {
-		return fmt.Errorf("activation setting for Feature %s cannot be toggled as its stability level is %s: %w", feature.Name, stability, ErrTypeForbidden)
+		return &ImmutableFeatureError{Feature: feature.Name, Stability: stability, Policy: policy}
 	}
 	return nil
 }
@@ -0,0 +1,152 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/util"
+)
+
+// WaitForFeatureState waits until a Feature is in the requested activation state: the FeatureGate gating the Feature
+// sets it to the activation, reports the reference as applied, and the status of the Feature reflects it. Setting the
+// activation intent with ActivateFeature or DeactivateFeature only changes the FeatureGate spec, which the Feature
+// reconciler applies later.
+//
+// The wait ends with an InvalidReferenceError carrying the message of the FeatureGate when the FeatureGate reports the
+// reference as invalid while waiting. An invalid result that was already reported when the wait started may predate
+// the latest activation intent, so it is only reported once the context is done.
+func (f *FeatureGateClient) WaitForFeatureState(ctx context.Context, featureName string, activated bool) error {
+	watchClient, ok := f.crClient.(client.WithWatch)
+	if !ok {
+		return fmt.Errorf("could not wait for Feature %s: the cluster client does not support watches", featureName)
+	}
+
+	features, err := f.GetFeatureList(ctx)
+	if err != nil {
+		return err
+	}
+	gates, err := f.GetFeatureGateList(ctx)
+	if err != nil {
+		return err
+	}
+
+	featureWatch, err := watchClient.Watch(ctx, &corev1alpha2.FeatureList{},
+		&client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: features.ResourceVersion}})
+	if err != nil {
+		return fmt.Errorf("could not watch Features: %w", err)
+	}
+	defer featureWatch.Stop()
+	gateWatch, err := watchClient.Watch(ctx, &corev1alpha2.FeatureGateList{},
+		&client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: gates.ResourceVersion}})
+	if err != nil {
+		return fmt.Errorf("could not watch FeatureGates: %w", err)
+	}
+	defer gateWatch.Stop()
+
+	var feature *corev1alpha2.Feature
+	for i := range features.Items {
+		if features.Items[i].Name == featureName {
+			feature = &features.Items[i]
+		}
+	}
+	knownGates := map[string]*corev1alpha2.FeatureGate{}
+	for i := range gates.Items {
+		knownGates[gates.Items[i].Name] = &gates.Items[i]
+	}
+
+	converged, result := featureStateConverged(feature, knownGates, featureName, activated)
+	for !converged {
+		select {
+		case <-ctx.Done():
+			return waitTimeoutError(featureName, activated, result, ctx.Err())
+		case event, ok := <-featureWatch.ResultChan():
+			if !ok {
+				return fmt.Errorf("could not wait for Feature %s: the Feature watch was closed", featureName)
+			}
+			changed, ok := event.Object.(*corev1alpha2.Feature)
+			if !ok || changed.Name != featureName {
+				continue
+			}
+			feature = changed
+			if event.Type == watch.Deleted {
+				feature = nil
+			}
+		case event, ok := <-gateWatch.ResultChan():
+			if !ok {
+				return fmt.Errorf("could not wait for Feature %s: the FeatureGate watch was closed", featureName)
+			}
+			gate, ok := event.Object.(*corev1alpha2.FeatureGate)
+			if !ok {
+				continue
+			}
+			if event.Type == watch.Deleted {
+				delete(knownGates, gate.Name)
+			} else {
+				knownGates[gate.Name] = gate
+			}
+		}
+
+		previous := result
+		converged, result = featureStateConverged(feature, knownGates, featureName, activated)
+		if result != nil && result.Status == corev1alpha2.InvalidReferenceStatus && (previous == nil || *previous != *result) {
+			return fmt.Errorf("could not set Feature %s to be %s: %w", featureName, activationState(activated),
+				invalidReferenceError(knownGates, featureName, result))
+		}
+	}
+	return nil
+}
+
+// featureStateConverged reports whether the Feature is in the activation state, and returns the result the FeatureGate
+// referencing the Feature with that activation intent reports for the reference, if any.
+func featureStateConverged(feature *corev1alpha2.Feature, gates map[string]*corev1alpha2.FeatureGate, featureName string, activated bool) (bool, *corev1alpha2.FeatureReferenceResult) {
+	for _, gate := range gates {
+		ref, found := util.GetFeatureReferenceFromFeatureGate(gate, featureName)
+		if !found || ref.Activate != activated {
+			continue
+		}
+		for i := range gate.Status.FeatureReferenceResults {
+			result := gate.Status.FeatureReferenceResults[i]
+			if result.Name != featureName {
+				continue
+			}
+			applied := result.Status == corev1alpha2.AppliedReferenceStatus
+			return applied && feature != nil && feature.Status.Activated == activated, &result
+		}
+	}
+	return false, nil
+}
+
+// invalidReferenceError returns an InvalidReferenceError for the result reported by the FeatureGate referencing the
+// Feature.
+func invalidReferenceError(gates map[string]*corev1alpha2.FeatureGate, featureName string, result *corev1alpha2.FeatureReferenceResult) error {
+	err := &InvalidReferenceError{Feature: featureName, Message: result.Message}
+	for name, gate := range gates {
+		if _, found := util.GetFeatureReferenceFromFeatureGate(gate, featureName); found {
+			err.FeatureGate = name
+			break
+		}
+	}
+	return err
+}
+
+func waitTimeoutError(featureName string, activated bool, result *corev1alpha2.FeatureReferenceResult, err error) error {
+	if result != nil && result.Status == corev1alpha2.InvalidReferenceStatus {
+		return fmt.Errorf("could not wait for Feature %s to be %s, its FeatureGate reports the reference as invalid: %s: %w", featureName, activationState(activated), result.Message, err)
+	}
+	return fmt.Errorf("could not wait for Feature %s to be %s: %w", featureName, activationState(activated), err)
+}
+
+func activationState(activated bool) string {
+	if activated {
+		return "activated"
+	}
+	return "deactivated"
+}
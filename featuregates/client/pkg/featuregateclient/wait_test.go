@@ -0,0 +1,234 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/internal/testobjects"
+)
+
+const invalidMessage = "Feature could not be toggled because it is immutable"
+
+// watchStartedClient closes watching once both the Feature and the FeatureGate watches are started, so that the
+// reconciliation simulated by a test is only seen through the watches.
+type watchStartedClient struct {
+	client.WithWatch
+	watches  int
+	watching chan struct{}
+}
+
+func (c *watchStartedClient) Watch(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	w, err := c.WithWatch.Watch(ctx, obj, opts...)
+	if err == nil {
+		c.watches++
+		if c.watches == 2 {
+			close(c.watching)
+		}
+	}
+	return w, err
+}
+
+// reconcileFeature sets the result of the reference to the Feature in the FeatureGate, and the Feature status, as the
+// Feature reconciler does.
+func reconcileFeature(ctx context.Context, cl client.Client, gateName, featureName string, result corev1alpha2.FeatureReferenceResult, activated bool) error {
+	gate := &corev1alpha2.FeatureGate{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: gateName}, gate); err != nil {
+		return err
+	}
+	gate.Status.FeatureReferenceResults = append(gate.Status.FeatureReferenceResults, result)
+	if err := cl.Update(ctx, gate); err != nil {
+		return err
+	}
+	if result.Status == corev1alpha2.InvalidReferenceStatus {
+		return nil
+	}
+	feature := &corev1alpha2.Feature{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: featureName}, feature); err != nil {
+		return err
+	}
+	feature.Status.Activated = activated
+	return cl.Update(ctx, feature)
+}
+
+func TestWaitForFeatureState(t *testing.T) {
+	applied := corev1alpha2.FeatureReferenceResult{Status: corev1alpha2.AppliedReferenceStatus, Message: "Feature has been successfully toggled"}
+	invalid := corev1alpha2.FeatureReferenceResult{Status: corev1alpha2.InvalidReferenceStatus, Message: invalidMessage}
+
+	tests := []struct {
+		description string
+		featureName string
+		activated   bool
+		// initialResult is reported by the FeatureGate before the wait starts.
+		initialResult *corev1alpha2.FeatureReferenceResult
+		// reconciledResult is reported by the FeatureGate while waiting.
+		reconciledResult *corev1alpha2.FeatureReferenceResult
+		timeout          time.Duration
+		wantErr          error
+		wantErrMsg       string
+	}{
+		{
+			description:      "should wait until the Feature is activated",
+			featureName:      "tuner",
+			activated:        true,
+			reconciledResult: &applied,
+			timeout:          contextTimeout,
+		},
+		{
+			description:      "should wait until the Feature is deactivated",
+			featureName:      "bar",
+			activated:        false,
+			reconciledResult: &applied,
+			timeout:          contextTimeout,
+		},
+		{
+			description:   "should not wait for a Feature already in the state",
+			featureName:   "bazzies",
+			activated:     true,
+			initialResult: &applied,
+			timeout:       contextTimeout,
+		},
+		{
+			description:      "should report a reference found invalid while waiting",
+			featureName:      "tuner",
+			activated:        true,
+			reconciledResult: &invalid,
+			timeout:          contextTimeout,
+			wantErr:          ErrTypeInvalid,
+			wantErrMsg:       invalidMessage,
+		},
+		{
+			description: "should time out if the Feature is not reconciled",
+			featureName: "tuner",
+			activated:   true,
+			timeout:     100 * time.Millisecond,
+			wantErr:     context.DeadlineExceeded,
+		},
+		{
+			description:   "should time out on an invalid reference reported before waiting, and report it",
+			featureName:   "tuner",
+			activated:     true,
+			initialResult: &invalid,
+			timeout:       100 * time.Millisecond,
+			wantErr:       context.DeadlineExceeded,
+			wantErrMsg:    invalidMessage,
+		},
+	}
+
+	testScheme := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(testScheme); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			objs, _, _ := testobjects.Get()
+			cl := &watchStartedClient{
+				WithWatch: crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build(),
+				watching:  make(chan struct{}),
+			}
+			featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+			if err != nil {
+				t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+			}
+
+			gates, err := featureGateClient.GetFeatureGateList(ctx)
+			if err != nil {
+				t.Fatalf("unable to get FeatureGate list: %v", err)
+			}
+			gateName, _ := FeatureRefFromGateList(gates, tc.featureName)
+
+			if tc.initialResult != nil {
+				result := *tc.initialResult
+				result.Name = tc.featureName
+				if err := reconcileFeature(ctx, cl, gateName, tc.featureName, result, tc.activated); err != nil {
+					t.Fatalf("unable to reconcile Feature: %v", err)
+				}
+			}
+
+			reconcileErrs := make(chan error, 1)
+			go func() {
+				if tc.reconciledResult == nil {
+					reconcileErrs <- nil
+					return
+				}
+				<-cl.watching
+				result := *tc.reconciledResult
+				result.Name = tc.featureName
+				reconcileErrs <- reconcileFeature(ctx, cl, gateName, tc.featureName, result, tc.activated)
+			}()
+
+			err = featureGateClient.WaitForFeatureState(ctx, tc.featureName, tc.activated)
+			if tc.wantErr == nil && err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("got error: %v, want: %v", err, tc.wantErr)
+			}
+			if tc.wantErrMsg != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErrMsg)) {
+				t.Errorf("got error: %v, want it to contain: %q", err, tc.wantErrMsg)
+			}
+			if err := <-reconcileErrs; err != nil {
+				t.Errorf("unable to reconcile Feature: %v", err)
+			}
+		})
+	}
+}
+
+func TestFeatureStateConverged(t *testing.T) {
+	gate := func(activate bool, results ...corev1alpha2.FeatureReferenceResult) map[string]*corev1alpha2.FeatureGate {
+		return map[string]*corev1alpha2.FeatureGate{"tanzu-fg": {
+			Spec:   corev1alpha2.FeatureGateSpec{Features: []corev1alpha2.FeatureReference{{Name: "tuna", Activate: activate}}},
+			Status: corev1alpha2.FeatureGateStatus{FeatureReferenceResults: results},
+		}}
+	}
+	feature := func(activated bool) *corev1alpha2.Feature {
+		f := &corev1alpha2.Feature{Status: corev1alpha2.FeatureStatus{Activated: activated}}
+		f.Name = "tuna"
+		return f
+	}
+	applied := corev1alpha2.FeatureReferenceResult{Name: "tuna", Status: corev1alpha2.AppliedReferenceStatus}
+	invalid := corev1alpha2.FeatureReferenceResult{Name: "tuna", Status: corev1alpha2.InvalidReferenceStatus}
+
+	tests := []struct {
+		description   string
+		feature       *corev1alpha2.Feature
+		gates         map[string]*corev1alpha2.FeatureGate
+		wantConverged bool
+		wantResult    *corev1alpha2.FeatureReferenceResult
+	}{
+		{description: "applied and activated", feature: feature(true), gates: gate(true, applied), wantConverged: true, wantResult: &applied},
+		{description: "applied but status not reconciled", feature: feature(false), gates: gate(true, applied), wantResult: &applied},
+		{description: "invalid", feature: feature(false), gates: gate(true, invalid), wantResult: &invalid},
+		{description: "no result yet", feature: feature(true), gates: gate(true)},
+		{description: "gate sets the other activation", feature: feature(true), gates: gate(false, applied)},
+		{description: "feature removed", gates: gate(true, applied), wantResult: &applied},
+		{description: "no gate references the feature", feature: feature(true), gates: map[string]*corev1alpha2.FeatureGate{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			converged, result := featureStateConverged(tc.feature, tc.gates, "tuna", true)
+			if converged != tc.wantConverged {
+				t.Errorf("got converged: %t, want: %t", converged, tc.wantConverged)
+			}
+			if (result == nil) != (tc.wantResult == nil) || (result != nil && *result != *tc.wantResult) {
+				t.Errorf("got result: %+v, want: %+v", result, tc.wantResult)
+			}
+		})
+	}
+}
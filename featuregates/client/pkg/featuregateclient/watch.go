@@ -0,0 +1,361 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// FeatureEventType is the type of change of the state of a Feature.
+type FeatureEventType string
+
+const (
+	// FeatureAdded indicates a Feature appeared in the cluster.
+	FeatureAdded FeatureEventType = "Added"
+	// FeatureRemoved indicates a Feature was removed from the cluster.
+	FeatureRemoved FeatureEventType = "Removed"
+	// FeatureActivated indicates a Feature was activated.
+	FeatureActivated FeatureEventType = "Activated"
+	// FeatureDeactivated indicates a Feature was deactivated.
+	FeatureDeactivated FeatureEventType = "Deactivated"
+	// FeatureReferenceInvalid indicates a FeatureGate reported its reference to a Feature as invalid.
+	FeatureReferenceInvalid FeatureEventType = "Invalid"
+	// FeatureWarrantyVoided indicates a FeatureGate reference permanently voided all support guarantees for a Feature.
+	FeatureWarrantyVoided FeatureEventType = "WarrantyVoided"
+	// FeatureWatchError indicates the cluster reported an error while watching Features or FeatureGates.
+	FeatureWatchError FeatureEventType = "Error"
+)
+
+// FeatureEvent is a change of the state of a Feature.
+type FeatureEvent struct {
+	Time        time.Time        `json:"time"`
+	Type        FeatureEventType `json:"type"`
+	Feature     string           `json:"feature"`
+	FeatureGate string           `json:"featureGate,omitempty"`
+	Message     string           `json:"message,omitempty"`
+}
+
+// watchRetryInterval is how long to wait before watching again when the cluster fails to start a watch.
+const watchRetryInterval = 5 * time.Second
+
+// WatchFeatureEvents streams the changes to the state of Features: Features appearing and being removed, activation
+// changes, FeatureGates reporting invalid references and FeatureGate references voiding the support guarantees. When
+// the cluster ends a watch, it is resumed from the last seen version; when that version is too old, the Features and
+// FeatureGates are listed again and the changes since are streamed. Errors reported by the cluster are streamed as
+// FeatureWatchError events. The channel is closed when the context is done.
+func (f *FeatureGateClient) WatchFeatureEvents(ctx context.Context) (<-chan FeatureEvent, error) {
+	watchClient, ok := f.crClient.(client.WithWatch)
+	if !ok {
+		return nil, fmt.Errorf("could not watch Features: the cluster client does not support watches")
+	}
+
+	w := &featureEventWatcher{}
+	w.featureWatch = &resumableWatch{client: watchClient, kind: "Features",
+		newList: func() client.ObjectList { return &corev1alpha2.FeatureList{} },
+		relist:  w.listFeatures, apply: w.applyFeatureEvent}
+	w.gateWatch = &resumableWatch{client: watchClient, kind: "FeatureGates",
+		newList: func() client.ObjectList { return &corev1alpha2.FeatureGateList{} },
+		relist:  w.listFeatureGates, apply: w.applyFeatureGateEvent}
+
+	if _, err := w.featureWatch.relistAndStart(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := w.gateWatch.relistAndStart(ctx); err != nil {
+		w.featureWatch.stop()
+		return nil, err
+	}
+
+	events := make(chan FeatureEvent)
+	go func() {
+		defer close(events)
+		defer w.featureWatch.stop()
+		defer w.gateWatch.stop()
+
+		for {
+			var changes []FeatureEvent
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-w.featureWatch.watch.ResultChan():
+				changes = w.featureWatch.handle(ctx, event, ok)
+			case event, ok := <-w.gateWatch.watch.ResultChan():
+				changes = w.gateWatch.handle(ctx, event, ok)
+			}
+
+			now := time.Now()
+			for _, change := range changes {
+				change.Time = now
+				select {
+				case events <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// featureEventWatcher keeps the last seen state of the Features and FeatureGates, to turn their changes into
+// FeatureEvents.
+type featureEventWatcher struct {
+	features     map[string]*corev1alpha2.Feature
+	gates        map[string]*corev1alpha2.FeatureGate
+	featureWatch *resumableWatch
+	gateWatch    *resumableWatch
+}
+
+// listFeatures lists the Features, and returns the changes since they were last seen along with the version of the list.
+func (w *featureEventWatcher) listFeatures(ctx context.Context, c client.Client) (string, []FeatureEvent, error) {
+	features := &corev1alpha2.FeatureList{}
+	if err := c.List(ctx, features); err != nil {
+		return "", nil, fmt.Errorf("could not get FeatureList: %w", err)
+	}
+
+	var changes []FeatureEvent
+	listed := make(map[string]*corev1alpha2.Feature, len(features.Items))
+	for i := range features.Items {
+		feature := &features.Items[i]
+		listed[feature.Name] = feature
+		if w.features != nil {
+			changes = append(changes, featureEvents(w.features[feature.Name], feature)...)
+		}
+	}
+	for name, old := range w.features {
+		if _, found := listed[name]; !found {
+			changes = append(changes, featureEvents(old, nil)...)
+		}
+	}
+	w.features = listed
+	return features.ResourceVersion, changes, nil
+}
+
+// listFeatureGates lists the FeatureGates, and returns the changes since they were last seen along with the version of
+// the list.
+func (w *featureEventWatcher) listFeatureGates(ctx context.Context, c client.Client) (string, []FeatureEvent, error) {
+	gates := &corev1alpha2.FeatureGateList{}
+	if err := c.List(ctx, gates); err != nil {
+		return "", nil, fmt.Errorf("could not get FeatureGateList: %w", err)
+	}
+
+	var changes []FeatureEvent
+	listed := make(map[string]*corev1alpha2.FeatureGate, len(gates.Items))
+	for i := range gates.Items {
+		gate := &gates.Items[i]
+		listed[gate.Name] = gate
+		if w.gates != nil {
+			changes = append(changes, featureGateEvents(w.gates[gate.Name], gate)...)
+		}
+	}
+	w.gates = listed
+	return gates.ResourceVersion, changes, nil
+}
+
+func (w *featureEventWatcher) applyFeatureEvent(event watch.Event) []FeatureEvent {
+	feature, ok := event.Object.(*corev1alpha2.Feature)
+	if !ok {
+		return nil
+	}
+	if event.Type == watch.Deleted {
+		changes := featureEvents(w.features[feature.Name], nil)
+		delete(w.features, feature.Name)
+		return changes
+	}
+	changes := featureEvents(w.features[feature.Name], feature)
+	w.features[feature.Name] = feature
+	return changes
+}
+
+func (w *featureEventWatcher) applyFeatureGateEvent(event watch.Event) []FeatureEvent {
+	gate, ok := event.Object.(*corev1alpha2.FeatureGate)
+	if !ok {
+		return nil
+	}
+	if event.Type == watch.Deleted {
+		delete(w.gates, gate.Name)
+		return nil
+	}
+	changes := featureGateEvents(w.gates[gate.Name], gate)
+	w.gates[gate.Name] = gate
+	return changes
+}
+
+// resumableWatch watches a kind of resource, and resumes the watch from the last seen resourceVersion when the
+// cluster ends it. When the cluster no longer has that resourceVersion, the resources are listed again.
+type resumableWatch struct {
+	client client.WithWatch
+	// kind names the watched resources in errors.
+	kind    string
+	newList func() client.ObjectList
+	// relist lists the resources, and returns the version of the list and the changes since they were last seen.
+	relist func(context.Context, client.Client) (string, []FeatureEvent, error)
+	// apply returns the changes an added, modified or deleted resource makes.
+	apply func(watch.Event) []FeatureEvent
+
+	resourceVersion string
+	watch           watch.Interface
+}
+
+// start watches the resources from the last seen resourceVersion.
+func (r *resumableWatch) start(ctx context.Context) error {
+	w, err := r.client.Watch(ctx, r.newList(),
+		&client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: r.resourceVersion, AllowWatchBookmarks: true}})
+	if err != nil {
+		return fmt.Errorf("could not watch %s: %w", r.kind, err)
+	}
+	r.watch = w
+	return nil
+}
+
+// relistAndStart lists the resources, and watches them from the version of the list.
+func (r *resumableWatch) relistAndStart(ctx context.Context) ([]FeatureEvent, error) {
+	version, changes, err := r.relist(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+	r.resourceVersion = version
+	return changes, r.start(ctx)
+}
+
+// resume watches the resources again from the last seen resourceVersion, or lists them again if the cluster no longer
+// has it.
+func (r *resumableWatch) resume(ctx context.Context) ([]FeatureEvent, error) {
+	err := r.start(ctx)
+	if err != nil && isResourceVersionExpired(err) {
+		return r.relistAndStart(ctx)
+	}
+	return nil, err
+}
+
+// handle returns the changes an event received from the watch makes. ok is false when the watch was ended.
+func (r *resumableWatch) handle(ctx context.Context, event watch.Event, ok bool) []FeatureEvent {
+	if !ok {
+		changes, err := r.resume(ctx)
+		return r.withError(ctx, changes, err)
+	}
+
+	switch event.Type {
+	case watch.Error:
+		r.watch.Stop()
+		err := apierrors.FromObject(event.Object)
+		if isResourceVersionExpired(err) {
+			changes, err := r.relistAndStart(ctx)
+			return r.withError(ctx, changes, err)
+		}
+		changes, resumeErr := r.resume(ctx)
+		return append([]FeatureEvent{watchErrorEvent(err)}, r.withError(ctx, changes, resumeErr)...)
+	case watch.Bookmark:
+		if obj, ok := event.Object.(client.Object); ok {
+			r.resourceVersion = obj.GetResourceVersion()
+		}
+		return nil
+	default:
+		if obj, ok := event.Object.(client.Object); ok {
+			r.resourceVersion = obj.GetResourceVersion()
+		}
+		return r.apply(event)
+	}
+}
+
+// withError appends an error event to the changes when the watch could not be resumed, and schedules another attempt
+// after watchRetryInterval.
+func (r *resumableWatch) withError(ctx context.Context, changes []FeatureEvent, err error) []FeatureEvent {
+	if err == nil || ctx.Err() != nil {
+		return changes
+	}
+
+	// A watch whose result channel is closed after the interval, so that the watch is resumed then.
+	retry := make(chan watch.Event)
+	time.AfterFunc(watchRetryInterval, func() { close(retry) })
+	r.watch = watch.NewProxyWatcher(retry)
+	return append(changes, watchErrorEvent(err))
+}
+
+func (r *resumableWatch) stop() {
+	if r.watch != nil {
+		r.watch.Stop()
+	}
+}
+
+func isResourceVersionExpired(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
+
+func watchErrorEvent(err error) FeatureEvent {
+	return FeatureEvent{Type: FeatureWatchError, Message: err.Error()}
+}
+
+// featureEvents returns the changes between the old and new state of a Feature. The old Feature is nil when the
+// Feature appeared, and the new one is nil when it was removed.
+func featureEvents(old, feature *corev1alpha2.Feature) []FeatureEvent {
+	switch {
+	case feature == nil && old == nil:
+		return nil
+	case feature == nil:
+		return []FeatureEvent{{Type: FeatureRemoved, Feature: old.Name}}
+	case old == nil:
+		return []FeatureEvent{{Type: FeatureAdded, Feature: feature.Name, Message: activationMessage(feature)}}
+	case old.Status.Activated != feature.Status.Activated:
+		eventType := FeatureDeactivated
+		if feature.Status.Activated {
+			eventType = FeatureActivated
+		}
+		return []FeatureEvent{{Type: eventType, Feature: feature.Name}}
+	}
+	return nil
+}
+
+func activationMessage(feature *corev1alpha2.Feature) string {
+	if feature.Status.Activated {
+		return fmt.Sprintf("%s, activated", feature.Spec.Stability)
+	}
+	return fmt.Sprintf("%s, deactivated", feature.Spec.Stability)
+}
+
+// featureGateEvents returns the invalid references and the voided support guarantees that are new in the FeatureGate.
+// The old FeatureGate is nil when the FeatureGate appeared.
+func featureGateEvents(old, gate *corev1alpha2.FeatureGate) []FeatureEvent {
+	oldResults := map[string]corev1alpha2.FeatureReferenceResult{}
+	oldVoided := map[string]bool{}
+	if old != nil {
+		for _, result := range old.Status.FeatureReferenceResults {
+			oldResults[result.Name] = result
+		}
+		for _, ref := range old.Spec.Features {
+			oldVoided[ref.Name] = ref.PermanentlyVoidAllSupportGuarantees
+		}
+	}
+
+	var events []FeatureEvent
+	for _, ref := range gate.Spec.Features {
+		if ref.PermanentlyVoidAllSupportGuarantees && !oldVoided[ref.Name] {
+			events = append(events, FeatureEvent{
+				Type:        FeatureWarrantyVoided,
+				Feature:     ref.Name,
+				FeatureGate: gate.Name,
+				Message:     "all support guarantees for this environment are permanently voided",
+			})
+		}
+	}
+	for _, result := range gate.Status.FeatureReferenceResults {
+		if result.Status == corev1alpha2.InvalidReferenceStatus && oldResults[result.Name] != result {
+			events = append(events, FeatureEvent{
+				Type:        FeatureReferenceInvalid,
+				Feature:     result.Name,
+				FeatureGate: gate.Name,
+				Message:     result.Message,
+			})
+		}
+	}
+	return events
+}
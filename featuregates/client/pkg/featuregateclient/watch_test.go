@@ -0,0 +1,229 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregateclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient/internal/testobjects"
+)
+
+func TestWatchFeatureEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	objs, features, gates := testobjects.Get()
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	cl := crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	events, err := featureGateClient.WatchFeatureEvents(ctx)
+	if err != nil {
+		t.Fatalf("unable to watch Feature events: %v", err)
+	}
+
+	next := func() FeatureEvent {
+		t.Helper()
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("events channel closed")
+			}
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+		return FeatureEvent{}
+	}
+
+	tuna := &corev1alpha2.Feature{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: "tuna"}, tuna); err != nil {
+		t.Fatalf("unable to get Feature: %v", err)
+	}
+	tuna.Status.Activated = true
+	if err := cl.Update(ctx, tuna); err != nil {
+		t.Fatalf("unable to update Feature: %v", err)
+	}
+	if event := next(); event.Type != FeatureActivated || event.Feature != "tuna" {
+		t.Errorf("got event: %+v, want tuna to be activated", event)
+	}
+
+	if err := cl.Create(ctx, features["specialized-toaster"].DeepCopy()); err != nil {
+		t.Fatalf("unable to create Feature: %v", err)
+	}
+	if event := next(); event.Type != FeatureAdded || event.Feature != "specialized-toaster" {
+		t.Errorf("got event: %+v, want specialized-toaster to be added", event)
+	}
+
+	gate := &corev1alpha2.FeatureGate{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: gates["tkg-system"].Name}, gate); err != nil {
+		t.Fatalf("unable to get FeatureGate: %v", err)
+	}
+	for i := range gate.Spec.Features {
+		if gate.Spec.Features[i].Name == "foo" {
+			gate.Spec.Features[i].PermanentlyVoidAllSupportGuarantees = true
+		}
+	}
+	gate.Status.FeatureReferenceResults = []corev1alpha2.FeatureReferenceResult{
+		{Name: "bar", Status: corev1alpha2.InvalidReferenceStatus, Message: "Invalid operation, feature cannot be toggled"},
+	}
+	if err := cl.Update(ctx, gate); err != nil {
+		t.Fatalf("unable to update FeatureGate: %v", err)
+	}
+	if event := next(); event.Type != FeatureWarrantyVoided || event.Feature != "foo" || event.FeatureGate != "tkg-system" {
+		t.Errorf("got event: %+v, want warranty voided for foo", event)
+	}
+	if event := next(); event.Type != FeatureReferenceInvalid || event.Feature != "bar" || event.Message == "" {
+		t.Errorf("got event: %+v, want invalid reference to bar", event)
+	}
+
+	if err := cl.Delete(ctx, &corev1alpha2.Feature{ObjectMeta: metav1.ObjectMeta{Name: "tuna"}}); err != nil {
+		t.Fatalf("unable to delete Feature: %v", err)
+	}
+	if event := next(); event.Type != FeatureRemoved || event.Feature != "tuna" {
+		t.Errorf("got event: %+v, want tuna to be removed", event)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+// startedWatch is a watch handed out by scriptedWatchClient.
+type startedWatch struct {
+	kind            string
+	resourceVersion string
+	watcher         *watch.FakeWatcher
+}
+
+// scriptedWatchClient hands out watches the test drives, like the cluster ending a watch or reporting errors.
+type scriptedWatchClient struct {
+	client.WithWatch
+	started chan startedWatch
+}
+
+func (c *scriptedWatchClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	kind := "Features"
+	if _, ok := list.(*corev1alpha2.FeatureGateList); ok {
+		kind = "FeatureGates"
+	}
+	w := watch.NewFake()
+	c.started <- startedWatch{kind: kind, resourceVersion: listOpts.Raw.ResourceVersion, watcher: w}
+	return w, nil
+}
+
+func TestWatchFeatureEventsResumes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	objs, features, _ := testobjects.Get()
+	s := scheme.Scheme
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add config scheme: (%v)", err)
+	}
+	cl := &scriptedWatchClient{
+		WithWatch: crclient.NewClientBuilder().WithRuntimeObjects(objs...).Build(),
+		started:   make(chan startedWatch, 10),
+	}
+	featureGateClient, err := NewFeatureGateClient(WithClient(cl))
+	if err != nil {
+		t.Fatalf("unable to get FeatureGateClient: (%v)", err)
+	}
+
+	events, err := featureGateClient.WatchFeatureEvents(ctx)
+	if err != nil {
+		t.Fatalf("unable to watch Feature events: %v", err)
+	}
+
+	nextWatch := func(kind string) startedWatch {
+		t.Helper()
+		select {
+		case w := <-cl.started:
+			if w.kind != kind {
+				t.Fatalf("got watch of %s, want %s", w.kind, kind)
+			}
+			return w
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for watch of %s", kind)
+		}
+		return startedWatch{}
+	}
+	next := func() FeatureEvent {
+		t.Helper()
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("events channel closed")
+			}
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+		return FeatureEvent{}
+	}
+
+	featureWatch := nextWatch("Features")
+	nextWatch("FeatureGates")
+
+	tuna := features["tuna"].DeepCopy()
+	tuna.ResourceVersion = "42"
+	tuna.Status.Activated = true
+	featureWatch.watcher.Modify(tuna)
+	if event := next(); event.Type != FeatureActivated || event.Feature != "tuna" {
+		t.Errorf("got event: %+v, want tuna to be activated", event)
+	}
+
+	t.Run("should resume a watch ended by the cluster from the last seen version", func(t *testing.T) {
+		featureWatch.watcher.Stop()
+		featureWatch = nextWatch("Features")
+		if featureWatch.resourceVersion != "42" {
+			t.Errorf("got watch from version %q, want %q", featureWatch.resourceVersion, "42")
+		}
+	})
+
+	t.Run("should report errors and resume the watch", func(t *testing.T) {
+		featureWatch.watcher.Error(&apierrors.NewInternalError(errors.New("etcd unavailable")).ErrStatus)
+		if event := next(); event.Type != FeatureWatchError || event.Message == "" {
+			t.Errorf("got event: %+v, want an error event", event)
+		}
+		featureWatch = nextWatch("Features")
+		if featureWatch.resourceVersion != "42" {
+			t.Errorf("got watch from version %q, want %q", featureWatch.resourceVersion, "42")
+		}
+	})
+
+	t.Run("should list again when the last seen version is too old", func(t *testing.T) {
+		if err := cl.Create(ctx, features["specialized-toaster"].DeepCopy()); err != nil {
+			t.Fatalf("unable to create Feature: %v", err)
+		}
+		featureWatch.watcher.Error(&apierrors.NewResourceExpired("too old resource version").ErrStatus)
+		if event := next(); event.Type != FeatureAdded || event.Feature != "specialized-toaster" {
+			t.Errorf("got event: %+v, want specialized-toaster to be added", event)
+		}
+		nextWatch("Features")
+	})
+
+	cancel()
+	for range events {
+	}
+}
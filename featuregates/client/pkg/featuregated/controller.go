@@ -0,0 +1,359 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregated
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+// retryPeriod is how long to wait before trying again when the Feature could not be read or the controller exited
+// on its own.
+const retryPeriod = 10 * time.Second
+
+// Builder builds a controller that is started when a Feature is activated and stopped when it is deactivated.
+// It mirrors the parts of the controller-runtime builder that feature gated controllers need.
+type Builder struct {
+	mgr          manager.Manager
+	featureName  string
+	name         string
+	forInput     *watchInput
+	watchesInput []watchInput
+	options      controller.Options
+	err          error
+}
+
+// watchInput describes a watch that is set up every time the controller is started.
+type watchInput struct {
+	object     client.Object
+	predicates []predicate.Predicate
+	// eventHandler is the handler for events of object. It is nil for owned objects, whose handler depends on the
+	// type passed to For.
+	eventHandler handler.EventHandler
+}
+
+// NewControllerManagedBy returns a new Builder for a controller gated by the named Feature.
+func NewControllerManagedBy(mgr manager.Manager, featureName string) *Builder {
+	return &Builder{mgr: mgr, featureName: featureName}
+}
+
+// For defines the type of object being reconciled.
+func (b *Builder) For(object client.Object, predicates ...predicate.Predicate) *Builder {
+	if b.forInput != nil {
+		b.err = fmt.Errorf("For(...) should only be called once, could not assign multiple objects for reconciliation")
+		return b
+	}
+	b.forInput = &watchInput{object: object, predicates: predicates, eventHandler: &handler.EnqueueRequestForObject{}}
+	return b
+}
+
+// Owns defines the types of objects created by the controller. Events for them enqueue the controlling owner of the
+// type passed to For.
+func (b *Builder) Owns(object client.Object, predicates ...predicate.Predicate) *Builder {
+	b.watchesInput = append(b.watchesInput, watchInput{object: object, predicates: predicates})
+	return b
+}
+
+// Watches defines additional types of objects to watch and how their events are mapped to reconcile requests.
+func (b *Builder) Watches(object client.Object, eventHandler handler.EventHandler, predicates ...predicate.Predicate) *Builder {
+	b.watchesInput = append(b.watchesInput, watchInput{object: object, predicates: predicates, eventHandler: eventHandler})
+	return b
+}
+
+// Named sets the name of the controller. It defaults to the lowercased kind of the type passed to For.
+func (b *Builder) Named(name string) *Builder {
+	b.name = name
+	return b
+}
+
+// WithOptions overrides the options used to create the controller. The Reconciler field is ignored.
+func (b *Builder) WithOptions(options controller.Options) *Builder {
+	b.options = options
+	return b
+}
+
+// Complete builds the controller and adds it to the manager.
+func (b *Builder) Complete(r reconcile.Reconciler) error {
+	_, err := b.Build(r)
+	return err
+}
+
+// Build builds the controller, adds it to the manager and registers a readiness check for it.
+func (b *Builder) Build(r reconcile.Reconciler) (*Controller, error) {
+	if r == nil {
+		return nil, fmt.Errorf("must provide a non-nil Reconciler")
+	}
+	if b.mgr == nil {
+		return nil, fmt.Errorf("must provide a non-nil Manager")
+	}
+	if b.featureName == "" {
+		return nil, fmt.Errorf("must provide the name of the Feature gating the controller")
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.forInput == nil {
+		return nil, fmt.Errorf("must provide an object for reconciliation")
+	}
+
+	name := b.name
+	if name == "" {
+		gvk, err := apiutil.GVKForObject(b.forInput.object, b.mgr.GetScheme())
+		if err != nil {
+			return nil, err
+		}
+		name = strings.ToLower(gvk.Kind)
+	}
+
+	options := b.options
+	options.Reconciler = r
+
+	c := &Controller{
+		name:        name,
+		featureName: b.featureName,
+		reader:      b.mgr.GetClient(),
+		log:         b.mgr.GetLogger().WithValues("controller", name, "feature", b.featureName),
+		events:      make(chan struct{}, 1),
+	}
+	c.newController = func() (manager.Runnable, error) {
+		return b.newUnmanagedController(name, options)
+	}
+	c.informer = func(ctx context.Context) (cache.Informer, error) {
+		return b.mgr.GetCache().GetInformer(ctx, &corev1alpha2.Feature{})
+	}
+
+	if err := b.mgr.Add(c); err != nil {
+		return nil, err
+	}
+	if err := b.mgr.AddReadyzCheck(name+"-feature-gate", c.ReadyzCheck()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// newUnmanagedController creates a controller that is not added to the manager, with all the watches of the
+// builder. A controller can only be started once, so a new one is created every time the Feature is activated.
+func (b *Builder) newUnmanagedController(name string, options controller.Options) (manager.Runnable, error) {
+	c, err := controller.NewUnmanaged(name, b.mgr, options)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := append([]watchInput{*b.forInput}, b.watchesInput...)
+	for _, input := range inputs {
+		eventHandler := input.eventHandler
+		if eventHandler == nil {
+			eventHandler = &handler.EnqueueRequestForOwner{OwnerType: b.forInput.object, IsController: true}
+		}
+		if err := c.Watch(&source.Kind{Type: input.object}, eventHandler, input.predicates...); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Controller is a manager.Runnable that starts and stops a controller as the activation state of its Feature changes.
+// Starting the controller lists all the watched objects, so every object is reconciled when the Feature is activated.
+type Controller struct {
+	name        string
+	featureName string
+	reader      client.Reader
+	log         logr.Logger
+
+	// newController creates the underlying controller.
+	newController func() (manager.Runnable, error)
+	// informer returns the informer for Feature resources.
+	informer func(ctx context.Context) (cache.Informer, error)
+
+	// events is signalled whenever the Feature may have changed.
+	events chan struct{}
+
+	mu        sync.Mutex
+	observed  bool
+	activated bool
+	running   bool
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// Start implements manager.Runnable. It follows the activation state of the gating Feature, starting and stopping
+// the controller accordingly, until ctx is done.
+func (c *Controller) Start(ctx context.Context) error {
+	informer, err := c.informer(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get informer for Feature resources: %w", err)
+	}
+	informer.AddEventHandler(toolscache.FilteringResourceEventHandler{
+		FilterFunc: c.isGatingFeature,
+		Handler: toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { c.notify() },
+			UpdateFunc: func(interface{}, interface{}) { c.notify() },
+			DeleteFunc: func(interface{}) { c.notify() },
+		},
+	})
+	return c.run(ctx)
+}
+
+// Running reports whether the gated controller is currently running.
+func (c *Controller) Running() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.running
+}
+
+// ReadyzCheck returns a healthz.Checker that reports ready once the activation state of the Feature has been
+// observed and the controller is running if, and only if, the Feature is activated.
+func (c *Controller) ReadyzCheck() healthz.Checker {
+	return func(_ *http.Request) error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if !c.observed {
+			return fmt.Errorf("activation state of Feature %s has not been observed yet", c.featureName)
+		}
+		if c.activated != c.running {
+			return fmt.Errorf("controller %s has not converged to the activation state of Feature %s", c.name, c.featureName)
+		}
+		return nil
+	}
+}
+
+func (c *Controller) run(ctx context.Context) error {
+	c.notify()
+	for {
+		select {
+		case <-ctx.Done():
+			c.stop()
+			return nil
+		case <-c.events:
+			if err := c.sync(ctx); err != nil {
+				c.log.Error(err, "could not sync controller with Feature activation state")
+				time.AfterFunc(retryPeriod, c.notify)
+			}
+		}
+	}
+}
+
+// sync starts or stops the controller so that it is running only while the Feature is activated.
+func (c *Controller) sync(ctx context.Context) error {
+	activated, err := isFeatureActivated(ctx, c.reader, c.featureName)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.observed = true
+	c.activated = activated
+	running := c.running
+	c.mu.Unlock()
+
+	switch {
+	case activated && !running:
+		return c.start(ctx)
+	case !activated && running:
+		c.stop()
+	}
+	return nil
+}
+
+func (c *Controller) start(ctx context.Context) error {
+	ctrl, err := c.newController()
+	if err != nil {
+		return fmt.Errorf("could not create controller %s: %w", c.name, err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	c.mu.Lock()
+	c.running = true
+	c.cancel = cancel
+	c.done = done
+	c.mu.Unlock()
+
+	c.log.Info("Feature is activated, starting controller")
+	go func() {
+		defer close(done)
+		if err := ctrl.Start(runCtx); err != nil {
+			c.log.Error(err, "controller exited with error")
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.done != done {
+			// Stopped on purpose.
+			return
+		}
+		c.running = false
+		c.cancel = nil
+		c.done = nil
+		cancel()
+		time.AfterFunc(retryPeriod, c.notify)
+	}()
+	return nil
+}
+
+func (c *Controller) stop() {
+	c.mu.Lock()
+	cancel, done := c.cancel, c.done
+	c.running = false
+	c.cancel = nil
+	c.done = nil
+	c.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	c.log.Info("Feature is deactivated, stopping controller")
+	cancel()
+	<-done
+}
+
+func (c *Controller) notify() {
+	select {
+	case c.events <- struct{}{}:
+	default:
+		// A sync is already pending.
+	}
+}
+
+func (c *Controller) isGatingFeature(obj interface{}) bool {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	feature, ok := obj.(*corev1alpha2.Feature)
+	return ok && feature.Name == c.featureName
+}
+
+// isFeatureActivated reports whether the named Feature is activated. A Feature that does not exist is treated as
+// deactivated.
+func isFeatureActivated(ctx context.Context, c client.Reader, featureName string) (bool, error) {
+	feature := &corev1alpha2.Feature{}
+	if err := c.Get(ctx, client.ObjectKey{Name: featureName}, feature); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not get Feature %s: %w", featureName, err)
+	}
+	return feature.Status.Activated, nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregated
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+const contextTimeout = 30 * time.Second
+
+// fakeRunnable records how many times it was started and blocks until its context is done.
+type fakeRunnable struct {
+	started chan struct{}
+}
+
+func (f *fakeRunnable) Start(ctx context.Context) error {
+	f.started <- struct{}{}
+	<-ctx.Done()
+	return nil
+}
+
+func newTestFeature(name string, activated bool) *corev1alpha2.Feature {
+	return &corev1alpha2.Feature{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1alpha2.FeatureSpec{Stability: corev1alpha2.TechnicalPreview},
+		Status:     corev1alpha2.FeatureStatus{Activated: activated},
+	}
+}
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add core scheme: (%v)", err)
+	}
+	return s
+}
+
+func newTestController(cl client.Client, runnable *fakeRunnable) *Controller {
+	return &Controller{
+		name:          "foo",
+		featureName:   "foo-feature",
+		reader:        cl,
+		log:           logr.Discard(),
+		events:        make(chan struct{}, 1),
+		newController: func() (manager.Runnable, error) { return runnable, nil },
+	}
+}
+
+func TestControllerSync(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	feature := newTestFeature("foo-feature", false)
+	cl := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(feature).Build()
+	runnable := &fakeRunnable{started: make(chan struct{}, 2)}
+	c := newTestController(cl, runnable)
+
+	if err := c.ReadyzCheck()(nil); err == nil {
+		t.Errorf("expected controller not to be ready before the Feature is observed")
+	}
+
+	if err := c.sync(ctx); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if c.Running() {
+		t.Errorf("controller should not run while Feature is deactivated")
+	}
+	if err := c.ReadyzCheck()(nil); err != nil {
+		t.Errorf("expected controller to be ready, got: %v", err)
+	}
+
+	feature.Status.Activated = true
+	if err := cl.Update(ctx, feature); err != nil {
+		t.Fatalf("update Feature: %v", err)
+	}
+	if err := c.sync(ctx); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	select {
+	case <-runnable.started:
+	case <-ctx.Done():
+		t.Fatalf("controller was not started after Feature was activated")
+	}
+	if !c.Running() {
+		t.Errorf("controller should run while Feature is activated")
+	}
+
+	feature.Status.Activated = false
+	if err := cl.Update(ctx, feature); err != nil {
+		t.Fatalf("update Feature: %v", err)
+	}
+	if err := c.sync(ctx); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if c.Running() {
+		t.Errorf("controller should be stopped after Feature was deactivated")
+	}
+
+	if err := cl.Delete(ctx, feature); err != nil {
+		t.Fatalf("delete Feature: %v", err)
+	}
+	if err := c.sync(ctx); err != nil {
+		t.Fatalf("sync should treat a missing Feature as deactivated, got: %v", err)
+	}
+	if c.Running() {
+		t.Errorf("controller should not run while Feature does not exist")
+	}
+}
+
+func TestControllerRun(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	cl := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(newTestFeature("foo-feature", true)).Build()
+	runnable := &fakeRunnable{started: make(chan struct{}, 1)}
+	c := newTestController(cl, runnable)
+
+	runCtx, stop := context.WithCancel(ctx)
+	exited := make(chan error)
+	go func() { exited <- c.run(runCtx) }()
+
+	select {
+	case <-runnable.started:
+	case <-ctx.Done():
+		t.Fatalf("controller was not started for an activated Feature")
+	}
+
+	stop()
+	select {
+	case err := <-exited:
+		if err != nil {
+			t.Errorf("run returned error: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatalf("run did not return after its context was done")
+	}
+	if c.Running() {
+		t.Errorf("controller should be stopped when the runnable is stopped")
+	}
+}
+
+func TestFeatureToggled(t *testing.T) {
+	tests := []struct {
+		description string
+		oldFeature  *corev1alpha2.Feature
+		newFeature  *corev1alpha2.Feature
+		want        bool
+	}{
+		{
+			description: "activation of gating Feature",
+			oldFeature:  newTestFeature("foo-feature", false),
+			newFeature:  newTestFeature("foo-feature", true),
+			want:        true,
+		},
+		{
+			description: "update of gating Feature without activation change",
+			oldFeature:  newTestFeature("foo-feature", true),
+			newFeature:  newTestFeature("foo-feature", true),
+			want:        false,
+		},
+		{
+			description: "activation of another Feature",
+			oldFeature:  newTestFeature("bar-feature", false),
+			newFeature:  newTestFeature("bar-feature", true),
+			want:        false,
+		},
+	}
+
+	p := FeatureToggled("foo-feature")
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := p.Update(event.UpdateEvent{ObjectOld: tc.oldFeature, ObjectNew: tc.newFeature}); got != tc.want {
+				t.Errorf("got: %t, want: %t", got, tc.want)
+			}
+		})
+	}
+}
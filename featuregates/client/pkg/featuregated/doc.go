@@ -0,0 +1,6 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package featuregated provides controller-runtime builders for controllers and webhooks that only run while a
+// Feature is activated.
+package featuregated
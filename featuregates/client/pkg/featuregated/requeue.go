@@ -0,0 +1,92 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregated
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+var log = logf.Log.WithName("featuregated")
+
+// FeatureToggled returns a predicate that only admits events for the named Feature that change its activation
+// state, that is its creation, its deletion and updates of Status.Activated.
+func FeatureToggled(featureName string) predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return e.Object.GetName() == featureName
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return e.Object.GetName() == featureName
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectNew.GetName() != featureName {
+				return false
+			}
+			oldFeature, ok := e.ObjectOld.(*corev1alpha2.Feature)
+			if !ok {
+				return false
+			}
+			newFeature, ok := e.ObjectNew.(*corev1alpha2.Feature)
+			if !ok {
+				return false
+			}
+			return oldFeature.Status.Activated != newFeature.Status.Activated
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// EnqueueAll returns an event handler that enqueues a request for every object of the given list type, no matter
+// which object the event was for. Combined with FeatureToggled, it requeues all objects of a controller when the
+// Feature it depends on is activated or deactivated:
+//
+//	ctrl.NewControllerManagedBy(mgr).
+//		For(&v1.Foo{}).
+//		Watches(&source.Kind{Type: &corev1alpha2.Feature{}},
+//			featuregated.EnqueueAll(mgr.GetClient(), &v1.FooList{}),
+//			builder.WithPredicates(featuregated.FeatureToggled("foo"))).
+//		Complete(r)
+func EnqueueAll(c client.Reader, list client.ObjectList) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ client.Object) []reconcile.Request {
+		objs, ok := list.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			log.Info("could not copy list", "type", list)
+			return nil
+		}
+		if err := c.List(context.Background(), objs); err != nil {
+			log.Error(err, "could not list objects to requeue")
+			return nil
+		}
+
+		var requests []reconcile.Request
+		if err := meta.EachListItem(objs, func(o runtime.Object) error {
+			obj, ok := o.(client.Object)
+			if !ok {
+				return nil
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()},
+			})
+			return nil
+		}); err != nil {
+			log.Error(err, "could not enqueue objects")
+			return nil
+		}
+		return requests
+	})
+}
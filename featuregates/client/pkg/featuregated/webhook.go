@@ -0,0 +1,131 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregated
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// WebhookBuilder builds admission webhooks that are only enforced while a Feature is activated. While the Feature
+// is deactivated, or does not exist, every request is allowed unchanged.
+type WebhookBuilder struct {
+	mgr         manager.Manager
+	featureName string
+	apiType     runtime.Object
+}
+
+// NewWebhookManagedBy returns a new WebhookBuilder for webhooks gated by the named Feature.
+func NewWebhookManagedBy(mgr manager.Manager, featureName string) *WebhookBuilder {
+	return &WebhookBuilder{mgr: mgr, featureName: featureName}
+}
+
+// For takes the type the webhooks are for. A mutating webhook is registered if it implements admission.Defaulter
+// and a validating webhook is registered if it implements admission.Validator.
+func (b *WebhookBuilder) For(apiType runtime.Object) *WebhookBuilder {
+	b.apiType = apiType
+	return b
+}
+
+// Complete registers the webhooks with the webhook server of the manager, using the same paths as the
+// controller-runtime webhook builder.
+func (b *WebhookBuilder) Complete() error {
+	if b.mgr == nil {
+		return fmt.Errorf("must provide a non-nil Manager")
+	}
+	if b.featureName == "" {
+		return fmt.Errorf("must provide the name of the Feature gating the webhook")
+	}
+	if b.apiType == nil {
+		return fmt.Errorf("must provide the type the webhook is for")
+	}
+
+	gvk, err := apiutil.GVKForObject(b.apiType, b.mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	var registered bool
+	if defaulter, ok := b.apiType.(admission.Defaulter); ok {
+		if err := b.register(generateWebhookPath("mutate", gvk), admission.DefaultingWebhookFor(defaulter)); err != nil {
+			return err
+		}
+		registered = true
+	}
+	if validator, ok := b.apiType.(admission.Validator); ok {
+		if err := b.register(generateWebhookPath("validate", gvk), admission.ValidatingWebhookFor(validator)); err != nil {
+			return err
+		}
+		registered = true
+	}
+	if !registered {
+		return fmt.Errorf("%T implements neither admission.Defaulter nor admission.Validator", b.apiType)
+	}
+	return nil
+}
+
+func (b *WebhookBuilder) register(path string, hook *admission.Webhook) error {
+	server := b.mgr.GetWebhookServer()
+	if h, p := server.WebhookMux.Handler(&http.Request{URL: &url.URL{Path: path}}); p == path && h != nil {
+		return fmt.Errorf("a webhook is already registered for path %s", path)
+	}
+	hook.Handler = GateHandler(b.mgr.GetClient(), b.featureName, hook.Handler)
+	server.Register(path, hook)
+	return nil
+}
+
+func generateWebhookPath(prefix string, gvk schema.GroupVersionKind) string {
+	return "/" + prefix + "-" + strings.ReplaceAll(gvk.Group, ".", "-") + "-" + gvk.Version + "-" + strings.ToLower(gvk.Kind)
+}
+
+// GateHandler wraps an admission.Handler so that it is only invoked while the named Feature is activated. While the
+// Feature is deactivated, or does not exist, requests are allowed without consulting the wrapped handler.
+func GateHandler(c client.Reader, featureName string, h admission.Handler) admission.Handler {
+	return &gatedHandler{reader: c, featureName: featureName, handler: h}
+}
+
+type gatedHandler struct {
+	reader      client.Reader
+	featureName string
+	handler     admission.Handler
+}
+
+var (
+	_ admission.DecoderInjector = &gatedHandler{}
+	_ inject.Injector           = &gatedHandler{}
+)
+
+// Handle implements admission.Handler.
+func (h *gatedHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	activated, err := isFeatureActivated(ctx, h.reader, h.featureName)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if !activated {
+		return admission.Allowed(fmt.Sprintf("Feature %s is not activated", h.featureName))
+	}
+	return h.handler.Handle(ctx, req)
+}
+
+// InjectDecoder passes the decoder on to the wrapped handler.
+func (h *gatedHandler) InjectDecoder(d *admission.Decoder) error {
+	_, err := admission.InjectDecoderInto(d, h.handler)
+	return err
+}
+
+// InjectFunc passes the field setter on to the wrapped handler.
+func (h *gatedHandler) InjectFunc(f inject.Func) error {
+	return f(h.handler)
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featuregated
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// denyAll is an admission handler that denies every request.
+type denyAll struct{}
+
+func (denyAll) Handle(context.Context, admission.Request) admission.Response {
+	return admission.Denied("denied")
+}
+
+func TestGateHandler(t *testing.T) {
+	tests := []struct {
+		description string
+		featureName string
+		wantAllowed bool
+	}{
+		{
+			description: "requests are handled while Feature is activated",
+			featureName: "activated-feature",
+			wantAllowed: false,
+		},
+		{
+			description: "requests are allowed while Feature is deactivated",
+			featureName: "deactivated-feature",
+			wantAllowed: true,
+		},
+		{
+			description: "requests are allowed while Feature does not exist",
+			featureName: "missing-feature",
+			wantAllowed: true,
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		newTestFeature("activated-feature", true),
+		newTestFeature("deactivated-feature", false),
+	).Build()
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			h := GateHandler(cl, tc.featureName, denyAll{})
+			resp := h.Handle(context.Background(), admission.Request{})
+			if resp.Allowed != tc.wantAllowed {
+				t.Errorf("got allowed: %t, want: %t", resp.Allowed, tc.wantAllowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fieldgate provides admission webhooks that enforce API fields gated by Features, as declared with
+// +tanzu:feature field markers and listed in the gated fields manifests generated by the codegen feature generator.
+package fieldgate
@@ -0,0 +1,63 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fieldgate
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// AnyElement is the path segment that matches every element of a list and every value of a map.
+const AnyElement = "*"
+
+// GatedKind lists the fields of a kind that are gated by Features.
+type GatedKind struct {
+	// Group is the API group of the kind.
+	Group string `json:"group"`
+	// Version is the API version of the kind.
+	Version string `json:"version"`
+	// Kind is the name of the kind.
+	Kind string `json:"kind"`
+	// Fields are the gated fields of the kind.
+	Fields []GatedField `json:"fields"`
+}
+
+// GatedField is an API field that may only be set while a Feature is activated.
+type GatedField struct {
+	// Path is the dot separated JSON path of the field from the root of the object, e.g. spec.template.foo.
+	// The segment "*" matches every element of a list and every value of a map.
+	Path string `json:"path"`
+	// Feature is the name of the Feature gating the field.
+	Feature string `json:"feature"`
+}
+
+// GroupVersionKind returns the GroupVersionKind of the gated kind.
+func (k *GatedKind) GroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: k.Group, Version: k.Version, Kind: k.Kind}
+}
+
+// Segments splits the path of the field into its segments.
+func (f GatedField) Segments() []string {
+	return strings.Split(f.Path, ".")
+}
+
+// Parse reads a gated fields manifest.
+func Parse(data []byte) (*GatedKind, error) {
+	kind := &GatedKind{}
+	if err := yaml.UnmarshalStrict(data, kind); err != nil {
+		return nil, fmt.Errorf("could not parse gated fields manifest: %w", err)
+	}
+	if kind.Kind == "" || kind.Version == "" {
+		return nil, fmt.Errorf("gated fields manifest must specify version and kind")
+	}
+	for _, field := range kind.Fields {
+		if field.Path == "" || field.Feature == "" {
+			return nil, fmt.Errorf("gated field of %s must specify path and feature", kind.Kind)
+		}
+	}
+	return kind, nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fieldgate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/util"
+)
+
+// Mode determines how a request setting fields gated by a deactivated Feature is handled.
+type Mode string
+
+const (
+	// Reject denies the request. It is meant for validating webhooks.
+	Reject Mode = "Reject"
+	// Strip removes the gated fields from the object and allows the request. It is meant for mutating webhooks.
+	Strip Mode = "Strip"
+)
+
+// Handler is an admission.Handler that enforces the gated fields of a kind on create and update.
+// Like Kubernetes does for alpha fields, a gated field that is already set in the old object can be kept on update
+// even while its Feature is deactivated, so that deactivating a Feature does not make existing objects immutable.
+type Handler struct {
+	// Client is used to read Features.
+	Client client.Client
+	// Kind lists the gated fields to enforce.
+	Kind *GatedKind
+	// Mode determines whether requests are rejected or have the gated fields stripped.
+	Mode Mode
+}
+
+// NewWebhook returns an admission webhook enforcing the gated fields of kind. It should be registered with the
+// webhook server at the path of a ValidatingWebhookConfiguration in Reject mode, or of a MutatingWebhookConfiguration
+// in Strip mode.
+func NewWebhook(c client.Client, kind *GatedKind, mode Mode) *admission.Webhook {
+	return &admission.Webhook{Handler: &Handler{Client: c, Kind: kind, Mode: mode}}
+}
+
+// Handle implements admission.Handler.
+func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	var oldObj map[string]interface{}
+	if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+		if err := json.Unmarshal(req.OldObject.Raw, &oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	disallowed, err := h.disallowedFields(ctx, obj, oldObj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(disallowed) == 0 {
+		return admission.Allowed("")
+	}
+
+	if h.Mode == Strip {
+		for _, field := range disallowed {
+			removeField(obj, field.Segments())
+		}
+		stripped, err := json.Marshal(obj)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		return admission.PatchResponseFromRaw(req.Object.Raw, stripped)
+	}
+
+	fields := make([]string, 0, len(disallowed))
+	for _, field := range disallowed {
+		fields = append(fields, fmt.Sprintf("%s (Feature %s)", field.Path, field.Feature))
+	}
+	return admission.Denied(fmt.Sprintf("fields gated by deactivated Features cannot be set: %s", strings.Join(fields, ", ")))
+}
+
+// disallowedFields returns the gated fields that are set in obj, were not set in oldObj and whose Feature is
+// deactivated or does not exist.
+func (h *Handler) disallowedFields(ctx context.Context, obj, oldObj map[string]interface{}) ([]GatedField, error) {
+	activated := map[string]bool{}
+	var disallowed []GatedField
+	for _, field := range h.Kind.Fields {
+		segments := field.Segments()
+		if !hasField(obj, segments) || hasField(oldObj, segments) {
+			continue
+		}
+
+		isActivated, found := activated[field.Feature]
+		if !found {
+			var err error
+			isActivated, err = util.IsFeatureActivated(ctx, h.Client, field.Feature)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			activated[field.Feature] = isActivated
+		}
+		if !isActivated {
+			disallowed = append(disallowed, field)
+		}
+	}
+	return disallowed, nil
+}
+
+// hasField checks if a non-null value is set at the path of segments in obj.
+func hasField(obj interface{}, segments []string) bool {
+	if len(segments) == 0 {
+		return obj != nil
+	}
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		if segments[0] != AnyElement {
+			value, ok := v[segments[0]]
+			return ok && hasField(value, segments[1:])
+		}
+		for _, value := range v {
+			if hasField(value, segments[1:]) {
+				return true
+			}
+		}
+	case []interface{}:
+		if segments[0] != AnyElement {
+			return false
+		}
+		for _, value := range v {
+			if hasField(value, segments[1:]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// removeField removes the value at the path of segments from obj.
+func removeField(obj interface{}, segments []string) {
+	if len(segments) == 0 {
+		return
+	}
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		if len(segments) == 1 {
+			delete(v, segments[0])
+			return
+		}
+		if segments[0] != AnyElement {
+			removeField(v[segments[0]], segments[1:])
+			return
+		}
+		for _, value := range v {
+			removeField(value, segments[1:])
+		}
+	case []interface{}:
+		if segments[0] != AnyElement {
+			return
+		}
+		for _, value := range v {
+			removeField(value, segments[1:])
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fieldgate
+
+import (
+	"context"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+)
+
+const widgetManifest = `---
+group: fake.tanzu.vmware.com
+version: v1alpha1
+kind: Widget
+fields:
+- path: spec.color
+  feature: widget-colors
+- path: spec.parts.*.finish
+  feature: widget-finishes
+`
+
+func TestHandle(t *testing.T) {
+	tests := []struct {
+		description string
+		mode        Mode
+		operation   admissionv1.Operation
+		object      string
+		oldObject   string
+		wantAllowed bool
+		wantPatches int
+	}{
+		{
+			description: "object without gated fields is allowed",
+			mode:        Reject,
+			operation:   admissionv1.Create,
+			object:      `{"spec":{"size":1}}`,
+			wantAllowed: true,
+		},
+		{
+			description: "field gated by activated Feature is allowed",
+			mode:        Reject,
+			operation:   admissionv1.Create,
+			object:      `{"spec":{"color":"red"}}`,
+			wantAllowed: true,
+		},
+		{
+			description: "field gated by deactivated Feature is rejected",
+			mode:        Reject,
+			operation:   admissionv1.Create,
+			object:      `{"spec":{"parts":[{"name":"a"},{"name":"b","finish":"matte"}]}}`,
+			wantAllowed: false,
+		},
+		{
+			description: "field gated by deactivated Feature is stripped",
+			mode:        Strip,
+			operation:   admissionv1.Create,
+			object:      `{"spec":{"parts":[{"name":"a"},{"name":"b","finish":"matte"}]}}`,
+			wantAllowed: true,
+			wantPatches: 1,
+		},
+		{
+			description: "field gated by deactivated Feature already set in old object is kept on update",
+			mode:        Reject,
+			operation:   admissionv1.Update,
+			object:      `{"spec":{"parts":[{"name":"b","finish":"glossy"}]}}`,
+			oldObject:   `{"spec":{"parts":[{"name":"b","finish":"matte"}]}}`,
+			wantAllowed: true,
+		},
+		{
+			description: "field gated by deactivated Feature newly set on update is rejected",
+			mode:        Reject,
+			operation:   admissionv1.Update,
+			object:      `{"spec":{"parts":[{"name":"b","finish":"glossy"}]}}`,
+			oldObject:   `{"spec":{"parts":[{"name":"b"}]}}`,
+			wantAllowed: false,
+		},
+		{
+			description: "delete is always allowed",
+			mode:        Reject,
+			operation:   admissionv1.Delete,
+			wantAllowed: true,
+		},
+	}
+
+	kind, err := Parse([]byte(widgetManifest))
+	if err != nil {
+		t.Fatalf("unable to parse manifest: %v", err)
+	}
+
+	s := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add core scheme: (%v)", err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		&corev1alpha2.Feature{
+			ObjectMeta: metav1.ObjectMeta{Name: "widget-colors"},
+			Status:     corev1alpha2.FeatureStatus{Activated: true},
+		},
+		&corev1alpha2.Feature{
+			ObjectMeta: metav1.ObjectMeta{Name: "widget-finishes"},
+			Status:     corev1alpha2.FeatureStatus{Activated: false},
+		},
+	).Build()
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			h := &Handler{Client: cl, Kind: kind, Mode: tc.mode}
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: tc.operation,
+				Object:    runtime.RawExtension{Raw: []byte(tc.object)},
+				OldObject: runtime.RawExtension{Raw: []byte(tc.oldObject)},
+			}}
+
+			resp := h.Handle(context.Background(), req)
+			if resp.Allowed != tc.wantAllowed {
+				t.Errorf("got allowed: %t, want: %t (%v)", resp.Allowed, tc.wantAllowed, resp.Result)
+			}
+			if len(resp.Patches) != tc.wantPatches {
+				t.Errorf("got patches: %v, want %d patches", resp.Patches, tc.wantPatches)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		description string
+		manifest    string
+		wantErr     bool
+	}{
+		{
+			description: "valid manifest",
+			manifest:    widgetManifest,
+		},
+		{
+			description: "manifest without kind",
+			manifest:    "version: v1\nfields: []\n",
+			wantErr:     true,
+		},
+		{
+			description: "field without feature",
+			manifest:    "version: v1\nkind: Widget\nfields:\n- path: spec.color\n",
+			wantErr:     true,
+		},
+		{
+			description: "unknown field",
+			manifest:    "version: v1\nkind: Widget\nfeatures: []\n",
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := Parse([]byte(tc.manifest))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error: %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
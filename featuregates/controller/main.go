@@ -23,6 +23,7 @@ import (
 	configv1alpha1 "github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
 	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
 	coreFeatureController "github.com/vmware-tanzu/tanzu-framework/featuregates/controller/pkg/feature"
+	coreFeatureActivationRequestController "github.com/vmware-tanzu/tanzu-framework/featuregates/controller/pkg/featureactivationrequest"
 	configFeatureGateController "github.com/vmware-tanzu/tanzu-framework/featuregates/controller/pkg/featuregate"
 	"github.com/vmware-tanzu/tanzu-framework/util/buildinfo"
 	"github.com/vmware-tanzu/tanzu-framework/util/webhook/certs"
@@ -69,6 +70,8 @@ func main() {
 		webhookSecretNamespace       string
 		webhookSecretName            string
 		webhookSecretVolumeMountPath string
+		approvalPermission           corev1alpha2.ApprovalPermission
+		activationPermissions        corev1alpha2.ActivationPermissions
 	)
 
 	flag.IntVar(&webhookServerPort, "webhook-server-port", 9443, "The port that the webhook server serves at.")
@@ -80,6 +83,15 @@ func main() {
 	flag.StringVar(&webhookSecretNamespace, "webhook-secret-namespace", defaultWebhookSecretNamespace, "The namespace in which webhook secret is installed.")
 	flag.StringVar(&webhookSecretName, "webhook-secret-name", defaultWebhookSecretName, "The name of the webhook secret.")
 	flag.StringVar(&webhookSecretVolumeMountPath, "webhook-secret-volume-mount-path", defaultWebhookSecretVolumeMountPath, "The filesystem path to which the webhook secret is mounted.")
+	flag.StringVar(&approvalPermission.Verb, "feature-activation-approval-verb", corev1alpha2.DefaultApprovalPermission.Verb, "The verb a user must be allowed on the requested Feature to decide on a FeatureActivationRequest.")
+	flag.StringVar(&approvalPermission.Group, "feature-activation-approval-group", corev1alpha2.DefaultApprovalPermission.Group, "The API group of the resource checked for the approval permission.")
+	flag.StringVar(&approvalPermission.Resource, "feature-activation-approval-resource", corev1alpha2.DefaultApprovalPermission.Resource, "The resource checked for the approval permission, with the name of the requested Feature.")
+	flag.StringVar(&activationPermissions.ActivateVerb, "feature-activate-verb", "", fmt.Sprintf("The verb a user must be allowed on a Feature to activate it in a FeatureGate, such as %q. If empty, activation is not checked.", corev1alpha2.DefaultActivationPermissions.ActivateVerb))
+	flag.StringVar(&activationPermissions.DeactivateVerb, "feature-deactivate-verb", "", fmt.Sprintf("The verb a user must be allowed on a Feature to deactivate it in a FeatureGate, such as %q. If empty, deactivation is not checked.", corev1alpha2.DefaultActivationPermissions.DeactivateVerb))
+	flag.StringVar(&activationPermissions.VoidWarrantyVerb, "feature-void-warranty-verb", "", fmt.Sprintf("The verb a user must be allowed on a Feature to permanently void all support guarantees with it in a FeatureGate, such as %q. If empty, voiding the warranty is not checked.", corev1alpha2.DefaultActivationPermissions.VoidWarrantyVerb))
+	flag.StringVar(&activationPermissions.Group, "feature-activation-permission-group", corev1alpha2.DefaultActivationPermissions.Group, "The API group of the resource checked for the activation permissions.")
+	flag.StringVar(&activationPermissions.Resource, "feature-activation-permission-resource", corev1alpha2.DefaultActivationPermissions.Resource, "The resource checked for the activation permissions, with the name of the changed Feature.")
+	flag.StringVar(&activationPermissions.RequestApplier, "feature-activation-request-applier", "", "The user applying approved FeatureActivationRequests, usually the service account of this controller, such as \"system:serviceaccount:tkg-system:tanzu-featuregates-manager-sa\". If set, other users can only change the activation of Features that are not Stable with an approved FeatureActivationRequest.")
 
 	opts := zap.Options{
 		Development: true,
@@ -125,16 +137,30 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&coreFeatureActivationRequestController.FeatureActivationRequestReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("FeatureActivationRequest").WithValues("apigroup", "core"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FeatureActivationRequest", "apigroup", "core")
+		os.Exit(1)
+	}
+
 	if err = (&configv1alpha1.FeatureGate{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "FeatureGate", "apigroup", "config")
 		os.Exit(1)
 	}
 
-	if err = (&corev1alpha2.FeatureGate{}).SetupWebhookWithManager(mgr); err != nil {
+	if err = (&corev1alpha2.FeatureGate{}).SetupWebhookWithManagerAndPermissions(mgr, activationPermissions); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "FeatureGate", "apigroup", "core")
 		os.Exit(1)
 	}
 
+	if err = (&corev1alpha2.FeatureActivationRequest{}).SetupWebhookWithManagerAndPermission(mgr, approvalPermission); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "FeatureActivationRequest", "apigroup", "core")
+		os.Exit(1)
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	signalHandler := ctrl.SetupSignalHandler()
@@ -0,0 +1,6 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package featureactivationrequest has the controller for FeatureActivationRequest in core API group and is
+// responsible for changing the FeatureGate gating the requested Feature once the request is approved
+package featureactivationrequest
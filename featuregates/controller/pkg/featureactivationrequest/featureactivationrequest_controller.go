@@ -0,0 +1,154 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featureactivationrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/featuregateclient"
+)
+
+const contextTimeout = 30 * time.Second
+
+// FeatureActivationRequestReconciler reconciles a FeatureActivationRequest object.
+type FeatureActivationRequestReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=featureactivationrequests,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=featureactivationrequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=featuregates,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core.tanzu.vmware.com,resources=features,verbs=get;list;watch;activate;deactivate;void-warranty
+
+// Reconcile changes the FeatureGate gating the requested Feature once the request is approved, and reports the
+// outcome in the request status. Rejected, applied and failed requests are not reconciled again.
+func (r *FeatureActivationRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctxCancel, cancel := context.WithTimeout(ctx, contextTimeout)
+	defer cancel()
+
+	log := r.Log.WithValues("featureactivationrequest", req.NamespacedName)
+	log.Info("Starting reconcile")
+
+	request := &corev1alpha2.FeatureActivationRequest{}
+	if err := r.Client.Get(ctxCancel, req.NamespacedName, request); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	switch request.Status.Phase {
+	case corev1alpha2.FeatureActivationRequestRejected, corev1alpha2.FeatureActivationRequestApplied,
+		corev1alpha2.FeatureActivationRequestFailed:
+		return ctrl.Result{}, nil
+	}
+
+	status, err := r.applyDecision(ctxCancel, request)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if status == request.Status {
+		return ctrl.Result{}, nil
+	}
+
+	request.Status = status
+	if err := r.Client.Status().Update(ctxCancel, request); err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not update %s FeatureActivationRequest status :%w", request.Name, err)
+	}
+	log.Info("Updated status", "phase", status.Phase)
+	return ctrl.Result{}, nil
+}
+
+// applyDecision changes the FeatureGate gating the requested Feature if the request is approved, or does not require
+// an approval, and returns the status of the request. An error is only returned when the change should be retried.
+func (r *FeatureActivationRequestReconciler) applyDecision(ctx context.Context, request *corev1alpha2.FeatureActivationRequest) (corev1alpha2.FeatureActivationRequestStatus, error) {
+	decision := request.Spec.Decision
+	if decision == nil {
+		feature := &corev1alpha2.Feature{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: request.Spec.Feature}, feature); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return corev1alpha2.FeatureActivationRequestStatus{}, fmt.Errorf("could not get Feature %s: %w", request.Spec.Feature, err)
+			}
+			return corev1alpha2.FeatureActivationRequestStatus{
+				Phase:   corev1alpha2.FeatureActivationRequestFailed,
+				Message: fmt.Sprintf("Feature %s not found", request.Spec.Feature),
+			}, nil
+		}
+		if request.RequiresApproval(feature) {
+			return corev1alpha2.FeatureActivationRequestStatus{
+				Phase:   corev1alpha2.FeatureActivationRequestPending,
+				Message: "Waiting for a decision",
+			}, nil
+		}
+	} else if decision.Type == corev1alpha2.FeatureActivationRejected {
+		return corev1alpha2.FeatureActivationRequestStatus{
+			Phase:   corev1alpha2.FeatureActivationRequestRejected,
+			Message: decisionMessage("Rejected", decision),
+		}, nil
+	}
+
+	fgClient, err := featuregateclient.NewFeatureGateClient(featuregateclient.WithClient(r.Client))
+	if err != nil {
+		return corev1alpha2.FeatureActivationRequestStatus{}, fmt.Errorf("could not get FeatureGateClient: %w", err)
+	}
+
+	var result *featuregateclient.ActivationResult
+	if request.Spec.Activate {
+		// The webhook only admits an approval of a change that voids the warranty with the acknowledgement of the
+		// approver, and a change that voids the warranty always requires an approval.
+		result, err = fgClient.ActivateFeature(ctx, request.Spec.Feature, decision != nil && decision.PermanentlyVoidAllSupportGuarantees)
+	} else {
+		result, err = fgClient.DeactivateFeature(ctx, request.Spec.Feature)
+	}
+	if err != nil {
+		if !changeFailed(err) {
+			return corev1alpha2.FeatureActivationRequestStatus{}, err
+		}
+		return corev1alpha2.FeatureActivationRequestStatus{
+			Phase:   corev1alpha2.FeatureActivationRequestFailed,
+			Message: err.Error(),
+		}, nil
+	}
+
+	message := fmt.Sprintf("Applied without approval, as Feature %s does not require one", request.Spec.Feature)
+	if decision != nil {
+		message = decisionMessage("Approved", decision)
+	}
+	return corev1alpha2.FeatureActivationRequestStatus{
+		Phase:       corev1alpha2.FeatureActivationRequestApplied,
+		FeatureGate: result.FeatureGate,
+		Message:     message,
+	}, nil
+}
+
+// changeFailed reports whether the error of a FeatureGate change is permanent, so that retrying the change is
+// pointless.
+func changeFailed(err error) bool {
+	return errors.Is(err, featuregateclient.ErrTypeNotFound) || errors.Is(err, featuregateclient.ErrTypeForbidden) ||
+		errors.Is(err, featuregateclient.ErrTypeTooMany) || apierrors.IsInvalid(err)
+}
+
+func decisionMessage(decided string, decision *corev1alpha2.FeatureActivationDecision) string {
+	message := fmt.Sprintf("%s by %s", decided, decision.Approver)
+	if decision.Reason != "" {
+		message = fmt.Sprintf("%s: %s", message, decision.Reason)
+	}
+	return message
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FeatureActivationRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1alpha2.FeatureActivationRequest{}).
+		Complete(r)
+}
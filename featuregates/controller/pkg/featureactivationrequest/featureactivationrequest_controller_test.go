@@ -0,0 +1,190 @@
+// Copyright 2023 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package featureactivationrequest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha2 "github.com/vmware-tanzu/tanzu-framework/apis/core/v1alpha2"
+	"github.com/vmware-tanzu/tanzu-framework/featuregates/client/pkg/util"
+)
+
+func TestReconcile(t *testing.T) {
+	approved := func(voidWarranty bool) *corev1alpha2.FeatureActivationDecision {
+		return &corev1alpha2.FeatureActivationDecision{
+			Type:                                corev1alpha2.FeatureActivationApproved,
+			Approver:                            "admin",
+			PermanentlyVoidAllSupportGuarantees: voidWarranty,
+		}
+	}
+
+	testCases := []struct {
+		description      string
+		feature          string
+		activate         bool
+		decision         *corev1alpha2.FeatureActivationDecision
+		phase            corev1alpha2.FeatureActivationRequestPhase
+		wantPhase        corev1alpha2.FeatureActivationRequestPhase
+		wantMessage      string
+		wantActivate     bool
+		wantVoidWarranty bool
+	}{
+		{
+			description: "request waiting for a decision",
+			feature:     "preview",
+			activate:    true,
+			wantPhase:   corev1alpha2.FeatureActivationRequestPending,
+			wantMessage: "Waiting for a decision",
+		},
+		{
+			description: "request for an experimental Feature waiting for a decision",
+			feature:     "experiment",
+			activate:    false,
+			wantPhase:   corev1alpha2.FeatureActivationRequestPending,
+			wantMessage: "Waiting for a decision",
+		},
+		{
+			description:  "request for a stable Feature not requiring an approval",
+			feature:      "stable",
+			activate:     true,
+			wantPhase:    corev1alpha2.FeatureActivationRequestApplied,
+			wantMessage:  "Applied without approval",
+			wantActivate: true,
+		},
+		{
+			description:  "request to toggle a stable Feature not requiring an approval",
+			feature:      "stable",
+			activate:     false,
+			wantPhase:    corev1alpha2.FeatureActivationRequestFailed,
+			wantMessage:  "cannot be toggled",
+			wantActivate: true,
+		},
+		{
+			description: "request for a Feature that does not exist",
+			feature:     "missing",
+			activate:    true,
+			wantPhase:   corev1alpha2.FeatureActivationRequestFailed,
+			wantMessage: "Feature missing not found",
+		},
+		{
+			description: "rejected request",
+			feature:     "preview",
+			activate:    true,
+			decision:    &corev1alpha2.FeatureActivationDecision{Type: corev1alpha2.FeatureActivationRejected, Approver: "admin", Reason: "not now"},
+			wantPhase:   corev1alpha2.FeatureActivationRequestRejected,
+			wantMessage: "Rejected by admin: not now",
+		},
+		{
+			description:  "approved request",
+			feature:      "preview",
+			activate:     true,
+			decision:     approved(false),
+			wantPhase:    corev1alpha2.FeatureActivationRequestApplied,
+			wantMessage:  "Approved by admin",
+			wantActivate: true,
+		},
+		{
+			description:      "approved request voiding the warranty",
+			feature:          "experiment",
+			activate:         true,
+			decision:         approved(true),
+			wantPhase:        corev1alpha2.FeatureActivationRequestApplied,
+			wantMessage:      "Approved by admin",
+			wantActivate:     true,
+			wantVoidWarranty: true,
+		},
+		{
+			description:  "approved request for an immutable Feature",
+			feature:      "stable",
+			activate:     false,
+			decision:     approved(false),
+			wantPhase:    corev1alpha2.FeatureActivationRequestFailed,
+			wantMessage:  "cannot be toggled",
+			wantActivate: true,
+		},
+		{
+			description: "request already applied",
+			feature:     "preview",
+			activate:    true,
+			decision:    approved(false),
+			phase:       corev1alpha2.FeatureActivationRequestApplied,
+			wantPhase:   corev1alpha2.FeatureActivationRequestApplied,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := corev1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to add scheme: %v", err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			request := &corev1alpha2.FeatureActivationRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "request"},
+				Spec: corev1alpha2.FeatureActivationRequestSpec{
+					Feature:       tc.feature,
+					Activate:      tc.activate,
+					Justification: "needed",
+					Decision:      tc.decision,
+				},
+				Status: corev1alpha2.FeatureActivationRequestStatus{Phase: tc.phase},
+			}
+			feature := func(name string, stability corev1alpha2.StabilityLevel) *corev1alpha2.Feature {
+				return &corev1alpha2.Feature{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: corev1alpha2.FeatureSpec{Stability: stability}}
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+				request,
+				feature("preview", corev1alpha2.TechnicalPreview),
+				feature("experiment", corev1alpha2.Experimental),
+				feature("stable", corev1alpha2.Stable),
+				&corev1alpha2.FeatureGate{
+					ObjectMeta: metav1.ObjectMeta{Name: "policies"},
+					Spec: corev1alpha2.FeatureGateSpec{Features: []corev1alpha2.FeatureReference{
+						{Name: "preview"},
+						{Name: "experiment"},
+						{Name: "stable", Activate: true},
+					}},
+				},
+			).Build()
+
+			r := &FeatureActivationRequestReconciler{Client: c, Log: ctrl.Log, Scheme: scheme}
+			if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "request"}}); err != nil {
+				t.Fatalf("unable to reconcile: %v", err)
+			}
+
+			got := &corev1alpha2.FeatureActivationRequest{}
+			if err := c.Get(ctx, client.ObjectKey{Name: "request"}, got); err != nil {
+				t.Fatalf("unable to get FeatureActivationRequest: %v", err)
+			}
+			if got.Status.Phase != tc.wantPhase {
+				t.Errorf("got phase: %q, want: %q", got.Status.Phase, tc.wantPhase)
+			}
+			if !strings.Contains(got.Status.Message, tc.wantMessage) {
+				t.Errorf("got message: %q, want it to contain: %q", got.Status.Message, tc.wantMessage)
+			}
+
+			gate := &corev1alpha2.FeatureGate{}
+			if err := c.Get(ctx, client.ObjectKey{Name: "policies"}, gate); err != nil {
+				t.Fatalf("unable to get FeatureGate: %v", err)
+			}
+			ref, _ := util.GetFeatureReferenceFromFeatureGate(gate, tc.feature)
+			if ref.Activate != tc.wantActivate || ref.PermanentlyVoidAllSupportGuarantees != tc.wantVoidWarranty {
+				t.Errorf("got reference: %+v, want activate: %t and warranty voided: %t", ref, tc.wantActivate, tc.wantVoidWarranty)
+			}
+			if got.Status.Phase == corev1alpha2.FeatureActivationRequestApplied && tc.phase == "" && got.Status.FeatureGate != "policies" {
+				t.Errorf("got FeatureGate: %q, want: %q", got.Status.FeatureGate, "policies")
+			}
+		})
+	}
+}